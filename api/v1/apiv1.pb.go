@@ -74,6 +74,59 @@ func (UserFlag) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{0}
 }
 
+// Roles which can be assigned to users, see AdminService.SetUserRole.
+type UserRole int32
+
+const (
+	UserRole_USER_ROLE_NONE      UserRole = 0
+	UserRole_USER_ROLE_MEMBER    UserRole = 1
+	UserRole_USER_ROLE_MODERATOR UserRole = 2
+	UserRole_USER_ROLE_ADMIN     UserRole = 3
+)
+
+// Enum value maps for UserRole.
+var (
+	UserRole_name = map[int32]string{
+		0: "USER_ROLE_NONE",
+		1: "USER_ROLE_MEMBER",
+		2: "USER_ROLE_MODERATOR",
+		3: "USER_ROLE_ADMIN",
+	}
+	UserRole_value = map[string]int32{
+		"USER_ROLE_NONE":      0,
+		"USER_ROLE_MEMBER":    1,
+		"USER_ROLE_MODERATOR": 2,
+		"USER_ROLE_ADMIN":     3,
+	}
+)
+
+func (x UserRole) Enum() *UserRole {
+	p := new(UserRole)
+	*p = x
+	return p
+}
+
+func (x UserRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_apiv1_proto_enumTypes[1].Descriptor()
+}
+
+func (UserRole) Type() protoreflect.EnumType {
+	return &file_api_v1_apiv1_proto_enumTypes[1]
+}
+
+func (x UserRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserRole.Descriptor instead.
+func (UserRole) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{1}
+}
+
 type UserStatus int32
 
 const (
@@ -110,11 +163,11 @@ func (x UserStatus) String() string {
 }
 
 func (UserStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_apiv1_proto_enumTypes[1].Descriptor()
+	return file_api_v1_apiv1_proto_enumTypes[2].Descriptor()
 }
 
 func (UserStatus) Type() protoreflect.EnumType {
-	return &file_api_v1_apiv1_proto_enumTypes[1]
+	return &file_api_v1_apiv1_proto_enumTypes[2]
 }
 
 func (x UserStatus) Number() protoreflect.EnumNumber {
@@ -123,7 +176,7 @@ func (x UserStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use UserStatus.Descriptor instead.
 func (UserStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{1}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{2}
 }
 
 type LeaveReason int32
@@ -131,6 +184,7 @@ type LeaveReason int32
 const (
 	LeaveReason_LEAVE_REASON_USER_ACTION  LeaveReason = 0
 	LeaveReason_LEAVE_REASON_DISCONNECTED LeaveReason = 1
+	LeaveReason_LEAVE_REASON_KICKED       LeaveReason = 2
 )
 
 // Enum value maps for LeaveReason.
@@ -138,10 +192,12 @@ var (
 	LeaveReason_name = map[int32]string{
 		0: "LEAVE_REASON_USER_ACTION",
 		1: "LEAVE_REASON_DISCONNECTED",
+		2: "LEAVE_REASON_KICKED",
 	}
 	LeaveReason_value = map[string]int32{
 		"LEAVE_REASON_USER_ACTION":  0,
 		"LEAVE_REASON_DISCONNECTED": 1,
+		"LEAVE_REASON_KICKED":       2,
 	}
 )
 
@@ -156,11 +212,11 @@ func (x LeaveReason) String() string {
 }
 
 func (LeaveReason) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_apiv1_proto_enumTypes[2].Descriptor()
+	return file_api_v1_apiv1_proto_enumTypes[3].Descriptor()
 }
 
 func (LeaveReason) Type() protoreflect.EnumType {
-	return &file_api_v1_apiv1_proto_enumTypes[2]
+	return &file_api_v1_apiv1_proto_enumTypes[3]
 }
 
 func (x LeaveReason) Number() protoreflect.EnumNumber {
@@ -169,7 +225,7 @@ func (x LeaveReason) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LeaveReason.Descriptor instead.
 func (LeaveReason) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{2}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{3}
 }
 
 // //////////////////////////////////////////////////////////////////////////////
@@ -268,6 +324,8 @@ type UserDetails struct {
 	Initials      string                 `protobuf:"bytes,2,opt,name=initials" json:"initials,omitempty"` // max 3 chars
 	Color1        *Color                 `protobuf:"bytes,3,opt,name=color1" json:"color1,omitempty"`     // avatar background
 	Color2        *Color                 `protobuf:"bytes,4,opt,name=color2" json:"color2,omitempty"`     // avatar text
+	Picture       string                 `protobuf:"bytes,5,opt,name=picture" json:"picture,omitempty"`   // avatar picture url, read-only, see UploadAvatar
+	Profile       *Profile               `protobuf:"bytes,6,opt,name=profile" json:"profile,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -330,28 +388,44 @@ func (x *UserDetails) GetColor2() *Color {
 	return nil
 }
 
-type UserMention struct {
+func (x *UserDetails) GetPicture() string {
+	if x != nil {
+		return x.Picture
+	}
+	return ""
+}
+
+func (x *UserDetails) GetProfile() *Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+// Optional details a user shares about itself.
+type Profile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
-	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName" json:"user_name,omitempty"`
+	Pronouns      string                 `protobuf:"bytes,1,opt,name=pronouns" json:"pronouns,omitempty"`                 // max 32 chars
+	Bio           string                 `protobuf:"bytes,2,opt,name=bio" json:"bio,omitempty"`                           // max 160 chars
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone" json:"time_zone,omitempty"` // IANA time zone name, e.g. Europe/Amsterdam
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserMention) Reset() {
-	*x = UserMention{}
+func (x *Profile) Reset() {
+	*x = Profile{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserMention) String() string {
+func (x *Profile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserMention) ProtoMessage() {}
+func (*Profile) ProtoMessage() {}
 
-func (x *UserMention) ProtoReflect() protoreflect.Message {
+func (x *Profile) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -363,47 +437,57 @@ func (x *UserMention) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserMention.ProtoReflect.Descriptor instead.
-func (*UserMention) Descriptor() ([]byte, []int) {
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UserMention) GetUserId() *UUID {
+func (x *Profile) GetPronouns() string {
 	if x != nil {
-		return x.UserId
+		return x.Pronouns
 	}
-	return nil
+	return ""
 }
 
-func (x *UserMention) GetUserName() string {
+func (x *Profile) GetBio() string {
 	if x != nil {
-		return x.UserName
+		return x.Bio
 	}
 	return ""
 }
 
-type ChatID struct {
+func (x *Profile) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// CustomStatus is a free-text status with an optional emoji, which is cleared
+// automatically when it expires.
+type CustomStatus struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReceiverId    *UUID                  `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId" json:"receiver_id,omitempty"` // empty = global chatroom
-	ChatId        *UUID                  `protobuf:"bytes,2,opt,name=chat_id,json=chatId" json:"chat_id,omitempty"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`                            // max 100 chars
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji" json:"emoji,omitempty"`                          // a single emoji
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"` // empty = never expires
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatID) Reset() {
-	*x = ChatID{}
+func (x *CustomStatus) Reset() {
+	*x = CustomStatus{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatID) String() string {
+func (x *CustomStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatID) ProtoMessage() {}
+func (*CustomStatus) ProtoMessage() {}
 
-func (x *ChatID) ProtoReflect() protoreflect.Message {
+func (x *CustomStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -415,47 +499,54 @@ func (x *ChatID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatID.ProtoReflect.Descriptor instead.
-func (*ChatID) Descriptor() ([]byte, []int) {
+// Deprecated: Use CustomStatus.ProtoReflect.Descriptor instead.
+func (*CustomStatus) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ChatID) GetReceiverId() *UUID {
+func (x *CustomStatus) GetText() string {
 	if x != nil {
-		return x.ReceiverId
+		return x.Text
 	}
-	return nil
+	return ""
 }
 
-func (x *ChatID) GetChatId() *UUID {
+func (x *CustomStatus) GetEmoji() string {
 	if x != nil {
-		return x.ChatId
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *CustomStatus) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-type JoinRequest struct {
+type UserMention struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *UserDetails           `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
-	Flags         UserFlag               `protobuf:"varint,2,opt,name=flags,enum=api.v1.UserFlag" json:"flags,omitempty"`
+	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName" json:"user_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinRequest) Reset() {
-	*x = JoinRequest{}
+func (x *UserMention) Reset() {
+	*x = UserMention{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinRequest) String() string {
+func (x *UserMention) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRequest) ProtoMessage() {}
+func (*UserMention) ProtoMessage() {}
 
-func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+func (x *UserMention) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -467,46 +558,47 @@ func (x *JoinRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
-func (*JoinRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserMention.ProtoReflect.Descriptor instead.
+func (*UserMention) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *JoinRequest) GetUser() *UserDetails {
+func (x *UserMention) GetUserId() *UUID {
 	if x != nil {
-		return x.User
+		return x.UserId
 	}
 	return nil
 }
 
-func (x *JoinRequest) GetFlags() UserFlag {
+func (x *UserMention) GetUserName() string {
 	if x != nil {
-		return x.Flags
+		return x.UserName
 	}
-	return UserFlag_USER_FLAG_NONE
+	return ""
 }
 
-type JoinResponse struct {
+type ChatID struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	ReceiverId    *UUID                  `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId" json:"receiver_id,omitempty"` // empty = global chatroom
+	ChatId        *UUID                  `protobuf:"bytes,2,opt,name=chat_id,json=chatId" json:"chat_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinResponse) Reset() {
-	*x = JoinResponse{}
+func (x *ChatID) Reset() {
+	*x = ChatID{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinResponse) String() string {
+func (x *ChatID) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinResponse) ProtoMessage() {}
+func (*ChatID) ProtoMessage() {}
 
-func (x *JoinResponse) ProtoReflect() protoreflect.Message {
+func (x *ChatID) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -518,39 +610,52 @@ func (x *JoinResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinResponse.ProtoReflect.Descriptor instead.
-func (*JoinResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChatID.ProtoReflect.Descriptor instead.
+func (*ChatID) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *JoinResponse) GetToken() string {
+func (x *ChatID) GetReceiverId() *UUID {
 	if x != nil {
-		return x.Token
+		return x.ReceiverId
 	}
-	return ""
+	return nil
 }
 
-type RenewResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+func (x *ChatID) GetChatId() *UUID {
+	if x != nil {
+		return x.ChatId
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	User  *UserDetails           `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	Flags []UserFlag             `protobuf:"varint,2,rep,packed,name=flags,enum=api.v1.UserFlag" json:"flags,omitempty"`
+	// OIDC ID token of the user. The user's name and picture are taken from
+	// the token when provided.
+	IdToken string `protobuf:"bytes,3,opt,name=id_token,json=idToken" json:"id_token,omitempty"`
+	// Solution to the challenge from GetChallenge.
+	Challenge     *ChallengeSolution `protobuf:"bytes,4,opt,name=challenge" json:"challenge,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RenewResponse) Reset() {
-	*x = RenewResponse{}
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RenewResponse) String() string {
+func (x *JoinRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RenewResponse) ProtoMessage() {}
+func (*JoinRequest) ProtoMessage() {}
 
-func (x *RenewResponse) ProtoReflect() protoreflect.Message {
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -562,40 +667,65 @@ func (x *RenewResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RenewResponse.ProtoReflect.Descriptor instead.
-func (*RenewResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *RenewResponse) GetToken() string {
+func (x *JoinRequest) GetUser() *UserDetails {
 	if x != nil {
-		return x.Token
+		return x.User
+	}
+	return nil
+}
+
+func (x *JoinRequest) GetFlags() []UserFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *JoinRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
 	}
 	return ""
 }
 
-type ActiveUsersResponse struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	Time          *timestamppb.Timestamp      `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
-	Users         []*ActiveUsersResponse_User `protobuf:"bytes,2,rep,name=users" json:"users,omitempty"`
+func (x *JoinRequest) GetChallenge() *ChallengeSolution {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+type Challenge struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Server signed nonce, which can be used once.
+	Nonce string `protobuf:"bytes,1,opt,name=nonce" json:"nonce,omitempty"`
+	// Number of leading zero bits the SHA-256 hash of "<nonce>:<counter>"
+	// must have. No solution is required when zero.
+	Difficulty    uint32                 `protobuf:"varint,2,opt,name=difficulty" json:"difficulty,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ActiveUsersResponse) Reset() {
-	*x = ActiveUsersResponse{}
+func (x *Challenge) Reset() {
+	*x = Challenge{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ActiveUsersResponse) String() string {
+func (x *Challenge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActiveUsersResponse) ProtoMessage() {}
+func (*Challenge) ProtoMessage() {}
 
-func (x *ActiveUsersResponse) ProtoReflect() protoreflect.Message {
+func (x *Challenge) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -607,46 +737,54 @@ func (x *ActiveUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActiveUsersResponse.ProtoReflect.Descriptor instead.
-func (*ActiveUsersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Challenge.ProtoReflect.Descriptor instead.
+func (*Challenge) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ActiveUsersResponse) GetTime() *timestamppb.Timestamp {
+func (x *Challenge) GetNonce() string {
 	if x != nil {
-		return x.Time
+		return x.Nonce
 	}
-	return nil
+	return ""
 }
 
-func (x *ActiveUsersResponse) GetUsers() []*ActiveUsersResponse_User {
+func (x *Challenge) GetDifficulty() uint32 {
 	if x != nil {
-		return x.Users
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *Challenge) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-type UpdateDetailsRequest struct {
+type ChallengeSolution struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Details       *UserDetails           `protobuf:"bytes,1,opt,name=details" json:"details,omitempty"`
+	Nonce         string                 `protobuf:"bytes,1,opt,name=nonce" json:"nonce,omitempty"`
+	Counter       uint64                 `protobuf:"varint,2,opt,name=counter" json:"counter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDetailsRequest) Reset() {
-	*x = UpdateDetailsRequest{}
+func (x *ChallengeSolution) Reset() {
+	*x = ChallengeSolution{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDetailsRequest) String() string {
+func (x *ChallengeSolution) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDetailsRequest) ProtoMessage() {}
+func (*ChallengeSolution) ProtoMessage() {}
 
-func (x *UpdateDetailsRequest) ProtoReflect() protoreflect.Message {
+func (x *ChallengeSolution) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -658,39 +796,46 @@ func (x *UpdateDetailsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDetailsRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDetailsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChallengeSolution.ProtoReflect.Descriptor instead.
+func (*ChallengeSolution) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateDetailsRequest) GetDetails() *UserDetails {
+func (x *ChallengeSolution) GetNonce() string {
 	if x != nil {
-		return x.Details
+		return x.Nonce
 	}
-	return nil
+	return ""
 }
 
-type UpdateStatusRequest struct {
+func (x *ChallengeSolution) GetCounter() uint64 {
+	if x != nil {
+		return x.Counter
+	}
+	return 0
+}
+
+type JoinBotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        UserStatus             `protobuf:"varint,1,opt,name=status,enum=api.v1.UserStatus" json:"status,omitempty"`
+	User          *UserDetails           `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateStatusRequest) Reset() {
-	*x = UpdateStatusRequest{}
+func (x *JoinBotRequest) Reset() {
+	*x = JoinBotRequest{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStatusRequest) String() string {
+func (x *JoinBotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStatusRequest) ProtoMessage() {}
+func (*JoinBotRequest) ProtoMessage() {}
 
-func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *JoinBotRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -702,40 +847,42 @@ func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use JoinBotRequest.ProtoReflect.Descriptor instead.
+func (*JoinBotRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateStatusRequest) GetStatus() UserStatus {
+func (x *JoinBotRequest) GetUser() *UserDetails {
 	if x != nil {
-		return x.Status
+		return x.User
 	}
-	return UserStatus_USER_STATUS_DEFAULT
+	return nil
 }
 
-type IndicateTypingRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReceiverId    *UUID                  `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId" json:"receiver_id,omitempty"` // empty = global chatroom
-	Typing        bool                   `protobuf:"varint,2,opt,name=typing" json:"typing,omitempty"`
+type RegisterRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	User     *UserDetails           `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	Password string                 `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	// Solution to the challenge from GetChallenge.
+	Challenge     *ChallengeSolution `protobuf:"bytes,3,opt,name=challenge" json:"challenge,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IndicateTypingRequest) Reset() {
-	*x = IndicateTypingRequest{}
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
 	mi := &file_api_v1_apiv1_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IndicateTypingRequest) String() string {
+func (x *RegisterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndicateTypingRequest) ProtoMessage() {}
+func (*RegisterRequest) ProtoMessage() {}
 
-func (x *IndicateTypingRequest) ProtoReflect() protoreflect.Message {
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_apiv1_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -747,14 +894,675 @@ func (x *IndicateTypingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndicateTypingRequest.ProtoReflect.Descriptor instead.
-func (*IndicateTypingRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *IndicateTypingRequest) GetReceiverId() *UUID {
+func (x *RegisterRequest) GetUser() *UserDetails {
 	if x != nil {
-		return x.ReceiverId
+		return x.User
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetChallenge() *ChallengeSolution {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+type LoginRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Password string                 `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	// Optional details to use, the name is always the account's name.
+	User          *UserDetails `protobuf:"bytes,3,opt,name=user" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LoginRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetUser() *UserDetails {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type JoinResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Short-lived access token.
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	// Long-lived, single-use token which is used to renew the access token.
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken" json:"refresh_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinResponse) Reset() {
+	*x = JoinResponse{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinResponse) ProtoMessage() {}
+
+func (x *JoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinResponse.ProtoReflect.Descriptor instead.
+func (*JoinResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *JoinResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *JoinResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *JoinResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type KeepaliveRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Renewed access token, which extends the lifetime of the user's open
+	// streams.
+	Token         string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeepaliveRequest) Reset() {
+	*x = KeepaliveRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeepaliveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeepaliveRequest) ProtoMessage() {}
+
+func (x *KeepaliveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeepaliveRequest.ProtoReflect.Descriptor instead.
+func (*KeepaliveRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *KeepaliveRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RenewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewRequest) Reset() {
+	*x = RenewRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewRequest) ProtoMessage() {}
+
+func (x *RenewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewRequest.ProtoReflect.Descriptor instead.
+func (*RenewRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RenewRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RenewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken" json:"refresh_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewResponse) Reset() {
+	*x = RenewResponse{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewResponse) ProtoMessage() {}
+
+func (x *RenewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewResponse.ProtoReflect.Descriptor instead.
+func (*RenewResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RenewResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RenewResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RenewResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ActiveUsersResponse struct {
+	state protoimpl.MessageState      `protogen:"open.v1"`
+	Time  *timestamppb.Timestamp      `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+	Users []*ActiveUsersResponse_User `protobuf:"bytes,2,rep,name=users" json:"users,omitempty"`
+	// Users who are typing in the global chatroom or to the requesting user.
+	Typing        []*ActiveUsersResponse_Typing `protobuf:"bytes,3,rep,name=typing" json:"typing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveUsersResponse) Reset() {
+	*x = ActiveUsersResponse{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveUsersResponse) ProtoMessage() {}
+
+func (x *ActiveUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveUsersResponse.ProtoReflect.Descriptor instead.
+func (*ActiveUsersResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ActiveUsersResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ActiveUsersResponse) GetUsers() []*ActiveUsersResponse_User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ActiveUsersResponse) GetTyping() []*ActiveUsersResponse_Typing {
+	if x != nil {
+		return x.Typing
+	}
+	return nil
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix" json:"prefix,omitempty"`                                    // name prefix, ignores case and look-alike characters
+	Flags         []UserFlag             `protobuf:"varint,2,rep,packed,name=flags,enum=api.v1.UserFlag" json:"flags,omitempty"`         // users must have all flags
+	Statuses      []UserStatus           `protobuf:"varint,3,rep,packed,name=statuses,enum=api.v1.UserStatus" json:"statuses,omitempty"` // users must have any of the statuses, empty = any
+	PageSize      uint32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`               // default 50, max 200
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`             // next_page_token of the previous response
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListUsersRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetFlags() []UserFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetStatuses() []UserStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Users         []*ActiveUsersResponse_User `protobuf:"bytes,1,rep,name=users" json:"users,omitempty"`
+	NextPageToken string                      `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken" json:"next_page_token,omitempty"` // empty = last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListUsersResponse) GetUsers() []*ActiveUsersResponse_User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetUserRequest) GetUserId() *UUID {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+type UpdateDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Details       *UserDetails           `protobuf:"bytes,1,opt,name=details" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDetailsRequest) Reset() {
+	*x = UpdateDetailsRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDetailsRequest) ProtoMessage() {}
+
+func (x *UpdateDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDetailsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateDetailsRequest) GetDetails() *UserDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type UpdateStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        UserStatus             `protobuf:"varint,1,opt,name=status,enum=api.v1.UserStatus" json:"status,omitempty"`
+	CustomStatus  *CustomStatus          `protobuf:"bytes,2,opt,name=custom_status,json=customStatus" json:"custom_status,omitempty"` // empty = clear custom status
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStatusRequest) Reset() {
+	*x = UpdateStatusRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStatusRequest) ProtoMessage() {}
+
+func (x *UpdateStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateStatusRequest) GetStatus() UserStatus {
+	if x != nil {
+		return x.Status
+	}
+	return UserStatus_USER_STATUS_DEFAULT
+}
+
+func (x *UpdateStatusRequest) GetCustomStatus() *CustomStatus {
+	if x != nil {
+		return x.CustomStatus
+	}
+	return nil
+}
+
+type IndicateTypingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceiverId    *UUID                  `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId" json:"receiver_id,omitempty"` // empty = global chatroom
+	Typing        bool                   `protobuf:"varint,2,opt,name=typing" json:"typing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndicateTypingRequest) Reset() {
+	*x = IndicateTypingRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndicateTypingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndicateTypingRequest) ProtoMessage() {}
+
+func (x *IndicateTypingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndicateTypingRequest.ProtoReflect.Descriptor instead.
+func (*IndicateTypingRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IndicateTypingRequest) GetReceiverId() *UUID {
+	if x != nil {
+		return x.ReceiverId
 	}
 	return nil
 }
@@ -779,7 +1587,7 @@ type SendChatRequest struct {
 
 func (x *SendChatRequest) Reset() {
 	*x = SendChatRequest{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[12]
+	mi := &file_api_v1_apiv1_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -791,7 +1599,7 @@ func (x *SendChatRequest) String() string {
 func (*SendChatRequest) ProtoMessage() {}
 
 func (x *SendChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[12]
+	mi := &file_api_v1_apiv1_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1612,7 @@ func (x *SendChatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendChatRequest.ProtoReflect.Descriptor instead.
 func (*SendChatRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{12}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *SendChatRequest) GetTime() *timestamppb.Timestamp {
@@ -853,7 +1661,7 @@ type EditChatRequest struct {
 
 func (x *EditChatRequest) Reset() {
 	*x = EditChatRequest{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[13]
+	mi := &file_api_v1_apiv1_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -865,7 +1673,7 @@ func (x *EditChatRequest) String() string {
 func (*EditChatRequest) ProtoMessage() {}
 
 func (x *EditChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[13]
+	mi := &file_api_v1_apiv1_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -878,7 +1686,7 @@ func (x *EditChatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EditChatRequest.ProtoReflect.Descriptor instead.
 func (*EditChatRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{13}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *EditChatRequest) GetTime() *timestamppb.Timestamp {
@@ -914,7 +1722,7 @@ type EmojiReplyRequest struct {
 
 func (x *EmojiReplyRequest) Reset() {
 	*x = EmojiReplyRequest{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[14]
+	mi := &file_api_v1_apiv1_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -926,7 +1734,7 @@ func (x *EmojiReplyRequest) String() string {
 func (*EmojiReplyRequest) ProtoMessage() {}
 
 func (x *EmojiReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[14]
+	mi := &file_api_v1_apiv1_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,7 +1747,7 @@ func (x *EmojiReplyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmojiReplyRequest.ProtoReflect.Descriptor instead.
 func (*EmojiReplyRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{14}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *EmojiReplyRequest) GetTime() *timestamppb.Timestamp {
@@ -956,18 +1764,243 @@ func (x *EmojiReplyRequest) GetChat() *ChatID {
 	return nil
 }
 
-func (x *EmojiReplyRequest) GetEmoji() []byte {
+func (x *EmojiReplyRequest) GetEmoji() []byte {
+	if x != nil {
+		return x.Emoji
+	}
+	return nil
+}
+
+func (x *EmojiReplyRequest) GetAdd() bool {
+	if x != nil {
+		return x.Add
+	}
+	return false
+}
+
+// Chat, typing and emoji events of blocked users are no longer received, and
+// blocked users cannot send direct messages to the user who blocked them.
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BlockUserRequest) GetUserId() *UUID {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnblockUserRequest) GetUserId() *UUID {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+type ListBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []*UUID                `protobuf:"bytes,1,rep,name=user_ids,json=userIds" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListBlockedResponse) GetUserIds() []*UUID {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// The image is re-encoded as png and becomes the user's picture. It should be
+// a png, jpeg or gif image of at most 1 MiB and 1024x1024 pixels, unless
+// configured otherwise.
+type UploadAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         []byte                 `protobuf:"bytes,1,opt,name=image" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarRequest) Reset() {
+	*x = UploadAvatarRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarRequest) ProtoMessage() {}
+
+func (x *UploadAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UploadAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UploadAvatarRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type UploadAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Picture       string                 `protobuf:"bytes,1,opt,name=picture" json:"picture,omitempty"` // url of the avatar picture
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarResponse) Reset() {
+	*x = UploadAvatarResponse{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarResponse) ProtoMessage() {}
+
+func (x *UploadAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[31]
 	if x != nil {
-		return x.Emoji
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *EmojiReplyRequest) GetAdd() bool {
+// Deprecated: Use UploadAvatarResponse.ProtoReflect.Descriptor instead.
+func (*UploadAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UploadAvatarResponse) GetPicture() string {
 	if x != nil {
-		return x.Add
+		return x.Picture
 	}
-	return false
+	return ""
 }
 
 type EventUser struct {
@@ -980,7 +2013,7 @@ type EventUser struct {
 
 func (x *EventUser) Reset() {
 	*x = EventUser{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[15]
+	mi := &file_api_v1_apiv1_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -992,7 +2025,7 @@ func (x *EventUser) String() string {
 func (*EventUser) ProtoMessage() {}
 
 func (x *EventUser) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[15]
+	mi := &file_api_v1_apiv1_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,7 +2038,7 @@ func (x *EventUser) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventUser.ProtoReflect.Descriptor instead.
 func (*EventUser) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{15}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *EventUser) GetId() *UUID {
@@ -1032,7 +2065,7 @@ type PreviousEventsRequest struct {
 
 func (x *PreviousEventsRequest) Reset() {
 	*x = PreviousEventsRequest{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[16]
+	mi := &file_api_v1_apiv1_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1044,7 +2077,7 @@ func (x *PreviousEventsRequest) String() string {
 func (*PreviousEventsRequest) ProtoMessage() {}
 
 func (x *PreviousEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[16]
+	mi := &file_api_v1_apiv1_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1057,7 +2090,7 @@ func (x *PreviousEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PreviousEventsRequest.ProtoReflect.Descriptor instead.
 func (*PreviousEventsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{16}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *PreviousEventsRequest) GetUntilTime() *timestamppb.Timestamp {
@@ -1083,7 +2116,7 @@ type PreviousEventsResponse struct {
 
 func (x *PreviousEventsResponse) Reset() {
 	*x = PreviousEventsResponse{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[17]
+	mi := &file_api_v1_apiv1_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1095,7 +2128,7 @@ func (x *PreviousEventsResponse) String() string {
 func (*PreviousEventsResponse) ProtoMessage() {}
 
 func (x *PreviousEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[17]
+	mi := &file_api_v1_apiv1_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1108,7 +2141,7 @@ func (x *PreviousEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PreviousEventsResponse.ProtoReflect.Descriptor instead.
 func (*PreviousEventsResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{17}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *PreviousEventsResponse) GetHistory() []*PreviousEventsResponse_PreviousEvent {
@@ -1131,7 +2164,7 @@ type EventStreamRequest struct {
 
 func (x *EventStreamRequest) Reset() {
 	*x = EventStreamRequest{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[18]
+	mi := &file_api_v1_apiv1_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1143,7 +2176,7 @@ func (x *EventStreamRequest) String() string {
 func (*EventStreamRequest) ProtoMessage() {}
 
 func (x *EventStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[18]
+	mi := &file_api_v1_apiv1_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1156,7 +2189,7 @@ func (x *EventStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventStreamRequest.ProtoReflect.Descriptor instead.
 func (*EventStreamRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{18}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *EventStreamRequest) GetStream() isEventStreamRequest_Stream {
@@ -1220,7 +2253,7 @@ type EventStreamResponse struct {
 
 func (x *EventStreamResponse) Reset() {
 	*x = EventStreamResponse{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[19]
+	mi := &file_api_v1_apiv1_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1232,7 +2265,7 @@ func (x *EventStreamResponse) String() string {
 func (*EventStreamResponse) ProtoMessage() {}
 
 func (x *EventStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[19]
+	mi := &file_api_v1_apiv1_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1245,7 +2278,7 @@ func (x *EventStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventStreamResponse.ProtoReflect.Descriptor instead.
 func (*EventStreamResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{19}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *EventStreamResponse) GetTime() *timestamppb.Timestamp {
@@ -1390,14 +2423,14 @@ func (*EventStreamResponse_EmojiReply) isEventStreamResponse_Event() {}
 type UserJoinEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *EventUser             `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
-	Flags         UserFlag               `protobuf:"varint,2,opt,name=flags,enum=api.v1.UserFlag" json:"flags,omitempty"`
+	Flags         []UserFlag             `protobuf:"varint,2,rep,packed,name=flags,enum=api.v1.UserFlag" json:"flags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UserJoinEvent) Reset() {
 	*x = UserJoinEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[20]
+	mi := &file_api_v1_apiv1_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1409,7 +2442,7 @@ func (x *UserJoinEvent) String() string {
 func (*UserJoinEvent) ProtoMessage() {}
 
 func (x *UserJoinEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[20]
+	mi := &file_api_v1_apiv1_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1422,7 +2455,7 @@ func (x *UserJoinEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserJoinEvent.ProtoReflect.Descriptor instead.
 func (*UserJoinEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{20}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *UserJoinEvent) GetUser() *EventUser {
@@ -1432,11 +2465,11 @@ func (x *UserJoinEvent) GetUser() *EventUser {
 	return nil
 }
 
-func (x *UserJoinEvent) GetFlags() UserFlag {
+func (x *UserJoinEvent) GetFlags() []UserFlag {
 	if x != nil {
 		return x.Flags
 	}
-	return UserFlag_USER_FLAG_NONE
+	return nil
 }
 
 // User leaves
@@ -1450,7 +2483,7 @@ type UserLeaveEvent struct {
 
 func (x *UserLeaveEvent) Reset() {
 	*x = UserLeaveEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[21]
+	mi := &file_api_v1_apiv1_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1462,7 +2495,7 @@ func (x *UserLeaveEvent) String() string {
 func (*UserLeaveEvent) ProtoMessage() {}
 
 func (x *UserLeaveEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[21]
+	mi := &file_api_v1_apiv1_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1475,7 +2508,7 @@ func (x *UserLeaveEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserLeaveEvent.ProtoReflect.Descriptor instead.
 func (*UserLeaveEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{21}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *UserLeaveEvent) GetUser() *EventUser {
@@ -1503,7 +2536,7 @@ type UserUpdateEvent struct {
 
 func (x *UserUpdateEvent) Reset() {
 	*x = UserUpdateEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[22]
+	mi := &file_api_v1_apiv1_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1515,7 +2548,7 @@ func (x *UserUpdateEvent) String() string {
 func (*UserUpdateEvent) ProtoMessage() {}
 
 func (x *UserUpdateEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[22]
+	mi := &file_api_v1_apiv1_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1528,7 +2561,7 @@ func (x *UserUpdateEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserUpdateEvent.ProtoReflect.Descriptor instead.
 func (*UserUpdateEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{22}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *UserUpdateEvent) GetUser() *EventUser {
@@ -1551,13 +2584,14 @@ type UserStatusEvent struct {
 	User          *EventUser             `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
 	Status        UserStatus             `protobuf:"varint,2,opt,name=status,enum=api.v1.UserStatus" json:"status,omitempty"`
 	Before        UserStatus             `protobuf:"varint,3,opt,name=before,enum=api.v1.UserStatus" json:"before,omitempty"`
+	CustomStatus  *CustomStatus          `protobuf:"bytes,4,opt,name=custom_status,json=customStatus" json:"custom_status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UserStatusEvent) Reset() {
 	*x = UserStatusEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[23]
+	mi := &file_api_v1_apiv1_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1569,7 +2603,7 @@ func (x *UserStatusEvent) String() string {
 func (*UserStatusEvent) ProtoMessage() {}
 
 func (x *UserStatusEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[23]
+	mi := &file_api_v1_apiv1_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1582,7 +2616,7 @@ func (x *UserStatusEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserStatusEvent.ProtoReflect.Descriptor instead.
 func (*UserStatusEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{23}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *UserStatusEvent) GetUser() *EventUser {
@@ -1606,6 +2640,13 @@ func (x *UserStatusEvent) GetBefore() UserStatus {
 	return UserStatus_USER_STATUS_DEFAULT
 }
 
+func (x *UserStatusEvent) GetCustomStatus() *CustomStatus {
+	if x != nil {
+		return x.CustomStatus
+	}
+	return nil
+}
+
 // User is typing a message
 type UserTypingEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1618,7 +2659,7 @@ type UserTypingEvent struct {
 
 func (x *UserTypingEvent) Reset() {
 	*x = UserTypingEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[24]
+	mi := &file_api_v1_apiv1_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1630,7 +2671,7 @@ func (x *UserTypingEvent) String() string {
 func (*UserTypingEvent) ProtoMessage() {}
 
 func (x *UserTypingEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[24]
+	mi := &file_api_v1_apiv1_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1643,7 +2684,7 @@ func (x *UserTypingEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserTypingEvent.ProtoReflect.Descriptor instead.
 func (*UserTypingEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{24}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *UserTypingEvent) GetUser() *EventUser {
@@ -1684,7 +2725,7 @@ type ChatSentEvent struct {
 
 func (x *ChatSentEvent) Reset() {
 	*x = ChatSentEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[25]
+	mi := &file_api_v1_apiv1_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1696,7 +2737,7 @@ func (x *ChatSentEvent) String() string {
 func (*ChatSentEvent) ProtoMessage() {}
 
 func (x *ChatSentEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[25]
+	mi := &file_api_v1_apiv1_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1709,7 +2750,7 @@ func (x *ChatSentEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatSentEvent.ProtoReflect.Descriptor instead.
 func (*ChatSentEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{25}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *ChatSentEvent) GetChatId() *UUID {
@@ -1779,7 +2820,7 @@ type ChatEditEvent struct {
 
 func (x *ChatEditEvent) Reset() {
 	*x = ChatEditEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[26]
+	mi := &file_api_v1_apiv1_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1791,7 +2832,7 @@ func (x *ChatEditEvent) String() string {
 func (*ChatEditEvent) ProtoMessage() {}
 
 func (x *ChatEditEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[26]
+	mi := &file_api_v1_apiv1_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1804,7 +2845,7 @@ func (x *ChatEditEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatEditEvent.ProtoReflect.Descriptor instead.
 func (*ChatEditEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{26}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *ChatEditEvent) GetUser() *EventUser {
@@ -1840,7 +2881,7 @@ type EmojiReplyEvent struct {
 
 func (x *EmojiReplyEvent) Reset() {
 	*x = EmojiReplyEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[27]
+	mi := &file_api_v1_apiv1_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1852,7 +2893,7 @@ func (x *EmojiReplyEvent) String() string {
 func (*EmojiReplyEvent) ProtoMessage() {}
 
 func (x *EmojiReplyEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[27]
+	mi := &file_api_v1_apiv1_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1865,7 +2906,7 @@ func (x *EmojiReplyEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmojiReplyEvent.ProtoReflect.Descriptor instead.
 func (*EmojiReplyEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{27}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *EmojiReplyEvent) GetUser() *EventUser {
@@ -1896,19 +2937,204 @@ func (x *EmojiReplyEvent) GetAdd() bool {
 	return false
 }
 
+type RevokeKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId" json:"key_id,omitempty"` // kid header of the tokens signed with the key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeKeyRequest) Reset() {
+	*x = RevokeKeyRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeKeyRequest) ProtoMessage() {}
+
+func (x *RevokeKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RevokeKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type KickUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KickUserRequest) Reset() {
+	*x = KickUserRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KickUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickUserRequest) ProtoMessage() {}
+
+func (x *KickUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickUserRequest.ProtoReflect.Descriptor instead.
+func (*KickUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *KickUserRequest) GetUserId() *UUID {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+type SetUserRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	Role          UserRole               `protobuf:"varint,2,opt,name=role,enum=api.v1.UserRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleRequest) Reset() {
+	*x = SetUserRoleRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleRequest) ProtoMessage() {}
+
+func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetUserRoleRequest) GetUserId() *UUID {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *SetUserRoleRequest) GetRole() UserRole {
+	if x != nil {
+		return x.Role
+	}
+	return UserRole_USER_ROLE_NONE
+}
+
+type SetChallengeDifficultyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Difficulty    uint32                 `protobuf:"varint,1,opt,name=difficulty" json:"difficulty,omitempty"` // number of leading zero bits, at most 32
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetChallengeDifficultyRequest) Reset() {
+	*x = SetChallengeDifficultyRequest{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChallengeDifficultyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChallengeDifficultyRequest) ProtoMessage() {}
+
+func (x *SetChallengeDifficultyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChallengeDifficultyRequest.ProtoReflect.Descriptor instead.
+func (*SetChallengeDifficultyRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetChallengeDifficultyRequest) GetDifficulty() uint32 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
 type ActiveUsersResponse_User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            *UUID                  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Details       *UserDetails           `protobuf:"bytes,2,opt,name=details" json:"details,omitempty"`
-	Flags         UserFlag               `protobuf:"varint,3,opt,name=flags,enum=api.v1.UserFlag" json:"flags,omitempty"`
+	Flags         []UserFlag             `protobuf:"varint,3,rep,packed,name=flags,enum=api.v1.UserFlag" json:"flags,omitempty"`
 	Status        UserStatus             `protobuf:"varint,4,opt,name=status,enum=api.v1.UserStatus" json:"status,omitempty"`
+	CustomStatus  *CustomStatus          `protobuf:"bytes,5,opt,name=custom_status,json=customStatus" json:"custom_status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ActiveUsersResponse_User) Reset() {
 	*x = ActiveUsersResponse_User{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[28]
+	mi := &file_api_v1_apiv1_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1920,7 +3146,7 @@ func (x *ActiveUsersResponse_User) String() string {
 func (*ActiveUsersResponse_User) ProtoMessage() {}
 
 func (x *ActiveUsersResponse_User) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[28]
+	mi := &file_api_v1_apiv1_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1933,7 +3159,7 @@ func (x *ActiveUsersResponse_User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActiveUsersResponse_User.ProtoReflect.Descriptor instead.
 func (*ActiveUsersResponse_User) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{8, 0}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{17, 0}
 }
 
 func (x *ActiveUsersResponse_User) GetId() *UUID {
@@ -1950,11 +3176,11 @@ func (x *ActiveUsersResponse_User) GetDetails() *UserDetails {
 	return nil
 }
 
-func (x *ActiveUsersResponse_User) GetFlags() UserFlag {
+func (x *ActiveUsersResponse_User) GetFlags() []UserFlag {
 	if x != nil {
 		return x.Flags
 	}
-	return UserFlag_USER_FLAG_NONE
+	return nil
 }
 
 func (x *ActiveUsersResponse_User) GetStatus() UserStatus {
@@ -1964,6 +3190,65 @@ func (x *ActiveUsersResponse_User) GetStatus() UserStatus {
 	return UserStatus_USER_STATUS_DEFAULT
 }
 
+func (x *ActiveUsersResponse_User) GetCustomStatus() *CustomStatus {
+	if x != nil {
+		return x.CustomStatus
+	}
+	return nil
+}
+
+type ActiveUsersResponse_Typing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *UUID                  `protobuf:"bytes,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	ReceiverId    *UUID                  `protobuf:"bytes,2,opt,name=receiver_id,json=receiverId" json:"receiver_id,omitempty"` // empty = global chatroom
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveUsersResponse_Typing) Reset() {
+	*x = ActiveUsersResponse_Typing{}
+	mi := &file_api_v1_apiv1_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveUsersResponse_Typing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveUsersResponse_Typing) ProtoMessage() {}
+
+func (x *ActiveUsersResponse_Typing) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_apiv1_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveUsersResponse_Typing.ProtoReflect.Descriptor instead.
+func (*ActiveUsersResponse_Typing) Descriptor() ([]byte, []int) {
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{17, 1}
+}
+
+func (x *ActiveUsersResponse_Typing) GetUserId() *UUID {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *ActiveUsersResponse_Typing) GetReceiverId() *UUID {
+	if x != nil {
+		return x.ReceiverId
+	}
+	return nil
+}
+
 type PreviousEventsResponse_PreviousEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Time  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
@@ -1980,7 +3265,7 @@ type PreviousEventsResponse_PreviousEvent struct {
 
 func (x *PreviousEventsResponse_PreviousEvent) Reset() {
 	*x = PreviousEventsResponse_PreviousEvent{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[29]
+	mi := &file_api_v1_apiv1_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1992,7 +3277,7 @@ func (x *PreviousEventsResponse_PreviousEvent) String() string {
 func (*PreviousEventsResponse_PreviousEvent) ProtoMessage() {}
 
 func (x *PreviousEventsResponse_PreviousEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[29]
+	mi := &file_api_v1_apiv1_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2005,7 +3290,7 @@ func (x *PreviousEventsResponse_PreviousEvent) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use PreviousEventsResponse_PreviousEvent.ProtoReflect.Descriptor instead.
 func (*PreviousEventsResponse_PreviousEvent) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{17, 0}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{34, 0}
 }
 
 func (x *PreviousEventsResponse_PreviousEvent) GetTime() *timestamppb.Timestamp {
@@ -2100,7 +3385,7 @@ type ChatSentEvent_Edit struct {
 
 func (x *ChatSentEvent_Edit) Reset() {
 	*x = ChatSentEvent_Edit{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[30]
+	mi := &file_api_v1_apiv1_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2112,7 +3397,7 @@ func (x *ChatSentEvent_Edit) String() string {
 func (*ChatSentEvent_Edit) ProtoMessage() {}
 
 func (x *ChatSentEvent_Edit) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[30]
+	mi := &file_api_v1_apiv1_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2125,7 +3410,7 @@ func (x *ChatSentEvent_Edit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatSentEvent_Edit.ProtoReflect.Descriptor instead.
 func (*ChatSentEvent_Edit) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{25, 0}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{42, 0}
 }
 
 func (x *ChatSentEvent_Edit) GetTime() *timestamppb.Timestamp {
@@ -2153,7 +3438,7 @@ type ChatSentEvent_EmojiReply struct {
 
 func (x *ChatSentEvent_EmojiReply) Reset() {
 	*x = ChatSentEvent_EmojiReply{}
-	mi := &file_api_v1_apiv1_proto_msgTypes[31]
+	mi := &file_api_v1_apiv1_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2165,7 +3450,7 @@ func (x *ChatSentEvent_EmojiReply) String() string {
 func (*ChatSentEvent_EmojiReply) ProtoMessage() {}
 
 func (x *ChatSentEvent_EmojiReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_apiv1_proto_msgTypes[31]
+	mi := &file_api_v1_apiv1_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2178,7 +3463,7 @@ func (x *ChatSentEvent_EmojiReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatSentEvent_EmojiReply.ProtoReflect.Descriptor instead.
 func (*ChatSentEvent_EmojiReply) Descriptor() ([]byte, []int) {
-	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{25, 1}
+	return file_api_v1_apiv1_proto_rawDescGZIP(), []int{42, 1}
 }
 
 func (x *ChatSentEvent_EmojiReply) GetTime() *timestamppb.Timestamp {
@@ -2210,38 +3495,100 @@ const file_api_v1_apiv1_proto_rawDesc = "" +
 	"\x04UUID\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\tR\x05value\"\x1d\n" +
 	"\x05Color\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\tR\x05value\"\x8b\x01\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"\xd0\x01\n" +
 	"\vUserDetails\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\binitials\x18\x02 \x01(\tR\binitials\x12%\n" +
 	"\x06color1\x18\x03 \x01(\v2\r.api.v1.ColorR\x06color1\x12%\n" +
-	"\x06color2\x18\x04 \x01(\v2\r.api.v1.ColorR\x06color2\"Q\n" +
+	"\x06color2\x18\x04 \x01(\v2\r.api.v1.ColorR\x06color2\x12\x18\n" +
+	"\apicture\x18\x05 \x01(\tR\apicture\x12)\n" +
+	"\aprofile\x18\x06 \x01(\v2\x0f.api.v1.ProfileR\aprofile\"T\n" +
+	"\aProfile\x12\x1a\n" +
+	"\bpronouns\x18\x01 \x01(\tR\bpronouns\x12\x10\n" +
+	"\x03bio\x18\x02 \x01(\tR\x03bio\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"s\n" +
+	"\fCustomStatus\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"Q\n" +
 	"\vUserMention\x12%\n" +
 	"\auser_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x06userId\x12\x1b\n" +
 	"\tuser_name\x18\x02 \x01(\tR\buserName\"^\n" +
 	"\x06ChatID\x12-\n" +
 	"\vreceiver_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\n" +
 	"receiverId\x12%\n" +
-	"\achat_id\x18\x02 \x01(\v2\f.api.v1.UUIDR\x06chatId\"^\n" +
+	"\achat_id\x18\x02 \x01(\v2\f.api.v1.UUIDR\x06chatId\"\xb2\x01\n" +
 	"\vJoinRequest\x12'\n" +
 	"\x04user\x18\x01 \x01(\v2\x13.api.v1.UserDetailsR\x04user\x12&\n" +
-	"\x05flags\x18\x02 \x01(\x0e2\x10.api.v1.UserFlagR\x05flags\"$\n" +
+	"\x05flags\x18\x02 \x03(\x0e2\x10.api.v1.UserFlagR\x05flags\x12\x19\n" +
+	"\bid_token\x18\x03 \x01(\tR\aidToken\x127\n" +
+	"\tchallenge\x18\x04 \x01(\v2\x19.api.v1.ChallengeSolutionR\tchallenge\"|\n" +
+	"\tChallenge\x12\x14\n" +
+	"\x05nonce\x18\x01 \x01(\tR\x05nonce\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x02 \x01(\rR\n" +
+	"difficulty\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"C\n" +
+	"\x11ChallengeSolution\x12\x14\n" +
+	"\x05nonce\x18\x01 \x01(\tR\x05nonce\x12\x18\n" +
+	"\acounter\x18\x02 \x01(\x04R\acounter\"9\n" +
+	"\x0eJoinBotRequest\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.api.v1.UserDetailsR\x04user\"\x8f\x01\n" +
+	"\x0fRegisterRequest\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.api.v1.UserDetailsR\x04user\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x127\n" +
+	"\tchallenge\x18\x03 \x01(\v2\x19.api.v1.ChallengeSolutionR\tchallenge\"g\n" +
+	"\fLoginRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12'\n" +
+	"\x04user\x18\x03 \x01(\v2\x13.api.v1.UserDetailsR\x04user\"\x84\x01\n" +
 	"\fJoinResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"%\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"(\n" +
+	"\x10KeepaliveRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"3\n" +
+	"\fRenewRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x85\x01\n" +
 	"\rRenewResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\xa7\x02\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xfe\x03\n" +
 	"\x13ActiveUsersResponse\x12.\n" +
 	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x126\n" +
-	"\x05users\x18\x02 \x03(\v2 .api.v1.ActiveUsersResponse.UserR\x05users\x1a\xa7\x01\n" +
+	"\x05users\x18\x02 \x03(\v2 .api.v1.ActiveUsersResponse.UserR\x05users\x12:\n" +
+	"\x06typing\x18\x03 \x03(\v2\".api.v1.ActiveUsersResponse.TypingR\x06typing\x1a\xe2\x01\n" +
 	"\x04User\x12\x1c\n" +
 	"\x02id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x02id\x12-\n" +
 	"\adetails\x18\x02 \x01(\v2\x13.api.v1.UserDetailsR\adetails\x12&\n" +
-	"\x05flags\x18\x03 \x01(\x0e2\x10.api.v1.UserFlagR\x05flags\x12*\n" +
-	"\x06status\x18\x04 \x01(\x0e2\x12.api.v1.UserStatusR\x06status\"E\n" +
+	"\x05flags\x18\x03 \x03(\x0e2\x10.api.v1.UserFlagR\x05flags\x12*\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x12.api.v1.UserStatusR\x06status\x129\n" +
+	"\rcustom_status\x18\x05 \x01(\v2\x14.api.v1.CustomStatusR\fcustomStatus\x1a^\n" +
+	"\x06Typing\x12%\n" +
+	"\auser_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x06userId\x12-\n" +
+	"\vreceiver_id\x18\x02 \x01(\v2\f.api.v1.UUIDR\n" +
+	"receiverId\"\xbe\x01\n" +
+	"\x10ListUsersRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12&\n" +
+	"\x05flags\x18\x02 \x03(\x0e2\x10.api.v1.UserFlagR\x05flags\x12.\n" +
+	"\bstatuses\x18\x03 \x03(\x0e2\x12.api.v1.UserStatusR\bstatuses\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"s\n" +
+	"\x11ListUsersResponse\x126\n" +
+	"\x05users\x18\x01 \x03(\v2 .api.v1.ActiveUsersResponse.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"7\n" +
+	"\x0eGetUserRequest\x12%\n" +
+	"\auser_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x06userId\"E\n" +
 	"\x14UpdateDetailsRequest\x12-\n" +
-	"\adetails\x18\x01 \x01(\v2\x13.api.v1.UserDetailsR\adetails\"A\n" +
+	"\adetails\x18\x01 \x01(\v2\x13.api.v1.UserDetailsR\adetails\"|\n" +
 	"\x13UpdateStatusRequest\x12*\n" +
-	"\x06status\x18\x01 \x01(\x0e2\x12.api.v1.UserStatusR\x06status\"^\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.api.v1.UserStatusR\x06status\x129\n" +
+	"\rcustom_status\x18\x02 \x01(\v2\x14.api.v1.CustomStatusR\fcustomStatus\"^\n" +
 	"\x15IndicateTypingRequest\x12-\n" +
 	"\vreceiver_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\n" +
 	"receiverId\x12\x16\n" +
@@ -2261,7 +3608,17 @@ const file_api_v1_apiv1_proto_rawDesc = "" +
 	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\"\n" +
 	"\x04chat\x18\x02 \x01(\v2\x0e.api.v1.ChatIDR\x04chat\x12\x14\n" +
 	"\x05emoji\x18\x03 \x01(\fR\x05emoji\x12\x10\n" +
-	"\x03add\x18\x04 \x01(\bR\x03add\"X\n" +
+	"\x03add\x18\x04 \x01(\bR\x03add\"9\n" +
+	"\x10BlockUserRequest\x12%\n" +
+	"\auser_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x06userId\";\n" +
+	"\x12UnblockUserRequest\x12%\n" +
+	"\auser_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x06userId\">\n" +
+	"\x13ListBlockedResponse\x12'\n" +
+	"\buser_ids\x18\x01 \x03(\v2\f.api.v1.UUIDR\auserIds\"+\n" +
+	"\x13UploadAvatarRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\fR\x05image\"0\n" +
+	"\x14UploadAvatarResponse\x12\x18\n" +
+	"\apicture\x18\x01 \x01(\tR\apicture\"X\n" +
 	"\tEventUser\x12\x1c\n" +
 	"\x02id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x02id\x12-\n" +
 	"\adetails\x18\x02 \x01(\v2\x13.api.v1.UserDetailsR\adetails\"h\n" +
@@ -2304,17 +3661,18 @@ const file_api_v1_apiv1_proto_rawDesc = "" +
 	"\x05event\"^\n" +
 	"\rUserJoinEvent\x12%\n" +
 	"\x04user\x18\x01 \x01(\v2\x11.api.v1.EventUserR\x04user\x12&\n" +
-	"\x05flags\x18\x02 \x01(\x0e2\x10.api.v1.UserFlagR\x05flags\"d\n" +
+	"\x05flags\x18\x02 \x03(\x0e2\x10.api.v1.UserFlagR\x05flags\"d\n" +
 	"\x0eUserLeaveEvent\x12%\n" +
 	"\x04user\x18\x01 \x01(\v2\x11.api.v1.EventUserR\x04user\x12+\n" +
 	"\x06reason\x18\x02 \x01(\x0e2\x13.api.v1.LeaveReasonR\x06reason\"e\n" +
 	"\x0fUserUpdateEvent\x12%\n" +
 	"\x04user\x18\x01 \x01(\v2\x11.api.v1.EventUserR\x04user\x12+\n" +
-	"\x06before\x18\x02 \x01(\v2\x13.api.v1.UserDetailsR\x06before\"\x90\x01\n" +
+	"\x06before\x18\x02 \x01(\v2\x13.api.v1.UserDetailsR\x06before\"\xcb\x01\n" +
 	"\x0fUserStatusEvent\x12%\n" +
 	"\x04user\x18\x01 \x01(\v2\x11.api.v1.EventUserR\x04user\x12*\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x12.api.v1.UserStatusR\x06status\x12*\n" +
-	"\x06before\x18\x03 \x01(\x0e2\x12.api.v1.UserStatusR\x06before\"\x7f\n" +
+	"\x06before\x18\x03 \x01(\x0e2\x12.api.v1.UserStatusR\x06before\x129\n" +
+	"\rcustom_status\x18\x04 \x01(\v2\x14.api.v1.CustomStatusR\fcustomStatus\"\x7f\n" +
 	"\x0fUserTypingEvent\x12%\n" +
 	"\x04user\x18\x01 \x01(\v2\x11.api.v1.EventUserR\x04user\x12-\n" +
 	"\vreceiver_id\x18\x02 \x01(\v2\f.api.v1.UUIDR\n" +
@@ -2346,27 +3704,50 @@ const file_api_v1_apiv1_proto_rawDesc = "" +
 	"\x04user\x18\x01 \x01(\v2\x11.api.v1.EventUserR\x04user\x12\"\n" +
 	"\x04chat\x18\x02 \x01(\v2\x0e.api.v1.ChatIDR\x04chat\x12\x14\n" +
 	"\x05emoji\x18\x03 \x01(\fR\x05emoji\x12\x10\n" +
-	"\x03add\x18\x04 \x01(\bR\x03add*I\n" +
+	"\x03add\x18\x04 \x01(\bR\x03add\")\n" +
+	"\x10RevokeKeyRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"8\n" +
+	"\x0fKickUserRequest\x12%\n" +
+	"\auser_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x06userId\"a\n" +
+	"\x12SetUserRoleRequest\x12%\n" +
+	"\auser_id\x18\x01 \x01(\v2\f.api.v1.UUIDR\x06userId\x12$\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x10.api.v1.UserRoleR\x04role\"?\n" +
+	"\x1dSetChallengeDifficultyRequest\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x01 \x01(\rR\n" +
+	"difficulty*I\n" +
 	"\bUserFlag\x12\x12\n" +
 	"\x0eUSER_FLAG_NONE\x10\x00\x12\x14\n" +
 	"\x10USER_FLAG_IS_BOT\x10\x01\x12\x13\n" +
-	"\x0fUSER_FLAG_NO_DM\x10\x02*o\n" +
+	"\x0fUSER_FLAG_NO_DM\x10\x02*b\n" +
+	"\bUserRole\x12\x12\n" +
+	"\x0eUSER_ROLE_NONE\x10\x00\x12\x14\n" +
+	"\x10USER_ROLE_MEMBER\x10\x01\x12\x17\n" +
+	"\x13USER_ROLE_MODERATOR\x10\x02\x12\x13\n" +
+	"\x0fUSER_ROLE_ADMIN\x10\x03*o\n" +
 	"\n" +
 	"UserStatus\x12\x17\n" +
 	"\x13USER_STATUS_DEFAULT\x10\x00\x12\x1c\n" +
 	"\x18USER_STATUS_UNRESPONSIVE\x10\x01\x12\x14\n" +
 	"\x10USER_STATUS_BUSY\x10\x02\x12\x14\n" +
-	"\x10USER_STATUS_AWAY\x10\x03*J\n" +
+	"\x10USER_STATUS_AWAY\x10\x03*c\n" +
 	"\vLeaveReason\x12\x1c\n" +
 	"\x18LEAVE_REASON_USER_ACTION\x10\x00\x12\x1d\n" +
-	"\x19LEAVE_REASON_DISCONNECTED\x10\x012\xf8\x01\n" +
-	"\vAuthService\x123\n" +
-	"\x04Join\x12\x13.api.v1.JoinRequest\x1a\x14.api.v1.JoinResponse\"\x00\x12?\n" +
-	"\tKeepalive\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00(\x01\x128\n" +
-	"\x05Renew\x12\x16.google.protobuf.Empty\x1a\x15.api.v1.RenewResponse\"\x00\x129\n" +
-	"\x05Leave\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x002W\n" +
+	"\x19LEAVE_REASON_DISCONNECTED\x10\x01\x12\x17\n" +
+	"\x13LEAVE_REASON_KICKED\x10\x022\xe4\x03\n" +
+	"\vAuthService\x12;\n" +
+	"\fGetChallenge\x12\x16.google.protobuf.Empty\x1a\x11.api.v1.Challenge\"\x00\x123\n" +
+	"\x04Join\x12\x13.api.v1.JoinRequest\x1a\x14.api.v1.JoinResponse\"\x00\x129\n" +
+	"\aJoinBot\x12\x16.api.v1.JoinBotRequest\x1a\x14.api.v1.JoinResponse\"\x00\x12;\n" +
+	"\bRegister\x12\x17.api.v1.RegisterRequest\x1a\x14.api.v1.JoinResponse\"\x00\x125\n" +
+	"\x05Login\x12\x14.api.v1.LoginRequest\x1a\x14.api.v1.JoinResponse\"\x00\x12A\n" +
+	"\tKeepalive\x12\x18.api.v1.KeepaliveRequest\x1a\x16.google.protobuf.Empty\"\x00(\x01\x126\n" +
+	"\x05Renew\x12\x14.api.v1.RenewRequest\x1a\x15.api.v1.RenewResponse\"\x00\x129\n" +
+	"\x05Leave\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x002\xe2\x01\n" +
 	"\x0fRegistryService\x12D\n" +
-	"\vActiveUsers\x12\x16.google.protobuf.Empty\x1a\x1b.api.v1.ActiveUsersResponse\"\x002\xa9\x03\n" +
+	"\vActiveUsers\x12\x16.google.protobuf.Empty\x1a\x1b.api.v1.ActiveUsersResponse\"\x00\x12B\n" +
+	"\tListUsers\x12\x18.api.v1.ListUsersRequest\x1a\x19.api.v1.ListUsersResponse\"\x00\x12E\n" +
+	"\aGetUser\x12\x16.api.v1.GetUserRequest\x1a .api.v1.ActiveUsersResponse.User\"\x002\xc2\x05\n" +
 	"\vUserService\x12G\n" +
 	"\rUpdateDetails\x12\x1c.api.v1.UpdateDetailsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12E\n" +
 	"\fUpdateStatus\x12\x1b.api.v1.UpdateStatusRequest\x1a\x16.google.protobuf.Empty\"\x00\x12I\n" +
@@ -2374,10 +3755,21 @@ const file_api_v1_apiv1_proto_rawDesc = "" +
 	"\bSendChat\x12\x17.api.v1.SendChatRequest\x1a\x16.google.protobuf.Empty\"\x00\x12=\n" +
 	"\bEditChat\x12\x17.api.v1.EditChatRequest\x1a\x16.google.protobuf.Empty\"\x00\x12A\n" +
 	"\n" +
-	"EmojiReply\x12\x19.api.v1.EmojiReplyRequest\x1a\x16.google.protobuf.Empty\"\x002\xaa\x01\n" +
+	"EmojiReply\x12\x19.api.v1.EmojiReplyRequest\x1a\x16.google.protobuf.Empty\"\x00\x12?\n" +
+	"\tBlockUser\x12\x18.api.v1.BlockUserRequest\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
+	"\vUnblockUser\x12\x1a.api.v1.UnblockUserRequest\x1a\x16.google.protobuf.Empty\"\x00\x12D\n" +
+	"\vListBlocked\x12\x16.google.protobuf.Empty\x1a\x1b.api.v1.ListBlockedResponse\"\x00\x12K\n" +
+	"\fUploadAvatar\x12\x1b.api.v1.UploadAvatarRequest\x1a\x1c.api.v1.UploadAvatarResponse\"\x002\xaa\x01\n" +
 	"\rEventsService\x12Q\n" +
 	"\x0ePreviousEvents\x12\x1d.api.v1.PreviousEventsRequest\x1a\x1e.api.v1.PreviousEventsResponse\"\x00\x12F\n" +
-	"\vEventStream\x12\x16.google.protobuf.Empty\x1a\x1b.api.v1.EventStreamResponse\"\x000\x01B4Z-github.com/roeldev/demo-chatroom/api/v1;apiv1\x92\x03\x02\b\x02b\beditionsp\xe8\a"
+	"\vEventStream\x12\x16.google.protobuf.Empty\x1a\x1b.api.v1.EventStreamResponse\"\x000\x012\xee\x02\n" +
+	"\fAdminService\x12>\n" +
+	"\n" +
+	"RotateKeys\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12?\n" +
+	"\tRevokeKey\x12\x18.api.v1.RevokeKeyRequest\x1a\x16.google.protobuf.Empty\"\x00\x12=\n" +
+	"\bKickUser\x12\x17.api.v1.KickUserRequest\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
+	"\vSetUserRole\x12\x1a.api.v1.SetUserRoleRequest\x1a\x16.google.protobuf.Empty\"\x00\x12Y\n" +
+	"\x16SetChallengeDifficulty\x12%.api.v1.SetChallengeDifficultyRequest\x1a\x16.google.protobuf.Empty\"\x00B4Z-github.com/roeldev/demo-chatroom/api/v1;apiv1\x92\x03\x02\b\x02b\beditionsp\xe8\a"
 
 var (
 	file_api_v1_apiv1_proto_rawDescOnce sync.Once
@@ -2391,146 +3783,225 @@ func file_api_v1_apiv1_proto_rawDescGZIP() []byte {
 	return file_api_v1_apiv1_proto_rawDescData
 }
 
-var file_api_v1_apiv1_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_api_v1_apiv1_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_api_v1_apiv1_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_v1_apiv1_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_api_v1_apiv1_proto_goTypes = []any{
 	(UserFlag)(0),                                // 0: api.v1.UserFlag
-	(UserStatus)(0),                              // 1: api.v1.UserStatus
-	(LeaveReason)(0),                             // 2: api.v1.LeaveReason
-	(*UUID)(nil),                                 // 3: api.v1.UUID
-	(*Color)(nil),                                // 4: api.v1.Color
-	(*UserDetails)(nil),                          // 5: api.v1.UserDetails
-	(*UserMention)(nil),                          // 6: api.v1.UserMention
-	(*ChatID)(nil),                               // 7: api.v1.ChatID
-	(*JoinRequest)(nil),                          // 8: api.v1.JoinRequest
-	(*JoinResponse)(nil),                         // 9: api.v1.JoinResponse
-	(*RenewResponse)(nil),                        // 10: api.v1.RenewResponse
-	(*ActiveUsersResponse)(nil),                  // 11: api.v1.ActiveUsersResponse
-	(*UpdateDetailsRequest)(nil),                 // 12: api.v1.UpdateDetailsRequest
-	(*UpdateStatusRequest)(nil),                  // 13: api.v1.UpdateStatusRequest
-	(*IndicateTypingRequest)(nil),                // 14: api.v1.IndicateTypingRequest
-	(*SendChatRequest)(nil),                      // 15: api.v1.SendChatRequest
-	(*EditChatRequest)(nil),                      // 16: api.v1.EditChatRequest
-	(*EmojiReplyRequest)(nil),                    // 17: api.v1.EmojiReplyRequest
-	(*EventUser)(nil),                            // 18: api.v1.EventUser
-	(*PreviousEventsRequest)(nil),                // 19: api.v1.PreviousEventsRequest
-	(*PreviousEventsResponse)(nil),               // 20: api.v1.PreviousEventsResponse
-	(*EventStreamRequest)(nil),                   // 21: api.v1.EventStreamRequest
-	(*EventStreamResponse)(nil),                  // 22: api.v1.EventStreamResponse
-	(*UserJoinEvent)(nil),                        // 23: api.v1.UserJoinEvent
-	(*UserLeaveEvent)(nil),                       // 24: api.v1.UserLeaveEvent
-	(*UserUpdateEvent)(nil),                      // 25: api.v1.UserUpdateEvent
-	(*UserStatusEvent)(nil),                      // 26: api.v1.UserStatusEvent
-	(*UserTypingEvent)(nil),                      // 27: api.v1.UserTypingEvent
-	(*ChatSentEvent)(nil),                        // 28: api.v1.ChatSentEvent
-	(*ChatEditEvent)(nil),                        // 29: api.v1.ChatEditEvent
-	(*EmojiReplyEvent)(nil),                      // 30: api.v1.EmojiReplyEvent
-	(*ActiveUsersResponse_User)(nil),             // 31: api.v1.ActiveUsersResponse.User
-	(*PreviousEventsResponse_PreviousEvent)(nil), // 32: api.v1.PreviousEventsResponse.PreviousEvent
-	(*ChatSentEvent_Edit)(nil),                   // 33: api.v1.ChatSentEvent.Edit
-	(*ChatSentEvent_EmojiReply)(nil),             // 34: api.v1.ChatSentEvent.EmojiReply
-	(*timestamppb.Timestamp)(nil),                // 35: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                        // 36: google.protobuf.Empty
+	(UserRole)(0),                                // 1: api.v1.UserRole
+	(UserStatus)(0),                              // 2: api.v1.UserStatus
+	(LeaveReason)(0),                             // 3: api.v1.LeaveReason
+	(*UUID)(nil),                                 // 4: api.v1.UUID
+	(*Color)(nil),                                // 5: api.v1.Color
+	(*UserDetails)(nil),                          // 6: api.v1.UserDetails
+	(*Profile)(nil),                              // 7: api.v1.Profile
+	(*CustomStatus)(nil),                         // 8: api.v1.CustomStatus
+	(*UserMention)(nil),                          // 9: api.v1.UserMention
+	(*ChatID)(nil),                               // 10: api.v1.ChatID
+	(*JoinRequest)(nil),                          // 11: api.v1.JoinRequest
+	(*Challenge)(nil),                            // 12: api.v1.Challenge
+	(*ChallengeSolution)(nil),                    // 13: api.v1.ChallengeSolution
+	(*JoinBotRequest)(nil),                       // 14: api.v1.JoinBotRequest
+	(*RegisterRequest)(nil),                      // 15: api.v1.RegisterRequest
+	(*LoginRequest)(nil),                         // 16: api.v1.LoginRequest
+	(*JoinResponse)(nil),                         // 17: api.v1.JoinResponse
+	(*KeepaliveRequest)(nil),                     // 18: api.v1.KeepaliveRequest
+	(*RenewRequest)(nil),                         // 19: api.v1.RenewRequest
+	(*RenewResponse)(nil),                        // 20: api.v1.RenewResponse
+	(*ActiveUsersResponse)(nil),                  // 21: api.v1.ActiveUsersResponse
+	(*ListUsersRequest)(nil),                     // 22: api.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),                    // 23: api.v1.ListUsersResponse
+	(*GetUserRequest)(nil),                       // 24: api.v1.GetUserRequest
+	(*UpdateDetailsRequest)(nil),                 // 25: api.v1.UpdateDetailsRequest
+	(*UpdateStatusRequest)(nil),                  // 26: api.v1.UpdateStatusRequest
+	(*IndicateTypingRequest)(nil),                // 27: api.v1.IndicateTypingRequest
+	(*SendChatRequest)(nil),                      // 28: api.v1.SendChatRequest
+	(*EditChatRequest)(nil),                      // 29: api.v1.EditChatRequest
+	(*EmojiReplyRequest)(nil),                    // 30: api.v1.EmojiReplyRequest
+	(*BlockUserRequest)(nil),                     // 31: api.v1.BlockUserRequest
+	(*UnblockUserRequest)(nil),                   // 32: api.v1.UnblockUserRequest
+	(*ListBlockedResponse)(nil),                  // 33: api.v1.ListBlockedResponse
+	(*UploadAvatarRequest)(nil),                  // 34: api.v1.UploadAvatarRequest
+	(*UploadAvatarResponse)(nil),                 // 35: api.v1.UploadAvatarResponse
+	(*EventUser)(nil),                            // 36: api.v1.EventUser
+	(*PreviousEventsRequest)(nil),                // 37: api.v1.PreviousEventsRequest
+	(*PreviousEventsResponse)(nil),               // 38: api.v1.PreviousEventsResponse
+	(*EventStreamRequest)(nil),                   // 39: api.v1.EventStreamRequest
+	(*EventStreamResponse)(nil),                  // 40: api.v1.EventStreamResponse
+	(*UserJoinEvent)(nil),                        // 41: api.v1.UserJoinEvent
+	(*UserLeaveEvent)(nil),                       // 42: api.v1.UserLeaveEvent
+	(*UserUpdateEvent)(nil),                      // 43: api.v1.UserUpdateEvent
+	(*UserStatusEvent)(nil),                      // 44: api.v1.UserStatusEvent
+	(*UserTypingEvent)(nil),                      // 45: api.v1.UserTypingEvent
+	(*ChatSentEvent)(nil),                        // 46: api.v1.ChatSentEvent
+	(*ChatEditEvent)(nil),                        // 47: api.v1.ChatEditEvent
+	(*EmojiReplyEvent)(nil),                      // 48: api.v1.EmojiReplyEvent
+	(*RevokeKeyRequest)(nil),                     // 49: api.v1.RevokeKeyRequest
+	(*KickUserRequest)(nil),                      // 50: api.v1.KickUserRequest
+	(*SetUserRoleRequest)(nil),                   // 51: api.v1.SetUserRoleRequest
+	(*SetChallengeDifficultyRequest)(nil),        // 52: api.v1.SetChallengeDifficultyRequest
+	(*ActiveUsersResponse_User)(nil),             // 53: api.v1.ActiveUsersResponse.User
+	(*ActiveUsersResponse_Typing)(nil),           // 54: api.v1.ActiveUsersResponse.Typing
+	(*PreviousEventsResponse_PreviousEvent)(nil), // 55: api.v1.PreviousEventsResponse.PreviousEvent
+	(*ChatSentEvent_Edit)(nil),                   // 56: api.v1.ChatSentEvent.Edit
+	(*ChatSentEvent_EmojiReply)(nil),             // 57: api.v1.ChatSentEvent.EmojiReply
+	(*timestamppb.Timestamp)(nil),                // 58: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                        // 59: google.protobuf.Empty
 }
 var file_api_v1_apiv1_proto_depIdxs = []int32{
-	4,  // 0: api.v1.UserDetails.color1:type_name -> api.v1.Color
-	4,  // 1: api.v1.UserDetails.color2:type_name -> api.v1.Color
-	3,  // 2: api.v1.UserMention.user_id:type_name -> api.v1.UUID
-	3,  // 3: api.v1.ChatID.receiver_id:type_name -> api.v1.UUID
-	3,  // 4: api.v1.ChatID.chat_id:type_name -> api.v1.UUID
-	5,  // 5: api.v1.JoinRequest.user:type_name -> api.v1.UserDetails
-	0,  // 6: api.v1.JoinRequest.flags:type_name -> api.v1.UserFlag
-	35, // 7: api.v1.ActiveUsersResponse.time:type_name -> google.protobuf.Timestamp
-	31, // 8: api.v1.ActiveUsersResponse.users:type_name -> api.v1.ActiveUsersResponse.User
-	5,  // 9: api.v1.UpdateDetailsRequest.details:type_name -> api.v1.UserDetails
-	1,  // 10: api.v1.UpdateStatusRequest.status:type_name -> api.v1.UserStatus
-	3,  // 11: api.v1.IndicateTypingRequest.receiver_id:type_name -> api.v1.UUID
-	35, // 12: api.v1.SendChatRequest.time:type_name -> google.protobuf.Timestamp
-	3,  // 13: api.v1.SendChatRequest.receiver_id:type_name -> api.v1.UUID
-	3,  // 14: api.v1.SendChatRequest.reply_chat_id:type_name -> api.v1.UUID
-	6,  // 15: api.v1.SendChatRequest.mentions:type_name -> api.v1.UserMention
-	35, // 16: api.v1.EditChatRequest.time:type_name -> google.protobuf.Timestamp
-	7,  // 17: api.v1.EditChatRequest.chat:type_name -> api.v1.ChatID
-	35, // 18: api.v1.EmojiReplyRequest.time:type_name -> google.protobuf.Timestamp
-	7,  // 19: api.v1.EmojiReplyRequest.chat:type_name -> api.v1.ChatID
-	3,  // 20: api.v1.EventUser.id:type_name -> api.v1.UUID
-	5,  // 21: api.v1.EventUser.details:type_name -> api.v1.UserDetails
-	35, // 22: api.v1.PreviousEventsRequest.until_time:type_name -> google.protobuf.Timestamp
-	32, // 23: api.v1.PreviousEventsResponse.history:type_name -> api.v1.PreviousEventsResponse.PreviousEvent
-	35, // 24: api.v1.EventStreamResponse.time:type_name -> google.protobuf.Timestamp
-	23, // 25: api.v1.EventStreamResponse.user_join:type_name -> api.v1.UserJoinEvent
-	24, // 26: api.v1.EventStreamResponse.user_leave:type_name -> api.v1.UserLeaveEvent
-	25, // 27: api.v1.EventStreamResponse.user_update:type_name -> api.v1.UserUpdateEvent
-	26, // 28: api.v1.EventStreamResponse.user_status:type_name -> api.v1.UserStatusEvent
-	27, // 29: api.v1.EventStreamResponse.user_typing:type_name -> api.v1.UserTypingEvent
-	28, // 30: api.v1.EventStreamResponse.chat_sent:type_name -> api.v1.ChatSentEvent
-	29, // 31: api.v1.EventStreamResponse.chat_edit:type_name -> api.v1.ChatEditEvent
-	30, // 32: api.v1.EventStreamResponse.emoji_reply:type_name -> api.v1.EmojiReplyEvent
-	18, // 33: api.v1.UserJoinEvent.user:type_name -> api.v1.EventUser
-	0,  // 34: api.v1.UserJoinEvent.flags:type_name -> api.v1.UserFlag
-	18, // 35: api.v1.UserLeaveEvent.user:type_name -> api.v1.EventUser
-	2,  // 36: api.v1.UserLeaveEvent.reason:type_name -> api.v1.LeaveReason
-	18, // 37: api.v1.UserUpdateEvent.user:type_name -> api.v1.EventUser
-	5,  // 38: api.v1.UserUpdateEvent.before:type_name -> api.v1.UserDetails
-	18, // 39: api.v1.UserStatusEvent.user:type_name -> api.v1.EventUser
-	1,  // 40: api.v1.UserStatusEvent.status:type_name -> api.v1.UserStatus
-	1,  // 41: api.v1.UserStatusEvent.before:type_name -> api.v1.UserStatus
-	18, // 42: api.v1.UserTypingEvent.user:type_name -> api.v1.EventUser
-	3,  // 43: api.v1.UserTypingEvent.receiver_id:type_name -> api.v1.UUID
-	3,  // 44: api.v1.ChatSentEvent.chat_id:type_name -> api.v1.UUID
-	18, // 45: api.v1.ChatSentEvent.user:type_name -> api.v1.EventUser
-	3,  // 46: api.v1.ChatSentEvent.receiver_id:type_name -> api.v1.UUID
-	3,  // 47: api.v1.ChatSentEvent.reply_chat_id:type_name -> api.v1.UUID
-	33, // 48: api.v1.ChatSentEvent.text_edit:type_name -> api.v1.ChatSentEvent.Edit
-	6,  // 49: api.v1.ChatSentEvent.mentions:type_name -> api.v1.UserMention
-	34, // 50: api.v1.ChatSentEvent.emojis:type_name -> api.v1.ChatSentEvent.EmojiReply
-	18, // 51: api.v1.ChatEditEvent.user:type_name -> api.v1.EventUser
-	7,  // 52: api.v1.ChatEditEvent.chat:type_name -> api.v1.ChatID
-	18, // 53: api.v1.EmojiReplyEvent.user:type_name -> api.v1.EventUser
-	7,  // 54: api.v1.EmojiReplyEvent.chat:type_name -> api.v1.ChatID
-	3,  // 55: api.v1.ActiveUsersResponse.User.id:type_name -> api.v1.UUID
-	5,  // 56: api.v1.ActiveUsersResponse.User.details:type_name -> api.v1.UserDetails
-	0,  // 57: api.v1.ActiveUsersResponse.User.flags:type_name -> api.v1.UserFlag
-	1,  // 58: api.v1.ActiveUsersResponse.User.status:type_name -> api.v1.UserStatus
-	35, // 59: api.v1.PreviousEventsResponse.PreviousEvent.time:type_name -> google.protobuf.Timestamp
-	23, // 60: api.v1.PreviousEventsResponse.PreviousEvent.user_join:type_name -> api.v1.UserJoinEvent
-	24, // 61: api.v1.PreviousEventsResponse.PreviousEvent.user_leave:type_name -> api.v1.UserLeaveEvent
-	25, // 62: api.v1.PreviousEventsResponse.PreviousEvent.user_update:type_name -> api.v1.UserUpdateEvent
-	28, // 63: api.v1.PreviousEventsResponse.PreviousEvent.chat_sent:type_name -> api.v1.ChatSentEvent
-	35, // 64: api.v1.ChatSentEvent.Edit.time:type_name -> google.protobuf.Timestamp
-	35, // 65: api.v1.ChatSentEvent.EmojiReply.time:type_name -> google.protobuf.Timestamp
-	18, // 66: api.v1.ChatSentEvent.EmojiReply.user:type_name -> api.v1.EventUser
-	8,  // 67: api.v1.AuthService.Join:input_type -> api.v1.JoinRequest
-	36, // 68: api.v1.AuthService.Keepalive:input_type -> google.protobuf.Empty
-	36, // 69: api.v1.AuthService.Renew:input_type -> google.protobuf.Empty
-	36, // 70: api.v1.AuthService.Leave:input_type -> google.protobuf.Empty
-	36, // 71: api.v1.RegistryService.ActiveUsers:input_type -> google.protobuf.Empty
-	12, // 72: api.v1.UserService.UpdateDetails:input_type -> api.v1.UpdateDetailsRequest
-	13, // 73: api.v1.UserService.UpdateStatus:input_type -> api.v1.UpdateStatusRequest
-	14, // 74: api.v1.UserService.IndicateTyping:input_type -> api.v1.IndicateTypingRequest
-	15, // 75: api.v1.UserService.SendChat:input_type -> api.v1.SendChatRequest
-	16, // 76: api.v1.UserService.EditChat:input_type -> api.v1.EditChatRequest
-	17, // 77: api.v1.UserService.EmojiReply:input_type -> api.v1.EmojiReplyRequest
-	19, // 78: api.v1.EventsService.PreviousEvents:input_type -> api.v1.PreviousEventsRequest
-	36, // 79: api.v1.EventsService.EventStream:input_type -> google.protobuf.Empty
-	9,  // 80: api.v1.AuthService.Join:output_type -> api.v1.JoinResponse
-	36, // 81: api.v1.AuthService.Keepalive:output_type -> google.protobuf.Empty
-	10, // 82: api.v1.AuthService.Renew:output_type -> api.v1.RenewResponse
-	36, // 83: api.v1.AuthService.Leave:output_type -> google.protobuf.Empty
-	11, // 84: api.v1.RegistryService.ActiveUsers:output_type -> api.v1.ActiveUsersResponse
-	36, // 85: api.v1.UserService.UpdateDetails:output_type -> google.protobuf.Empty
-	36, // 86: api.v1.UserService.UpdateStatus:output_type -> google.protobuf.Empty
-	36, // 87: api.v1.UserService.IndicateTyping:output_type -> google.protobuf.Empty
-	36, // 88: api.v1.UserService.SendChat:output_type -> google.protobuf.Empty
-	36, // 89: api.v1.UserService.EditChat:output_type -> google.protobuf.Empty
-	36, // 90: api.v1.UserService.EmojiReply:output_type -> google.protobuf.Empty
-	20, // 91: api.v1.EventsService.PreviousEvents:output_type -> api.v1.PreviousEventsResponse
-	22, // 92: api.v1.EventsService.EventStream:output_type -> api.v1.EventStreamResponse
-	80, // [80:93] is the sub-list for method output_type
-	67, // [67:80] is the sub-list for method input_type
-	67, // [67:67] is the sub-list for extension type_name
-	67, // [67:67] is the sub-list for extension extendee
-	0,  // [0:67] is the sub-list for field type_name
+	5,   // 0: api.v1.UserDetails.color1:type_name -> api.v1.Color
+	5,   // 1: api.v1.UserDetails.color2:type_name -> api.v1.Color
+	7,   // 2: api.v1.UserDetails.profile:type_name -> api.v1.Profile
+	58,  // 3: api.v1.CustomStatus.expires_at:type_name -> google.protobuf.Timestamp
+	4,   // 4: api.v1.UserMention.user_id:type_name -> api.v1.UUID
+	4,   // 5: api.v1.ChatID.receiver_id:type_name -> api.v1.UUID
+	4,   // 6: api.v1.ChatID.chat_id:type_name -> api.v1.UUID
+	6,   // 7: api.v1.JoinRequest.user:type_name -> api.v1.UserDetails
+	0,   // 8: api.v1.JoinRequest.flags:type_name -> api.v1.UserFlag
+	13,  // 9: api.v1.JoinRequest.challenge:type_name -> api.v1.ChallengeSolution
+	58,  // 10: api.v1.Challenge.expires_at:type_name -> google.protobuf.Timestamp
+	6,   // 11: api.v1.JoinBotRequest.user:type_name -> api.v1.UserDetails
+	6,   // 12: api.v1.RegisterRequest.user:type_name -> api.v1.UserDetails
+	13,  // 13: api.v1.RegisterRequest.challenge:type_name -> api.v1.ChallengeSolution
+	6,   // 14: api.v1.LoginRequest.user:type_name -> api.v1.UserDetails
+	58,  // 15: api.v1.JoinResponse.expires_at:type_name -> google.protobuf.Timestamp
+	58,  // 16: api.v1.RenewResponse.expires_at:type_name -> google.protobuf.Timestamp
+	58,  // 17: api.v1.ActiveUsersResponse.time:type_name -> google.protobuf.Timestamp
+	53,  // 18: api.v1.ActiveUsersResponse.users:type_name -> api.v1.ActiveUsersResponse.User
+	54,  // 19: api.v1.ActiveUsersResponse.typing:type_name -> api.v1.ActiveUsersResponse.Typing
+	0,   // 20: api.v1.ListUsersRequest.flags:type_name -> api.v1.UserFlag
+	2,   // 21: api.v1.ListUsersRequest.statuses:type_name -> api.v1.UserStatus
+	53,  // 22: api.v1.ListUsersResponse.users:type_name -> api.v1.ActiveUsersResponse.User
+	4,   // 23: api.v1.GetUserRequest.user_id:type_name -> api.v1.UUID
+	6,   // 24: api.v1.UpdateDetailsRequest.details:type_name -> api.v1.UserDetails
+	2,   // 25: api.v1.UpdateStatusRequest.status:type_name -> api.v1.UserStatus
+	8,   // 26: api.v1.UpdateStatusRequest.custom_status:type_name -> api.v1.CustomStatus
+	4,   // 27: api.v1.IndicateTypingRequest.receiver_id:type_name -> api.v1.UUID
+	58,  // 28: api.v1.SendChatRequest.time:type_name -> google.protobuf.Timestamp
+	4,   // 29: api.v1.SendChatRequest.receiver_id:type_name -> api.v1.UUID
+	4,   // 30: api.v1.SendChatRequest.reply_chat_id:type_name -> api.v1.UUID
+	9,   // 31: api.v1.SendChatRequest.mentions:type_name -> api.v1.UserMention
+	58,  // 32: api.v1.EditChatRequest.time:type_name -> google.protobuf.Timestamp
+	10,  // 33: api.v1.EditChatRequest.chat:type_name -> api.v1.ChatID
+	58,  // 34: api.v1.EmojiReplyRequest.time:type_name -> google.protobuf.Timestamp
+	10,  // 35: api.v1.EmojiReplyRequest.chat:type_name -> api.v1.ChatID
+	4,   // 36: api.v1.BlockUserRequest.user_id:type_name -> api.v1.UUID
+	4,   // 37: api.v1.UnblockUserRequest.user_id:type_name -> api.v1.UUID
+	4,   // 38: api.v1.ListBlockedResponse.user_ids:type_name -> api.v1.UUID
+	4,   // 39: api.v1.EventUser.id:type_name -> api.v1.UUID
+	6,   // 40: api.v1.EventUser.details:type_name -> api.v1.UserDetails
+	58,  // 41: api.v1.PreviousEventsRequest.until_time:type_name -> google.protobuf.Timestamp
+	55,  // 42: api.v1.PreviousEventsResponse.history:type_name -> api.v1.PreviousEventsResponse.PreviousEvent
+	58,  // 43: api.v1.EventStreamResponse.time:type_name -> google.protobuf.Timestamp
+	41,  // 44: api.v1.EventStreamResponse.user_join:type_name -> api.v1.UserJoinEvent
+	42,  // 45: api.v1.EventStreamResponse.user_leave:type_name -> api.v1.UserLeaveEvent
+	43,  // 46: api.v1.EventStreamResponse.user_update:type_name -> api.v1.UserUpdateEvent
+	44,  // 47: api.v1.EventStreamResponse.user_status:type_name -> api.v1.UserStatusEvent
+	45,  // 48: api.v1.EventStreamResponse.user_typing:type_name -> api.v1.UserTypingEvent
+	46,  // 49: api.v1.EventStreamResponse.chat_sent:type_name -> api.v1.ChatSentEvent
+	47,  // 50: api.v1.EventStreamResponse.chat_edit:type_name -> api.v1.ChatEditEvent
+	48,  // 51: api.v1.EventStreamResponse.emoji_reply:type_name -> api.v1.EmojiReplyEvent
+	36,  // 52: api.v1.UserJoinEvent.user:type_name -> api.v1.EventUser
+	0,   // 53: api.v1.UserJoinEvent.flags:type_name -> api.v1.UserFlag
+	36,  // 54: api.v1.UserLeaveEvent.user:type_name -> api.v1.EventUser
+	3,   // 55: api.v1.UserLeaveEvent.reason:type_name -> api.v1.LeaveReason
+	36,  // 56: api.v1.UserUpdateEvent.user:type_name -> api.v1.EventUser
+	6,   // 57: api.v1.UserUpdateEvent.before:type_name -> api.v1.UserDetails
+	36,  // 58: api.v1.UserStatusEvent.user:type_name -> api.v1.EventUser
+	2,   // 59: api.v1.UserStatusEvent.status:type_name -> api.v1.UserStatus
+	2,   // 60: api.v1.UserStatusEvent.before:type_name -> api.v1.UserStatus
+	8,   // 61: api.v1.UserStatusEvent.custom_status:type_name -> api.v1.CustomStatus
+	36,  // 62: api.v1.UserTypingEvent.user:type_name -> api.v1.EventUser
+	4,   // 63: api.v1.UserTypingEvent.receiver_id:type_name -> api.v1.UUID
+	4,   // 64: api.v1.ChatSentEvent.chat_id:type_name -> api.v1.UUID
+	36,  // 65: api.v1.ChatSentEvent.user:type_name -> api.v1.EventUser
+	4,   // 66: api.v1.ChatSentEvent.receiver_id:type_name -> api.v1.UUID
+	4,   // 67: api.v1.ChatSentEvent.reply_chat_id:type_name -> api.v1.UUID
+	56,  // 68: api.v1.ChatSentEvent.text_edit:type_name -> api.v1.ChatSentEvent.Edit
+	9,   // 69: api.v1.ChatSentEvent.mentions:type_name -> api.v1.UserMention
+	57,  // 70: api.v1.ChatSentEvent.emojis:type_name -> api.v1.ChatSentEvent.EmojiReply
+	36,  // 71: api.v1.ChatEditEvent.user:type_name -> api.v1.EventUser
+	10,  // 72: api.v1.ChatEditEvent.chat:type_name -> api.v1.ChatID
+	36,  // 73: api.v1.EmojiReplyEvent.user:type_name -> api.v1.EventUser
+	10,  // 74: api.v1.EmojiReplyEvent.chat:type_name -> api.v1.ChatID
+	4,   // 75: api.v1.KickUserRequest.user_id:type_name -> api.v1.UUID
+	4,   // 76: api.v1.SetUserRoleRequest.user_id:type_name -> api.v1.UUID
+	1,   // 77: api.v1.SetUserRoleRequest.role:type_name -> api.v1.UserRole
+	4,   // 78: api.v1.ActiveUsersResponse.User.id:type_name -> api.v1.UUID
+	6,   // 79: api.v1.ActiveUsersResponse.User.details:type_name -> api.v1.UserDetails
+	0,   // 80: api.v1.ActiveUsersResponse.User.flags:type_name -> api.v1.UserFlag
+	2,   // 81: api.v1.ActiveUsersResponse.User.status:type_name -> api.v1.UserStatus
+	8,   // 82: api.v1.ActiveUsersResponse.User.custom_status:type_name -> api.v1.CustomStatus
+	4,   // 83: api.v1.ActiveUsersResponse.Typing.user_id:type_name -> api.v1.UUID
+	4,   // 84: api.v1.ActiveUsersResponse.Typing.receiver_id:type_name -> api.v1.UUID
+	58,  // 85: api.v1.PreviousEventsResponse.PreviousEvent.time:type_name -> google.protobuf.Timestamp
+	41,  // 86: api.v1.PreviousEventsResponse.PreviousEvent.user_join:type_name -> api.v1.UserJoinEvent
+	42,  // 87: api.v1.PreviousEventsResponse.PreviousEvent.user_leave:type_name -> api.v1.UserLeaveEvent
+	43,  // 88: api.v1.PreviousEventsResponse.PreviousEvent.user_update:type_name -> api.v1.UserUpdateEvent
+	46,  // 89: api.v1.PreviousEventsResponse.PreviousEvent.chat_sent:type_name -> api.v1.ChatSentEvent
+	58,  // 90: api.v1.ChatSentEvent.Edit.time:type_name -> google.protobuf.Timestamp
+	58,  // 91: api.v1.ChatSentEvent.EmojiReply.time:type_name -> google.protobuf.Timestamp
+	36,  // 92: api.v1.ChatSentEvent.EmojiReply.user:type_name -> api.v1.EventUser
+	59,  // 93: api.v1.AuthService.GetChallenge:input_type -> google.protobuf.Empty
+	11,  // 94: api.v1.AuthService.Join:input_type -> api.v1.JoinRequest
+	14,  // 95: api.v1.AuthService.JoinBot:input_type -> api.v1.JoinBotRequest
+	15,  // 96: api.v1.AuthService.Register:input_type -> api.v1.RegisterRequest
+	16,  // 97: api.v1.AuthService.Login:input_type -> api.v1.LoginRequest
+	18,  // 98: api.v1.AuthService.Keepalive:input_type -> api.v1.KeepaliveRequest
+	19,  // 99: api.v1.AuthService.Renew:input_type -> api.v1.RenewRequest
+	59,  // 100: api.v1.AuthService.Leave:input_type -> google.protobuf.Empty
+	59,  // 101: api.v1.RegistryService.ActiveUsers:input_type -> google.protobuf.Empty
+	22,  // 102: api.v1.RegistryService.ListUsers:input_type -> api.v1.ListUsersRequest
+	24,  // 103: api.v1.RegistryService.GetUser:input_type -> api.v1.GetUserRequest
+	25,  // 104: api.v1.UserService.UpdateDetails:input_type -> api.v1.UpdateDetailsRequest
+	26,  // 105: api.v1.UserService.UpdateStatus:input_type -> api.v1.UpdateStatusRequest
+	27,  // 106: api.v1.UserService.IndicateTyping:input_type -> api.v1.IndicateTypingRequest
+	28,  // 107: api.v1.UserService.SendChat:input_type -> api.v1.SendChatRequest
+	29,  // 108: api.v1.UserService.EditChat:input_type -> api.v1.EditChatRequest
+	30,  // 109: api.v1.UserService.EmojiReply:input_type -> api.v1.EmojiReplyRequest
+	31,  // 110: api.v1.UserService.BlockUser:input_type -> api.v1.BlockUserRequest
+	32,  // 111: api.v1.UserService.UnblockUser:input_type -> api.v1.UnblockUserRequest
+	59,  // 112: api.v1.UserService.ListBlocked:input_type -> google.protobuf.Empty
+	34,  // 113: api.v1.UserService.UploadAvatar:input_type -> api.v1.UploadAvatarRequest
+	37,  // 114: api.v1.EventsService.PreviousEvents:input_type -> api.v1.PreviousEventsRequest
+	59,  // 115: api.v1.EventsService.EventStream:input_type -> google.protobuf.Empty
+	59,  // 116: api.v1.AdminService.RotateKeys:input_type -> google.protobuf.Empty
+	49,  // 117: api.v1.AdminService.RevokeKey:input_type -> api.v1.RevokeKeyRequest
+	50,  // 118: api.v1.AdminService.KickUser:input_type -> api.v1.KickUserRequest
+	51,  // 119: api.v1.AdminService.SetUserRole:input_type -> api.v1.SetUserRoleRequest
+	52,  // 120: api.v1.AdminService.SetChallengeDifficulty:input_type -> api.v1.SetChallengeDifficultyRequest
+	12,  // 121: api.v1.AuthService.GetChallenge:output_type -> api.v1.Challenge
+	17,  // 122: api.v1.AuthService.Join:output_type -> api.v1.JoinResponse
+	17,  // 123: api.v1.AuthService.JoinBot:output_type -> api.v1.JoinResponse
+	17,  // 124: api.v1.AuthService.Register:output_type -> api.v1.JoinResponse
+	17,  // 125: api.v1.AuthService.Login:output_type -> api.v1.JoinResponse
+	59,  // 126: api.v1.AuthService.Keepalive:output_type -> google.protobuf.Empty
+	20,  // 127: api.v1.AuthService.Renew:output_type -> api.v1.RenewResponse
+	59,  // 128: api.v1.AuthService.Leave:output_type -> google.protobuf.Empty
+	21,  // 129: api.v1.RegistryService.ActiveUsers:output_type -> api.v1.ActiveUsersResponse
+	23,  // 130: api.v1.RegistryService.ListUsers:output_type -> api.v1.ListUsersResponse
+	53,  // 131: api.v1.RegistryService.GetUser:output_type -> api.v1.ActiveUsersResponse.User
+	59,  // 132: api.v1.UserService.UpdateDetails:output_type -> google.protobuf.Empty
+	59,  // 133: api.v1.UserService.UpdateStatus:output_type -> google.protobuf.Empty
+	59,  // 134: api.v1.UserService.IndicateTyping:output_type -> google.protobuf.Empty
+	59,  // 135: api.v1.UserService.SendChat:output_type -> google.protobuf.Empty
+	59,  // 136: api.v1.UserService.EditChat:output_type -> google.protobuf.Empty
+	59,  // 137: api.v1.UserService.EmojiReply:output_type -> google.protobuf.Empty
+	59,  // 138: api.v1.UserService.BlockUser:output_type -> google.protobuf.Empty
+	59,  // 139: api.v1.UserService.UnblockUser:output_type -> google.protobuf.Empty
+	33,  // 140: api.v1.UserService.ListBlocked:output_type -> api.v1.ListBlockedResponse
+	35,  // 141: api.v1.UserService.UploadAvatar:output_type -> api.v1.UploadAvatarResponse
+	38,  // 142: api.v1.EventsService.PreviousEvents:output_type -> api.v1.PreviousEventsResponse
+	40,  // 143: api.v1.EventsService.EventStream:output_type -> api.v1.EventStreamResponse
+	59,  // 144: api.v1.AdminService.RotateKeys:output_type -> google.protobuf.Empty
+	59,  // 145: api.v1.AdminService.RevokeKey:output_type -> google.protobuf.Empty
+	59,  // 146: api.v1.AdminService.KickUser:output_type -> google.protobuf.Empty
+	59,  // 147: api.v1.AdminService.SetUserRole:output_type -> google.protobuf.Empty
+	59,  // 148: api.v1.AdminService.SetChallengeDifficulty:output_type -> google.protobuf.Empty
+	121, // [121:149] is the sub-list for method output_type
+	93,  // [93:121] is the sub-list for method input_type
+	93,  // [93:93] is the sub-list for extension type_name
+	93,  // [93:93] is the sub-list for extension extendee
+	0,   // [0:93] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_apiv1_proto_init() }
@@ -2538,11 +4009,11 @@ func file_api_v1_apiv1_proto_init() {
 	if File_api_v1_apiv1_proto != nil {
 		return
 	}
-	file_api_v1_apiv1_proto_msgTypes[18].OneofWrappers = []any{
+	file_api_v1_apiv1_proto_msgTypes[35].OneofWrappers = []any{
 		(*EventStreamRequest_Start)(nil),
 		(*EventStreamRequest_Ack)(nil),
 	}
-	file_api_v1_apiv1_proto_msgTypes[19].OneofWrappers = []any{
+	file_api_v1_apiv1_proto_msgTypes[36].OneofWrappers = []any{
 		(*EventStreamResponse_UserJoin)(nil),
 		(*EventStreamResponse_UserLeave)(nil),
 		(*EventStreamResponse_UserUpdate)(nil),
@@ -2552,7 +4023,7 @@ func file_api_v1_apiv1_proto_init() {
 		(*EventStreamResponse_ChatEdit)(nil),
 		(*EventStreamResponse_EmojiReply)(nil),
 	}
-	file_api_v1_apiv1_proto_msgTypes[29].OneofWrappers = []any{
+	file_api_v1_apiv1_proto_msgTypes[51].OneofWrappers = []any{
 		(*PreviousEventsResponse_PreviousEvent_UserJoin)(nil),
 		(*PreviousEventsResponse_PreviousEvent_UserLeave)(nil),
 		(*PreviousEventsResponse_PreviousEvent_UserUpdate)(nil),
@@ -2563,10 +4034,10 @@ func file_api_v1_apiv1_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_apiv1_proto_rawDesc), len(file_api_v1_apiv1_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   32,
+			NumEnums:      4,
+			NumMessages:   54,
 			NumExtensions: 0,
-			NumServices:   4,
+			NumServices:   5,
 		},
 		GoTypes:           file_api_v1_apiv1_proto_goTypes,
 		DependencyIndexes: file_api_v1_apiv1_proto_depIdxs,
@@ -0,0 +1,128 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/api/v1"
+	"github.com/roeldev/demo-chatroom/chatauth"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var _ AdminServiceHandler = (*AdminService)(nil)
+
+// Administrator performs the operations of the [AdminService].
+type Administrator interface {
+	RotateKeys() error
+	RevokeKey(kid string) error
+	KickUser(uid chatusers.UserID) error
+	SetUserRole(uid chatusers.UserID, role chatusers.Role) error
+	SetChallengeDifficulty(difficulty uint8)
+}
+
+type AdminService struct {
+	log   zerolog.Logger
+	admin Administrator
+}
+
+func NewAdminService(log zerolog.Logger, admin Administrator) *AdminService {
+	return &AdminService{
+		log:   log,
+		admin: admin,
+	}
+}
+
+func (svc *AdminService) RotateKeys(ctx context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+	if err := svc.admin.RotateKeys(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	svc.log.Info().
+		Stringer("admin", getUser(ctx)).
+		Msg("admin rotated signing keys")
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (svc *AdminService) RevokeKey(ctx context.Context, req *connect.Request[apiv1.RevokeKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := svc.admin.RevokeKey(req.Msg.KeyId); err != nil {
+		if errors.Is(err, chatauth.ErrUnknownKeyID) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	svc.log.Warn().
+		Stringer("admin", getUser(ctx)).
+		Str("kid", req.Msg.KeyId).
+		Msg("admin revoked signing key")
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (svc *AdminService) KickUser(ctx context.Context, req *connect.Request[apiv1.KickUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	uid, err := req.Msg.UserId.ParseUUID()
+	if err != nil || uid == uuid.Nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(ErrInvalidUserID))
+	}
+	if err = svc.admin.KickUser(uid); err != nil {
+		if errors.Is(err, chatusers.ErrUserNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	svc.log.Info().
+		Stringer("admin", getUser(ctx)).
+		Stringer("user_id", uid).
+		Msg("admin kicked user")
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (svc *AdminService) SetUserRole(ctx context.Context, req *connect.Request[apiv1.SetUserRoleRequest]) (*connect.Response[emptypb.Empty], error) {
+	uid, err := req.Msg.UserId.ParseUUID()
+	if err != nil || uid == uuid.Nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(ErrInvalidUserID))
+	}
+	role, err := req.Msg.Role.ToChatUserRole()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err = svc.admin.SetUserRole(uid, role); err != nil {
+		if errors.Is(err, chatusers.ErrUserNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	svc.log.Info().
+		Stringer("admin", getUser(ctx)).
+		Stringer("user_id", uid).
+		Stringer("role", role).
+		Msg("admin changed user role")
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (svc *AdminService) SetChallengeDifficulty(ctx context.Context, req *connect.Request[apiv1.SetChallengeDifficultyRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Difficulty > chatauth.MaxChallengeDifficulty {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(ErrInvalidDifficulty))
+	}
+
+	svc.admin.SetChallengeDifficulty(uint8(req.Msg.Difficulty))
+	svc.log.Info().
+		Stringer("admin", getUser(ctx)).
+		Uint32("difficulty", req.Msg.Difficulty).
+		Msg("admin changed challenge difficulty")
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
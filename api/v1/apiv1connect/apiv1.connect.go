@@ -30,6 +30,8 @@ const (
 	UserServiceName = "api.v1.UserService"
 	// EventsServiceName is the fully-qualified name of the EventsService service.
 	EventsServiceName = "api.v1.EventsService"
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "api.v1.AdminService"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -40,8 +42,17 @@ const (
 // reflection-formatted method names, remove the leading slash and convert the remaining slash to a
 // period.
 const (
+	// AuthServiceGetChallengeProcedure is the fully-qualified name of the AuthService's GetChallenge
+	// RPC.
+	AuthServiceGetChallengeProcedure = "/api.v1.AuthService/GetChallenge"
 	// AuthServiceJoinProcedure is the fully-qualified name of the AuthService's Join RPC.
 	AuthServiceJoinProcedure = "/api.v1.AuthService/Join"
+	// AuthServiceJoinBotProcedure is the fully-qualified name of the AuthService's JoinBot RPC.
+	AuthServiceJoinBotProcedure = "/api.v1.AuthService/JoinBot"
+	// AuthServiceRegisterProcedure is the fully-qualified name of the AuthService's Register RPC.
+	AuthServiceRegisterProcedure = "/api.v1.AuthService/Register"
+	// AuthServiceLoginProcedure is the fully-qualified name of the AuthService's Login RPC.
+	AuthServiceLoginProcedure = "/api.v1.AuthService/Login"
 	// AuthServiceKeepaliveProcedure is the fully-qualified name of the AuthService's Keepalive RPC.
 	AuthServiceKeepaliveProcedure = "/api.v1.AuthService/Keepalive"
 	// AuthServiceRenewProcedure is the fully-qualified name of the AuthService's Renew RPC.
@@ -51,6 +62,11 @@ const (
 	// RegistryServiceActiveUsersProcedure is the fully-qualified name of the RegistryService's
 	// ActiveUsers RPC.
 	RegistryServiceActiveUsersProcedure = "/api.v1.RegistryService/ActiveUsers"
+	// RegistryServiceListUsersProcedure is the fully-qualified name of the RegistryService's ListUsers
+	// RPC.
+	RegistryServiceListUsersProcedure = "/api.v1.RegistryService/ListUsers"
+	// RegistryServiceGetUserProcedure is the fully-qualified name of the RegistryService's GetUser RPC.
+	RegistryServiceGetUserProcedure = "/api.v1.RegistryService/GetUser"
 	// UserServiceUpdateDetailsProcedure is the fully-qualified name of the UserService's UpdateDetails
 	// RPC.
 	UserServiceUpdateDetailsProcedure = "/api.v1.UserService/UpdateDetails"
@@ -66,19 +82,54 @@ const (
 	UserServiceEditChatProcedure = "/api.v1.UserService/EditChat"
 	// UserServiceEmojiReplyProcedure is the fully-qualified name of the UserService's EmojiReply RPC.
 	UserServiceEmojiReplyProcedure = "/api.v1.UserService/EmojiReply"
+	// UserServiceBlockUserProcedure is the fully-qualified name of the UserService's BlockUser RPC.
+	UserServiceBlockUserProcedure = "/api.v1.UserService/BlockUser"
+	// UserServiceUnblockUserProcedure is the fully-qualified name of the UserService's UnblockUser RPC.
+	UserServiceUnblockUserProcedure = "/api.v1.UserService/UnblockUser"
+	// UserServiceListBlockedProcedure is the fully-qualified name of the UserService's ListBlocked RPC.
+	UserServiceListBlockedProcedure = "/api.v1.UserService/ListBlocked"
+	// UserServiceUploadAvatarProcedure is the fully-qualified name of the UserService's UploadAvatar
+	// RPC.
+	UserServiceUploadAvatarProcedure = "/api.v1.UserService/UploadAvatar"
 	// EventsServicePreviousEventsProcedure is the fully-qualified name of the EventsService's
 	// PreviousEvents RPC.
 	EventsServicePreviousEventsProcedure = "/api.v1.EventsService/PreviousEvents"
 	// EventsServiceEventStreamProcedure is the fully-qualified name of the EventsService's EventStream
 	// RPC.
 	EventsServiceEventStreamProcedure = "/api.v1.EventsService/EventStream"
+	// AdminServiceRotateKeysProcedure is the fully-qualified name of the AdminService's RotateKeys RPC.
+	AdminServiceRotateKeysProcedure = "/api.v1.AdminService/RotateKeys"
+	// AdminServiceRevokeKeyProcedure is the fully-qualified name of the AdminService's RevokeKey RPC.
+	AdminServiceRevokeKeyProcedure = "/api.v1.AdminService/RevokeKey"
+	// AdminServiceKickUserProcedure is the fully-qualified name of the AdminService's KickUser RPC.
+	AdminServiceKickUserProcedure = "/api.v1.AdminService/KickUser"
+	// AdminServiceSetUserRoleProcedure is the fully-qualified name of the AdminService's SetUserRole
+	// RPC.
+	AdminServiceSetUserRoleProcedure = "/api.v1.AdminService/SetUserRole"
+	// AdminServiceSetChallengeDifficultyProcedure is the fully-qualified name of the AdminService's
+	// SetChallengeDifficulty RPC.
+	AdminServiceSetChallengeDifficultyProcedure = "/api.v1.AdminService/SetChallengeDifficulty"
 )
 
 // AuthServiceClient is a client for the api.v1.AuthService service.
 type AuthServiceClient interface {
+	// GetChallenge returns a proof-of-work challenge which must be solved to
+	// join, when its difficulty is greater than zero.
+	GetChallenge(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.Challenge], error)
 	Join(context.Context, *connect.Request[v1.JoinRequest]) (*connect.Response[v1.JoinResponse], error)
-	Keepalive(context.Context) *connect.ClientStreamForClient[emptypb.Empty, emptypb.Empty]
-	Renew(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RenewResponse], error)
+	// JoinBot joins a pre-registered bot. The bot authenticates with its API
+	// key in the x-api-key header, or with a client certificate.
+	JoinBot(context.Context, *connect.Request[v1.JoinBotRequest]) (*connect.Response[v1.JoinResponse], error)
+	// Register creates an account with a reserved name and joins the
+	// chatroom with it.
+	Register(context.Context, *connect.Request[v1.RegisterRequest]) (*connect.Response[v1.JoinResponse], error)
+	// Login joins the chatroom using a registered account.
+	Login(context.Context, *connect.Request[v1.LoginRequest]) (*connect.Response[v1.JoinResponse], error)
+	// Keepalive keeps the user's session alive for as long as the stream is
+	// open. The stream is closed with an unauthenticated error when the access
+	// token expires, unless the client sends a renewed access token.
+	Keepalive(context.Context) *connect.ClientStreamForClient[v1.KeepaliveRequest, emptypb.Empty]
+	Renew(context.Context, *connect.Request[v1.RenewRequest]) (*connect.Response[v1.RenewResponse], error)
 	Leave(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
 }
 
@@ -93,19 +144,43 @@ func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 	baseURL = strings.TrimRight(baseURL, "/")
 	authServiceMethods := v1.File_api_v1_apiv1_proto.Services().ByName("AuthService").Methods()
 	return &authServiceClient{
+		getChallenge: connect.NewClient[emptypb.Empty, v1.Challenge](
+			httpClient,
+			baseURL+AuthServiceGetChallengeProcedure,
+			connect.WithSchema(authServiceMethods.ByName("GetChallenge")),
+			connect.WithClientOptions(opts...),
+		),
 		join: connect.NewClient[v1.JoinRequest, v1.JoinResponse](
 			httpClient,
 			baseURL+AuthServiceJoinProcedure,
 			connect.WithSchema(authServiceMethods.ByName("Join")),
 			connect.WithClientOptions(opts...),
 		),
-		keepalive: connect.NewClient[emptypb.Empty, emptypb.Empty](
+		joinBot: connect.NewClient[v1.JoinBotRequest, v1.JoinResponse](
+			httpClient,
+			baseURL+AuthServiceJoinBotProcedure,
+			connect.WithSchema(authServiceMethods.ByName("JoinBot")),
+			connect.WithClientOptions(opts...),
+		),
+		register: connect.NewClient[v1.RegisterRequest, v1.JoinResponse](
+			httpClient,
+			baseURL+AuthServiceRegisterProcedure,
+			connect.WithSchema(authServiceMethods.ByName("Register")),
+			connect.WithClientOptions(opts...),
+		),
+		login: connect.NewClient[v1.LoginRequest, v1.JoinResponse](
+			httpClient,
+			baseURL+AuthServiceLoginProcedure,
+			connect.WithSchema(authServiceMethods.ByName("Login")),
+			connect.WithClientOptions(opts...),
+		),
+		keepalive: connect.NewClient[v1.KeepaliveRequest, emptypb.Empty](
 			httpClient,
 			baseURL+AuthServiceKeepaliveProcedure,
 			connect.WithSchema(authServiceMethods.ByName("Keepalive")),
 			connect.WithClientOptions(opts...),
 		),
-		renew: connect.NewClient[emptypb.Empty, v1.RenewResponse](
+		renew: connect.NewClient[v1.RenewRequest, v1.RenewResponse](
 			httpClient,
 			baseURL+AuthServiceRenewProcedure,
 			connect.WithSchema(authServiceMethods.ByName("Renew")),
@@ -122,10 +197,19 @@ func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 
 // authServiceClient implements AuthServiceClient.
 type authServiceClient struct {
-	join      *connect.Client[v1.JoinRequest, v1.JoinResponse]
-	keepalive *connect.Client[emptypb.Empty, emptypb.Empty]
-	renew     *connect.Client[emptypb.Empty, v1.RenewResponse]
-	leave     *connect.Client[emptypb.Empty, emptypb.Empty]
+	getChallenge *connect.Client[emptypb.Empty, v1.Challenge]
+	join         *connect.Client[v1.JoinRequest, v1.JoinResponse]
+	joinBot      *connect.Client[v1.JoinBotRequest, v1.JoinResponse]
+	register     *connect.Client[v1.RegisterRequest, v1.JoinResponse]
+	login        *connect.Client[v1.LoginRequest, v1.JoinResponse]
+	keepalive    *connect.Client[v1.KeepaliveRequest, emptypb.Empty]
+	renew        *connect.Client[v1.RenewRequest, v1.RenewResponse]
+	leave        *connect.Client[emptypb.Empty, emptypb.Empty]
+}
+
+// GetChallenge calls api.v1.AuthService.GetChallenge.
+func (c *authServiceClient) GetChallenge(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.Challenge], error) {
+	return c.getChallenge.CallUnary(ctx, req)
 }
 
 // Join calls api.v1.AuthService.Join.
@@ -133,13 +217,28 @@ func (c *authServiceClient) Join(ctx context.Context, req *connect.Request[v1.Jo
 	return c.join.CallUnary(ctx, req)
 }
 
+// JoinBot calls api.v1.AuthService.JoinBot.
+func (c *authServiceClient) JoinBot(ctx context.Context, req *connect.Request[v1.JoinBotRequest]) (*connect.Response[v1.JoinResponse], error) {
+	return c.joinBot.CallUnary(ctx, req)
+}
+
+// Register calls api.v1.AuthService.Register.
+func (c *authServiceClient) Register(ctx context.Context, req *connect.Request[v1.RegisterRequest]) (*connect.Response[v1.JoinResponse], error) {
+	return c.register.CallUnary(ctx, req)
+}
+
+// Login calls api.v1.AuthService.Login.
+func (c *authServiceClient) Login(ctx context.Context, req *connect.Request[v1.LoginRequest]) (*connect.Response[v1.JoinResponse], error) {
+	return c.login.CallUnary(ctx, req)
+}
+
 // Keepalive calls api.v1.AuthService.Keepalive.
-func (c *authServiceClient) Keepalive(ctx context.Context) *connect.ClientStreamForClient[emptypb.Empty, emptypb.Empty] {
+func (c *authServiceClient) Keepalive(ctx context.Context) *connect.ClientStreamForClient[v1.KeepaliveRequest, emptypb.Empty] {
 	return c.keepalive.CallClientStream(ctx)
 }
 
 // Renew calls api.v1.AuthService.Renew.
-func (c *authServiceClient) Renew(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.RenewResponse], error) {
+func (c *authServiceClient) Renew(ctx context.Context, req *connect.Request[v1.RenewRequest]) (*connect.Response[v1.RenewResponse], error) {
 	return c.renew.CallUnary(ctx, req)
 }
 
@@ -150,9 +249,23 @@ func (c *authServiceClient) Leave(ctx context.Context, req *connect.Request[empt
 
 // AuthServiceHandler is an implementation of the api.v1.AuthService service.
 type AuthServiceHandler interface {
+	// GetChallenge returns a proof-of-work challenge which must be solved to
+	// join, when its difficulty is greater than zero.
+	GetChallenge(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.Challenge], error)
 	Join(context.Context, *connect.Request[v1.JoinRequest]) (*connect.Response[v1.JoinResponse], error)
-	Keepalive(context.Context, *connect.ClientStream[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
-	Renew(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RenewResponse], error)
+	// JoinBot joins a pre-registered bot. The bot authenticates with its API
+	// key in the x-api-key header, or with a client certificate.
+	JoinBot(context.Context, *connect.Request[v1.JoinBotRequest]) (*connect.Response[v1.JoinResponse], error)
+	// Register creates an account with a reserved name and joins the
+	// chatroom with it.
+	Register(context.Context, *connect.Request[v1.RegisterRequest]) (*connect.Response[v1.JoinResponse], error)
+	// Login joins the chatroom using a registered account.
+	Login(context.Context, *connect.Request[v1.LoginRequest]) (*connect.Response[v1.JoinResponse], error)
+	// Keepalive keeps the user's session alive for as long as the stream is
+	// open. The stream is closed with an unauthenticated error when the access
+	// token expires, unless the client sends a renewed access token.
+	Keepalive(context.Context, *connect.ClientStream[v1.KeepaliveRequest]) (*connect.Response[emptypb.Empty], error)
+	Renew(context.Context, *connect.Request[v1.RenewRequest]) (*connect.Response[v1.RenewResponse], error)
 	Leave(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
 }
 
@@ -163,12 +276,36 @@ type AuthServiceHandler interface {
 // and JSON codecs. They also support gzip compression.
 func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
 	authServiceMethods := v1.File_api_v1_apiv1_proto.Services().ByName("AuthService").Methods()
+	authServiceGetChallengeHandler := connect.NewUnaryHandler(
+		AuthServiceGetChallengeProcedure,
+		svc.GetChallenge,
+		connect.WithSchema(authServiceMethods.ByName("GetChallenge")),
+		connect.WithHandlerOptions(opts...),
+	)
 	authServiceJoinHandler := connect.NewUnaryHandler(
 		AuthServiceJoinProcedure,
 		svc.Join,
 		connect.WithSchema(authServiceMethods.ByName("Join")),
 		connect.WithHandlerOptions(opts...),
 	)
+	authServiceJoinBotHandler := connect.NewUnaryHandler(
+		AuthServiceJoinBotProcedure,
+		svc.JoinBot,
+		connect.WithSchema(authServiceMethods.ByName("JoinBot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRegisterHandler := connect.NewUnaryHandler(
+		AuthServiceRegisterProcedure,
+		svc.Register,
+		connect.WithSchema(authServiceMethods.ByName("Register")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceLoginHandler := connect.NewUnaryHandler(
+		AuthServiceLoginProcedure,
+		svc.Login,
+		connect.WithSchema(authServiceMethods.ByName("Login")),
+		connect.WithHandlerOptions(opts...),
+	)
 	authServiceKeepaliveHandler := connect.NewClientStreamHandler(
 		AuthServiceKeepaliveProcedure,
 		svc.Keepalive,
@@ -189,8 +326,16 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 	)
 	return "/api.v1.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
+		case AuthServiceGetChallengeProcedure:
+			authServiceGetChallengeHandler.ServeHTTP(w, r)
 		case AuthServiceJoinProcedure:
 			authServiceJoinHandler.ServeHTTP(w, r)
+		case AuthServiceJoinBotProcedure:
+			authServiceJoinBotHandler.ServeHTTP(w, r)
+		case AuthServiceRegisterProcedure:
+			authServiceRegisterHandler.ServeHTTP(w, r)
+		case AuthServiceLoginProcedure:
+			authServiceLoginHandler.ServeHTTP(w, r)
 		case AuthServiceKeepaliveProcedure:
 			authServiceKeepaliveHandler.ServeHTTP(w, r)
 		case AuthServiceRenewProcedure:
@@ -206,15 +351,31 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 // UnimplementedAuthServiceHandler returns CodeUnimplemented from all methods.
 type UnimplementedAuthServiceHandler struct{}
 
+func (UnimplementedAuthServiceHandler) GetChallenge(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.Challenge], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AuthService.GetChallenge is not implemented"))
+}
+
 func (UnimplementedAuthServiceHandler) Join(context.Context, *connect.Request[v1.JoinRequest]) (*connect.Response[v1.JoinResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AuthService.Join is not implemented"))
 }
 
-func (UnimplementedAuthServiceHandler) Keepalive(context.Context, *connect.ClientStream[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+func (UnimplementedAuthServiceHandler) JoinBot(context.Context, *connect.Request[v1.JoinBotRequest]) (*connect.Response[v1.JoinResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AuthService.JoinBot is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) Register(context.Context, *connect.Request[v1.RegisterRequest]) (*connect.Response[v1.JoinResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AuthService.Register is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) Login(context.Context, *connect.Request[v1.LoginRequest]) (*connect.Response[v1.JoinResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AuthService.Login is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) Keepalive(context.Context, *connect.ClientStream[v1.KeepaliveRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AuthService.Keepalive is not implemented"))
 }
 
-func (UnimplementedAuthServiceHandler) Renew(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RenewResponse], error) {
+func (UnimplementedAuthServiceHandler) Renew(context.Context, *connect.Request[v1.RenewRequest]) (*connect.Response[v1.RenewResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AuthService.Renew is not implemented"))
 }
 
@@ -225,6 +386,9 @@ func (UnimplementedAuthServiceHandler) Leave(context.Context, *connect.Request[e
 // RegistryServiceClient is a client for the api.v1.RegistryService service.
 type RegistryServiceClient interface {
 	ActiveUsers(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ActiveUsersResponse], error)
+	// ListUsers returns a page of users, ordered by name.
+	ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error)
+	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.ActiveUsersResponse_User], error)
 }
 
 // NewRegistryServiceClient constructs a client for the api.v1.RegistryService service. By default,
@@ -244,12 +408,26 @@ func NewRegistryServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(registryServiceMethods.ByName("ActiveUsers")),
 			connect.WithClientOptions(opts...),
 		),
+		listUsers: connect.NewClient[v1.ListUsersRequest, v1.ListUsersResponse](
+			httpClient,
+			baseURL+RegistryServiceListUsersProcedure,
+			connect.WithSchema(registryServiceMethods.ByName("ListUsers")),
+			connect.WithClientOptions(opts...),
+		),
+		getUser: connect.NewClient[v1.GetUserRequest, v1.ActiveUsersResponse_User](
+			httpClient,
+			baseURL+RegistryServiceGetUserProcedure,
+			connect.WithSchema(registryServiceMethods.ByName("GetUser")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // registryServiceClient implements RegistryServiceClient.
 type registryServiceClient struct {
 	activeUsers *connect.Client[emptypb.Empty, v1.ActiveUsersResponse]
+	listUsers   *connect.Client[v1.ListUsersRequest, v1.ListUsersResponse]
+	getUser     *connect.Client[v1.GetUserRequest, v1.ActiveUsersResponse_User]
 }
 
 // ActiveUsers calls api.v1.RegistryService.ActiveUsers.
@@ -257,9 +435,22 @@ func (c *registryServiceClient) ActiveUsers(ctx context.Context, req *connect.Re
 	return c.activeUsers.CallUnary(ctx, req)
 }
 
+// ListUsers calls api.v1.RegistryService.ListUsers.
+func (c *registryServiceClient) ListUsers(ctx context.Context, req *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error) {
+	return c.listUsers.CallUnary(ctx, req)
+}
+
+// GetUser calls api.v1.RegistryService.GetUser.
+func (c *registryServiceClient) GetUser(ctx context.Context, req *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.ActiveUsersResponse_User], error) {
+	return c.getUser.CallUnary(ctx, req)
+}
+
 // RegistryServiceHandler is an implementation of the api.v1.RegistryService service.
 type RegistryServiceHandler interface {
 	ActiveUsers(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ActiveUsersResponse], error)
+	// ListUsers returns a page of users, ordered by name.
+	ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error)
+	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.ActiveUsersResponse_User], error)
 }
 
 // NewRegistryServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -275,10 +466,26 @@ func NewRegistryServiceHandler(svc RegistryServiceHandler, opts ...connect.Handl
 		connect.WithSchema(registryServiceMethods.ByName("ActiveUsers")),
 		connect.WithHandlerOptions(opts...),
 	)
+	registryServiceListUsersHandler := connect.NewUnaryHandler(
+		RegistryServiceListUsersProcedure,
+		svc.ListUsers,
+		connect.WithSchema(registryServiceMethods.ByName("ListUsers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	registryServiceGetUserHandler := connect.NewUnaryHandler(
+		RegistryServiceGetUserProcedure,
+		svc.GetUser,
+		connect.WithSchema(registryServiceMethods.ByName("GetUser")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.RegistryService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RegistryServiceActiveUsersProcedure:
 			registryServiceActiveUsersHandler.ServeHTTP(w, r)
+		case RegistryServiceListUsersProcedure:
+			registryServiceListUsersHandler.ServeHTTP(w, r)
+		case RegistryServiceGetUserProcedure:
+			registryServiceGetUserHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -292,6 +499,14 @@ func (UnimplementedRegistryServiceHandler) ActiveUsers(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.RegistryService.ActiveUsers is not implemented"))
 }
 
+func (UnimplementedRegistryServiceHandler) ListUsers(context.Context, *connect.Request[v1.ListUsersRequest]) (*connect.Response[v1.ListUsersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.RegistryService.ListUsers is not implemented"))
+}
+
+func (UnimplementedRegistryServiceHandler) GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.ActiveUsersResponse_User], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.RegistryService.GetUser is not implemented"))
+}
+
 // UserServiceClient is a client for the api.v1.UserService service.
 type UserServiceClient interface {
 	UpdateDetails(context.Context, *connect.Request[v1.UpdateDetailsRequest]) (*connect.Response[emptypb.Empty], error)
@@ -300,6 +515,10 @@ type UserServiceClient interface {
 	SendChat(context.Context, *connect.Request[v1.SendChatRequest]) (*connect.Response[emptypb.Empty], error)
 	EditChat(context.Context, *connect.Request[v1.EditChatRequest]) (*connect.Response[emptypb.Empty], error)
 	EmojiReply(context.Context, *connect.Request[v1.EmojiReplyRequest]) (*connect.Response[emptypb.Empty], error)
+	BlockUser(context.Context, *connect.Request[v1.BlockUserRequest]) (*connect.Response[emptypb.Empty], error)
+	UnblockUser(context.Context, *connect.Request[v1.UnblockUserRequest]) (*connect.Response[emptypb.Empty], error)
+	ListBlocked(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListBlockedResponse], error)
+	UploadAvatar(context.Context, *connect.Request[v1.UploadAvatarRequest]) (*connect.Response[v1.UploadAvatarResponse], error)
 }
 
 // NewUserServiceClient constructs a client for the api.v1.UserService service. By default, it uses
@@ -349,6 +568,30 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceMethods.ByName("EmojiReply")),
 			connect.WithClientOptions(opts...),
 		),
+		blockUser: connect.NewClient[v1.BlockUserRequest, emptypb.Empty](
+			httpClient,
+			baseURL+UserServiceBlockUserProcedure,
+			connect.WithSchema(userServiceMethods.ByName("BlockUser")),
+			connect.WithClientOptions(opts...),
+		),
+		unblockUser: connect.NewClient[v1.UnblockUserRequest, emptypb.Empty](
+			httpClient,
+			baseURL+UserServiceUnblockUserProcedure,
+			connect.WithSchema(userServiceMethods.ByName("UnblockUser")),
+			connect.WithClientOptions(opts...),
+		),
+		listBlocked: connect.NewClient[emptypb.Empty, v1.ListBlockedResponse](
+			httpClient,
+			baseURL+UserServiceListBlockedProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListBlocked")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadAvatar: connect.NewClient[v1.UploadAvatarRequest, v1.UploadAvatarResponse](
+			httpClient,
+			baseURL+UserServiceUploadAvatarProcedure,
+			connect.WithSchema(userServiceMethods.ByName("UploadAvatar")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -360,6 +603,10 @@ type userServiceClient struct {
 	sendChat       *connect.Client[v1.SendChatRequest, emptypb.Empty]
 	editChat       *connect.Client[v1.EditChatRequest, emptypb.Empty]
 	emojiReply     *connect.Client[v1.EmojiReplyRequest, emptypb.Empty]
+	blockUser      *connect.Client[v1.BlockUserRequest, emptypb.Empty]
+	unblockUser    *connect.Client[v1.UnblockUserRequest, emptypb.Empty]
+	listBlocked    *connect.Client[emptypb.Empty, v1.ListBlockedResponse]
+	uploadAvatar   *connect.Client[v1.UploadAvatarRequest, v1.UploadAvatarResponse]
 }
 
 // UpdateDetails calls api.v1.UserService.UpdateDetails.
@@ -392,6 +639,26 @@ func (c *userServiceClient) EmojiReply(ctx context.Context, req *connect.Request
 	return c.emojiReply.CallUnary(ctx, req)
 }
 
+// BlockUser calls api.v1.UserService.BlockUser.
+func (c *userServiceClient) BlockUser(ctx context.Context, req *connect.Request[v1.BlockUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.blockUser.CallUnary(ctx, req)
+}
+
+// UnblockUser calls api.v1.UserService.UnblockUser.
+func (c *userServiceClient) UnblockUser(ctx context.Context, req *connect.Request[v1.UnblockUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.unblockUser.CallUnary(ctx, req)
+}
+
+// ListBlocked calls api.v1.UserService.ListBlocked.
+func (c *userServiceClient) ListBlocked(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListBlockedResponse], error) {
+	return c.listBlocked.CallUnary(ctx, req)
+}
+
+// UploadAvatar calls api.v1.UserService.UploadAvatar.
+func (c *userServiceClient) UploadAvatar(ctx context.Context, req *connect.Request[v1.UploadAvatarRequest]) (*connect.Response[v1.UploadAvatarResponse], error) {
+	return c.uploadAvatar.CallUnary(ctx, req)
+}
+
 // UserServiceHandler is an implementation of the api.v1.UserService service.
 type UserServiceHandler interface {
 	UpdateDetails(context.Context, *connect.Request[v1.UpdateDetailsRequest]) (*connect.Response[emptypb.Empty], error)
@@ -400,6 +667,10 @@ type UserServiceHandler interface {
 	SendChat(context.Context, *connect.Request[v1.SendChatRequest]) (*connect.Response[emptypb.Empty], error)
 	EditChat(context.Context, *connect.Request[v1.EditChatRequest]) (*connect.Response[emptypb.Empty], error)
 	EmojiReply(context.Context, *connect.Request[v1.EmojiReplyRequest]) (*connect.Response[emptypb.Empty], error)
+	BlockUser(context.Context, *connect.Request[v1.BlockUserRequest]) (*connect.Response[emptypb.Empty], error)
+	UnblockUser(context.Context, *connect.Request[v1.UnblockUserRequest]) (*connect.Response[emptypb.Empty], error)
+	ListBlocked(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListBlockedResponse], error)
+	UploadAvatar(context.Context, *connect.Request[v1.UploadAvatarRequest]) (*connect.Response[v1.UploadAvatarResponse], error)
 }
 
 // NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -445,6 +716,30 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceMethods.ByName("EmojiReply")),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceBlockUserHandler := connect.NewUnaryHandler(
+		UserServiceBlockUserProcedure,
+		svc.BlockUser,
+		connect.WithSchema(userServiceMethods.ByName("BlockUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUnblockUserHandler := connect.NewUnaryHandler(
+		UserServiceUnblockUserProcedure,
+		svc.UnblockUser,
+		connect.WithSchema(userServiceMethods.ByName("UnblockUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListBlockedHandler := connect.NewUnaryHandler(
+		UserServiceListBlockedProcedure,
+		svc.ListBlocked,
+		connect.WithSchema(userServiceMethods.ByName("ListBlocked")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUploadAvatarHandler := connect.NewUnaryHandler(
+		UserServiceUploadAvatarProcedure,
+		svc.UploadAvatar,
+		connect.WithSchema(userServiceMethods.ByName("UploadAvatar")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case UserServiceUpdateDetailsProcedure:
@@ -459,6 +754,14 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 			userServiceEditChatHandler.ServeHTTP(w, r)
 		case UserServiceEmojiReplyProcedure:
 			userServiceEmojiReplyHandler.ServeHTTP(w, r)
+		case UserServiceBlockUserProcedure:
+			userServiceBlockUserHandler.ServeHTTP(w, r)
+		case UserServiceUnblockUserProcedure:
+			userServiceUnblockUserHandler.ServeHTTP(w, r)
+		case UserServiceListBlockedProcedure:
+			userServiceListBlockedHandler.ServeHTTP(w, r)
+		case UserServiceUploadAvatarProcedure:
+			userServiceUploadAvatarHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -492,6 +795,22 @@ func (UnimplementedUserServiceHandler) EmojiReply(context.Context, *connect.Requ
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.UserService.EmojiReply is not implemented"))
 }
 
+func (UnimplementedUserServiceHandler) BlockUser(context.Context, *connect.Request[v1.BlockUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.UserService.BlockUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) UnblockUser(context.Context, *connect.Request[v1.UnblockUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.UserService.UnblockUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListBlocked(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListBlockedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.UserService.ListBlocked is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) UploadAvatar(context.Context, *connect.Request[v1.UploadAvatarRequest]) (*connect.Response[v1.UploadAvatarResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.UserService.UploadAvatar is not implemented"))
+}
+
 // EventsServiceClient is a client for the api.v1.EventsService service.
 type EventsServiceClient interface {
 	PreviousEvents(context.Context, *connect.Request[v1.PreviousEventsRequest]) (*connect.Response[v1.PreviousEventsResponse], error)
@@ -587,3 +906,199 @@ func (UnimplementedEventsServiceHandler) PreviousEvents(context.Context, *connec
 func (UnimplementedEventsServiceHandler) EventStream(context.Context, *connect.Request[emptypb.Empty], *connect.ServerStream[v1.EventStreamResponse]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.EventsService.EventStream is not implemented"))
 }
+
+// AdminServiceClient is a client for the api.v1.AdminService service.
+type AdminServiceClient interface {
+	// RotateKeys generates a new signing key. Tokens signed with the previous
+	// key remain valid until they expire.
+	RotateKeys(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
+	// RevokeKey removes a (compromised) signing key. All tokens signed with
+	// it are rejected immediately.
+	RevokeKey(context.Context, *connect.Request[v1.RevokeKeyRequest]) (*connect.Response[emptypb.Empty], error)
+	// KickUser removes the user from the chatroom and revokes all of its
+	// tokens.
+	KickUser(context.Context, *connect.Request[v1.KickUserRequest]) (*connect.Response[emptypb.Empty], error)
+	// SetUserRole changes the role of the user. Its access tokens are revoked,
+	// so the new role applies once its client renews its access token.
+	SetUserRole(context.Context, *connect.Request[v1.SetUserRoleRequest]) (*connect.Response[emptypb.Empty], error)
+	// SetChallengeDifficulty changes the difficulty of the proof-of-work
+	// challenge which must be solved to join or register. Challenges are no
+	// longer required when zero.
+	SetChallengeDifficulty(context.Context, *connect.Request[v1.SetChallengeDifficultyRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewAdminServiceClient constructs a client for the api.v1.AdminService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	adminServiceMethods := v1.File_api_v1_apiv1_proto.Services().ByName("AdminService").Methods()
+	return &adminServiceClient{
+		rotateKeys: connect.NewClient[emptypb.Empty, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceRotateKeysProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("RotateKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeKey: connect.NewClient[v1.RevokeKeyRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceRevokeKeyProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("RevokeKey")),
+			connect.WithClientOptions(opts...),
+		),
+		kickUser: connect.NewClient[v1.KickUserRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceKickUserProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("KickUser")),
+			connect.WithClientOptions(opts...),
+		),
+		setUserRole: connect.NewClient[v1.SetUserRoleRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceSetUserRoleProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetUserRole")),
+			connect.WithClientOptions(opts...),
+		),
+		setChallengeDifficulty: connect.NewClient[v1.SetChallengeDifficultyRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceSetChallengeDifficultyProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetChallengeDifficulty")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	rotateKeys             *connect.Client[emptypb.Empty, emptypb.Empty]
+	revokeKey              *connect.Client[v1.RevokeKeyRequest, emptypb.Empty]
+	kickUser               *connect.Client[v1.KickUserRequest, emptypb.Empty]
+	setUserRole            *connect.Client[v1.SetUserRoleRequest, emptypb.Empty]
+	setChallengeDifficulty *connect.Client[v1.SetChallengeDifficultyRequest, emptypb.Empty]
+}
+
+// RotateKeys calls api.v1.AdminService.RotateKeys.
+func (c *adminServiceClient) RotateKeys(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+	return c.rotateKeys.CallUnary(ctx, req)
+}
+
+// RevokeKey calls api.v1.AdminService.RevokeKey.
+func (c *adminServiceClient) RevokeKey(ctx context.Context, req *connect.Request[v1.RevokeKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.revokeKey.CallUnary(ctx, req)
+}
+
+// KickUser calls api.v1.AdminService.KickUser.
+func (c *adminServiceClient) KickUser(ctx context.Context, req *connect.Request[v1.KickUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.kickUser.CallUnary(ctx, req)
+}
+
+// SetUserRole calls api.v1.AdminService.SetUserRole.
+func (c *adminServiceClient) SetUserRole(ctx context.Context, req *connect.Request[v1.SetUserRoleRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.setUserRole.CallUnary(ctx, req)
+}
+
+// SetChallengeDifficulty calls api.v1.AdminService.SetChallengeDifficulty.
+func (c *adminServiceClient) SetChallengeDifficulty(ctx context.Context, req *connect.Request[v1.SetChallengeDifficultyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.setChallengeDifficulty.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the api.v1.AdminService service.
+type AdminServiceHandler interface {
+	// RotateKeys generates a new signing key. Tokens signed with the previous
+	// key remain valid until they expire.
+	RotateKeys(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error)
+	// RevokeKey removes a (compromised) signing key. All tokens signed with
+	// it are rejected immediately.
+	RevokeKey(context.Context, *connect.Request[v1.RevokeKeyRequest]) (*connect.Response[emptypb.Empty], error)
+	// KickUser removes the user from the chatroom and revokes all of its
+	// tokens.
+	KickUser(context.Context, *connect.Request[v1.KickUserRequest]) (*connect.Response[emptypb.Empty], error)
+	// SetUserRole changes the role of the user. Its access tokens are revoked,
+	// so the new role applies once its client renews its access token.
+	SetUserRole(context.Context, *connect.Request[v1.SetUserRoleRequest]) (*connect.Response[emptypb.Empty], error)
+	// SetChallengeDifficulty changes the difficulty of the proof-of-work
+	// challenge which must be solved to join or register. Challenges are no
+	// longer required when zero.
+	SetChallengeDifficulty(context.Context, *connect.Request[v1.SetChallengeDifficultyRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceMethods := v1.File_api_v1_apiv1_proto.Services().ByName("AdminService").Methods()
+	adminServiceRotateKeysHandler := connect.NewUnaryHandler(
+		AdminServiceRotateKeysProcedure,
+		svc.RotateKeys,
+		connect.WithSchema(adminServiceMethods.ByName("RotateKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceRevokeKeyHandler := connect.NewUnaryHandler(
+		AdminServiceRevokeKeyProcedure,
+		svc.RevokeKey,
+		connect.WithSchema(adminServiceMethods.ByName("RevokeKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceKickUserHandler := connect.NewUnaryHandler(
+		AdminServiceKickUserProcedure,
+		svc.KickUser,
+		connect.WithSchema(adminServiceMethods.ByName("KickUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetUserRoleHandler := connect.NewUnaryHandler(
+		AdminServiceSetUserRoleProcedure,
+		svc.SetUserRole,
+		connect.WithSchema(adminServiceMethods.ByName("SetUserRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetChallengeDifficultyHandler := connect.NewUnaryHandler(
+		AdminServiceSetChallengeDifficultyProcedure,
+		svc.SetChallengeDifficulty,
+		connect.WithSchema(adminServiceMethods.ByName("SetChallengeDifficulty")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceRotateKeysProcedure:
+			adminServiceRotateKeysHandler.ServeHTTP(w, r)
+		case AdminServiceRevokeKeyProcedure:
+			adminServiceRevokeKeyHandler.ServeHTTP(w, r)
+		case AdminServiceKickUserProcedure:
+			adminServiceKickUserHandler.ServeHTTP(w, r)
+		case AdminServiceSetUserRoleProcedure:
+			adminServiceSetUserRoleHandler.ServeHTTP(w, r)
+		case AdminServiceSetChallengeDifficultyProcedure:
+			adminServiceSetChallengeDifficultyHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) RotateKeys(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AdminService.RotateKeys is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) RevokeKey(context.Context, *connect.Request[v1.RevokeKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AdminService.RevokeKey is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) KickUser(context.Context, *connect.Request[v1.KickUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AdminService.KickUser is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetUserRole(context.Context, *connect.Request[v1.SetUserRoleRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AdminService.SetUserRole is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetChallengeDifficulty(context.Context, *connect.Request[v1.SetChallengeDifficultyRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.AdminService.SetChallengeDifficulty is not implemented"))
+}
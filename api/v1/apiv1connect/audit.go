@@ -0,0 +1,23 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/roeldev/demo-chatroom/chatauth"
+)
+
+// newAuditRecord creates a [chatauth.AuditRecord] with the peer info of the
+// request.
+func newAuditRecord(evt chatauth.AuditEvent, spec connect.Spec, peer connect.Peer, h http.Header) chatauth.AuditRecord {
+	return chatauth.AuditRecord{
+		Event:     evt,
+		Peer:      peer.Addr,
+		UserAgent: h.Get("User-Agent"),
+		Procedure: spec.Procedure,
+	}
+}
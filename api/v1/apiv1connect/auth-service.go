@@ -9,50 +9,337 @@ import (
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/go-pogo/errors"
 	"github.com/roeldev/demo-chatroom/api/v1"
+	"github.com/roeldev/demo-chatroom/chataccounts"
 	"github.com/roeldev/demo-chatroom/chatauth"
 	"github.com/roeldev/demo-chatroom/chatevents/event"
 	"github.com/roeldev/demo-chatroom/chatusers"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var _ AuthServiceHandler = (*AuthService)(nil)
 
+// APIKeyHeader is the request header which contains the API key of a bot.
+const APIKeyHeader = "x-api-key"
+
+// JoinPolicy determines how users are allowed to join the chatroom.
+type JoinPolicy struct {
+	// Identity verifies the ID tokens of users who join with an external
+	// identity. Joining with an ID token is disabled when nil.
+	Identity chatauth.IdentityVerifier
+	// AllowAnonymous allows users to join without an ID token.
+	AllowAnonymous bool
+	// Accounts contains the registered accounts. Their names cannot be used
+	// by other users. Register and Login are disabled when nil.
+	Accounts chataccounts.AccountsStore
+	// Challenge issues the proof-of-work challenges users must solve before
+	// they can join. Bots which join with their credentials skip the
+	// challenge. Challenges are disabled when nil.
+	Challenge *chatauth.Challenger
+}
+
 type AuthService struct {
-	log  zerolog.Logger
-	auth *chatauth.Manager
+	log    zerolog.Logger
+	auth   *chatauth.Manager
+	bots   *chatauth.Bots
+	policy JoinPolicy
 }
 
-func NewAuthService(log zerolog.Logger, auth *chatauth.Manager) *AuthService {
+func NewAuthService(log zerolog.Logger, auth *chatauth.Manager, bots *chatauth.Bots, policy JoinPolicy) *AuthService {
 	return &AuthService{
-		log:  log,
-		auth: auth,
+		log:    log,
+		auth:   auth,
+		bots:   bots,
+		policy: policy,
 	}
 }
 
-func (svc *AuthService) Join(_ context.Context, req *connect.Request[apiv1.JoinRequest]) (*connect.Response[apiv1.JoinResponse], error) {
-	user, err := chatusers.NewUser("", apiv1.FromJoinRequest(req.Msg))
+func (svc *AuthService) GetChallenge(_ context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[apiv1.Challenge], error) {
+	if svc.policy.Challenge == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New(ErrChallengeDisabled))
+	}
+
+	chal, err := svc.policy.Challenge.Issue()
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&apiv1.Challenge{
+		Nonce:      chal.Nonce,
+		Difficulty: uint32(chal.Difficulty),
+		ExpiresAt:  timestamppb.New(chal.Expires),
+	}), nil
+}
+
+// verifyChallenge checks the solution to the challenge, when a solution is
+// required.
+func (svc *AuthService) verifyChallenge(sol *apiv1.ChallengeSolution) error {
+	if svc.policy.Challenge == nil || svc.policy.Challenge.Difficulty() == 0 {
+		return nil
+	}
+	if sol.GetNonce() == "" {
+		return connect.NewError(connect.CodeFailedPrecondition, errors.New(chatauth.ErrChallengeRequired))
+	}
+	if err := svc.policy.Challenge.Verify(sol.Nonce, sol.Counter); err != nil {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return nil
+}
+
+func (svc *AuthService) Join(ctx context.Context, req *connect.Request[apiv1.JoinRequest]) (*connect.Response[apiv1.JoinResponse], error) {
+	rec := newAuditRecord(chatauth.AuditJoin, req.Spec(), req.Peer(), req.Header())
+	if err := svc.verifyChallenge(req.Msg.Challenge); err != nil {
+		svc.auditFailure(rec, chatauth.AuditJoinFailed, err)
+		return nil, err
 	}
 
-	uid, token, err := svc.auth.Join(*user, requestSecretSalter(req))
+	opts := []chatusers.UserOption{apiv1.FromJoinRequest(req.Msg)}
+	if req.Msg.IdToken != "" {
+		ident, err := svc.verifyIdentity(ctx, req.Msg.IdToken)
+		if err != nil {
+			svc.auditFailure(rec, chatauth.AuditJoinFailed, err)
+			return nil, err
+		}
+		opts = append(opts, withIdentity(ident))
+	} else if !svc.policy.AllowAnonymous {
+		err := errors.New(ErrIDTokenRequired)
+		svc.auditFailure(rec, chatauth.AuditJoinFailed, err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	user, err := chatusers.NewUser("", opts...)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	if user.Flags.Has(chatusers.Flag_IsBot) {
+		err = errors.New(ErrBotCredentialsRequired)
+		svc.auditFailure(rec, chatauth.AuditJoinFailed, err)
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	if svc.policy.Accounts != nil && svc.policy.Accounts.IsReserved(user.Name) {
+		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New(chataccounts.ErrNameReserved))
+	}
+
+	uid, tokens, err := svc.auth.Join(*user, getSalter(ctx))
+	if err != nil {
+		return nil, joinError(err)
+	}
 
 	svc.log.Info().
 		Str("user", chatusers.IdentifierString(uid, user.UserDetails)).
-		Str("token", token).
 		Msg("user joins")
 
+	svc.auditTokens(rec.WithUser(uid), tokens)
+	return newJoinResponse(tokens), nil
+}
+
+// auditTokens records a successful join or renew which issued tokens.
+func (svc *AuthService) auditTokens(rec chatauth.AuditRecord, tokens chatauth.Tokens) {
+	rec.Token = chatauth.RedactToken(tokens.Access)
+	svc.auth.Auditor().Record(rec)
+}
+
+// auditFailure records a failed join or renew.
+func (svc *AuthService) auditFailure(rec chatauth.AuditRecord, evt chatauth.AuditEvent, err error) {
+	rec.Event = evt
+	svc.auth.Auditor().Record(rec.WithError(err))
+}
+
+// joinError converts an error returned by [chatauth.Manager.Join] into a
+// connect error with a matching code.
+func joinError(err error) error {
+	var connectErr *connect.Error
+	switch {
+	case errors.As(err, &connectErr):
+		return connectErr
+	case errors.Is(err, chatusers.ErrNameAlreadyExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+// registerError converts an error returned by
+// [chataccounts.AccountsStore.Register] into a connect error with a matching
+// code.
+func registerError(err error) error {
+	switch {
+	case errors.Is(err, chataccounts.ErrNameReserved):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, chataccounts.ErrAccountNameRequired),
+		errors.Is(err, chataccounts.ErrPasswordTooShort),
+		errors.Is(err, chataccounts.ErrPasswordTooLong):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+func newJoinResponse(tokens chatauth.Tokens) *connect.Response[apiv1.JoinResponse] {
 	return connect.NewResponse(&apiv1.JoinResponse{
-		Token: token,
-	}), nil
+		Token:        tokens.Access,
+		RefreshToken: tokens.Refresh,
+		ExpiresAt:    timestamppb.New(tokens.AccessExpires),
+	})
 }
 
-func (svc *AuthService) Keepalive(ctx context.Context, stream *connect.ClientStream[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+func (svc *AuthService) Register(ctx context.Context, req *connect.Request[apiv1.RegisterRequest]) (*connect.Response[apiv1.JoinResponse], error) {
+	if svc.policy.Accounts == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New(ErrAccountsDisabled))
+	}
+
+	rec := newAuditRecord(chatauth.AuditJoin, req.Spec(), req.Peer(), req.Header())
+	if err := svc.verifyChallenge(req.Msg.Challenge); err != nil {
+		svc.auditFailure(rec, chatauth.AuditJoinFailed, err)
+		return nil, err
+	}
+
+	user, err := chatusers.NewUser("", apiv1.FromRegisterRequest(req.Msg))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	acc, err := svc.policy.Accounts.Register(user.Name, req.Msg.Password)
+	if err != nil {
+		return nil, registerError(err)
+	}
+
+	user.Role = acc.Role
+	uid, tokens, err := svc.auth.Join(*user, getSalter(ctx))
+	if err != nil {
+		// release the name, the account cannot be used by anyone
+		if delErr := svc.policy.Accounts.Delete(acc.ID); delErr != nil {
+			svc.log.Err(delErr).
+				Stringer("account", acc.ID).
+				Msg("failed to delete account after failed join")
+		}
+		return nil, joinError(err)
+	}
+
+	svc.log.Info().
+		Str("user", chatusers.IdentifierString(uid, user.UserDetails)).
+		Stringer("account", acc.ID).
+		Msg("user registers")
+
+	svc.auditTokens(rec.WithUser(uid), tokens)
+	return newJoinResponse(tokens), nil
+}
+
+func (svc *AuthService) Login(ctx context.Context, req *connect.Request[apiv1.LoginRequest]) (*connect.Response[apiv1.JoinResponse], error) {
+	if svc.policy.Accounts == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New(ErrAccountsDisabled))
+	}
+
+	rec := newAuditRecord(chatauth.AuditJoin, req.Spec(), req.Peer(), req.Header())
+	acc, err := svc.policy.Accounts.Authenticate(req.Msg.Name, req.Msg.Password)
+	if err != nil {
+		svc.log.Warn().Err(err).
+			Str("peer", req.Peer().Addr).
+			Msg("login failed")
+		svc.auditFailure(rec, chatauth.AuditJoinFailed, err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	user, err := chatusers.NewUser(acc.Name, apiv1.FromLoginRequest(req.Msg), func(u *chatusers.User) error {
+		u.Name = acc.Name
+		u.Role = acc.Role
+		return nil
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	uid, tokens, err := svc.auth.Join(*user, getSalter(ctx))
+	if err != nil {
+		return nil, joinError(err)
+	}
+
+	svc.log.Info().
+		Str("user", chatusers.IdentifierString(uid, user.UserDetails)).
+		Stringer("account", acc.ID).
+		Msg("user logs in")
+
+	svc.auditTokens(rec.WithUser(uid), tokens)
+	return newJoinResponse(tokens), nil
+}
+
+func (svc *AuthService) verifyIdentity(ctx context.Context, token string) (chatauth.Identity, error) {
+	if svc.policy.Identity == nil {
+		return chatauth.Identity{}, connect.NewError(connect.CodeFailedPrecondition, errors.New(ErrIdentityLoginDisabled))
+	}
+
+	ident, err := svc.policy.Identity.VerifyIdentity(ctx, token)
+	if err != nil {
+		svc.log.Warn().Err(err).Msg("id token verification failed")
+		return ident, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	return ident, nil
+}
+
+// withIdentity sets the user's name and picture from the verified identity.
+func withIdentity(ident chatauth.Identity) chatusers.UserOption {
+	return func(u *chatusers.User) error {
+		if ident.Name != "" {
+			u.Name = ident.Name
+			u.Initials = ""
+		}
+		u.Picture = ident.Picture
+		return nil
+	}
+}
+
+func (svc *AuthService) JoinBot(ctx context.Context, req *connect.Request[apiv1.JoinBotRequest]) (*connect.Response[apiv1.JoinResponse], error) {
+	rec := newAuditRecord(chatauth.AuditJoin, req.Spec(), req.Peer(), req.Header())
+
+	var bot chatauth.BotAccount
+	var err error
+	if key := req.Header().Get(APIKeyHeader); key != "" {
+		bot, err = svc.bots.ByAPIKey(key)
+	} else {
+		bot, err = svc.bots.ByCertificate(getPeerCertificate(ctx))
+	}
+	if err != nil {
+		svc.log.Warn().Err(err).
+			Str("peer", req.Peer().Addr).
+			Msg("bot authentication failed")
+		svc.auditFailure(rec, chatauth.AuditJoinFailed, err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	opts := []chatusers.UserOption{apiv1.FromJoinBotRequest(req.Msg)}
+	if bot.Name != "" {
+		opts = append(opts, func(u *chatusers.User) error {
+			u.Name = bot.Name
+			return nil
+		})
+	}
+
+	user, err := chatusers.NewUser("", opts...)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	uid, tokens, err := svc.auth.Join(*user, getSalter(ctx))
+	if err != nil {
+		return nil, joinError(err)
+	}
+
+	svc.log.Info().
+		Str("user", chatusers.IdentifierString(uid, user.UserDetails)).
+		Str("bot", bot.ID).
+		Msg("bot joins")
+
+	svc.auditTokens(rec.WithUser(uid), tokens)
+	return newJoinResponse(tokens), nil
+}
+
+// Keepalive keeps the session of the user alive until the stream is closed.
+// Renewed access tokens received over the stream are handled by the handler
+// interceptor.
+func (svc *AuthService) Keepalive(ctx context.Context, stream *connect.ClientStream[apiv1.KeepaliveRequest]) (*connect.Response[emptypb.Empty], error) {
 	streamStart := time.Now()
 
 	user := getUser(ctx)
@@ -60,8 +347,9 @@ func (svc *AuthService) Keepalive(ctx context.Context, stream *connect.ClientStr
 		Stringer("user", user).
 		Msg("start keepalive")
 
+	svc.auth.Connect(user.ID)
 	defer func() {
-		svc.auth.Leave(user.ID, event.Disconnected)
+		svc.auth.Disconnect(user.ID)
 
 		svc.log.Debug().
 			Stringer("user", user).
@@ -69,29 +357,45 @@ func (svc *AuthService) Keepalive(ctx context.Context, stream *connect.ClientStr
 			Msg("close keepalive")
 	}()
 
-	for stream.Receive() {
-	}
+	done := make(chan error, 1)
+	go func() {
+		for stream.Receive() {
+		}
+		done <- stream.Err()
+	}()
 
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+	case <-ctx.Done():
+	}
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
-func (svc *AuthService) Renew(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[apiv1.RenewResponse], error) {
-	oldClaims := getClaims(ctx)
-	newClaims := chatauth.RenewClaims(oldClaims)
-
-	token, err := svc.auth.Sign(newClaims, requestSecretSalter(req))
+func (svc *AuthService) Renew(ctx context.Context, req *connect.Request[apiv1.RenewRequest]) (*connect.Response[apiv1.RenewResponse], error) {
+	rec := newAuditRecord(chatauth.AuditRenew, req.Spec(), req.Peer(), req.Header())
+	uid, tokens, err := svc.auth.Renew(req.Msg.RefreshToken, getSalter(ctx))
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		svc.log.Warn().Err(err).
+			Stringer("user", uid).
+			Msg("renew token failed")
+		rec.Token = chatauth.RedactToken(req.Msg.RefreshToken)
+		svc.auditFailure(rec.WithUser(uid), chatauth.AuditRenewFailed, err)
+		return nil, err
 	}
 
-	user := getUser(ctx)
 	svc.log.Info().
-		Stringer("user", user).
-		Str("token", token).
+		Stringer("user", uid).
 		Msg("renew token")
 
+	svc.auditTokens(rec.WithUser(uid), tokens)
+
 	return connect.NewResponse(&apiv1.RenewResponse{
-		Token: token,
+		Token:        tokens.Access,
+		RefreshToken: tokens.Refresh,
+		ExpiresAt:    timestamppb.New(tokens.AccessExpires),
 	}), nil
 }
 
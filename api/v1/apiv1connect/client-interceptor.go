@@ -8,6 +8,7 @@ import (
 	"context"
 	"net/http"
 	"sync"
+	"time"
 
 	"connectrpc.com/connect"
 	apiv1 "github.com/roeldev/demo-chatroom/api/v1"
@@ -16,8 +17,10 @@ import (
 // https://connectrpc.com/docs/go/interceptors
 
 type ClientInterceptor struct {
-	mut   sync.RWMutex
-	token string
+	mut     sync.RWMutex
+	token   string
+	refresh string
+	expires time.Time
 }
 
 func NewClientInterceptor(token string) *ClientInterceptor {
@@ -36,8 +39,11 @@ func (ci *ClientInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc
 			return res, err
 		}
 
-		if join, ok := res.Any().(*apiv1.JoinResponse); ok {
-			ci.UpdateToken(join.Token)
+		switch msg := res.Any().(type) {
+		case *apiv1.JoinResponse:
+			ci.updateTokens(msg.Token, msg.RefreshToken, msg.ExpiresAt.AsTime())
+		case *apiv1.RenewResponse:
+			ci.updateTokens(msg.Token, msg.RefreshToken, msg.ExpiresAt.AsTime())
 		}
 		return res, nil
 	}
@@ -69,3 +75,32 @@ func (ci *ClientInterceptor) UpdateToken(token string) {
 	ci.token = token
 	ci.mut.Unlock()
 }
+
+// Token returns the current access token.
+func (ci *ClientInterceptor) Token() string {
+	ci.mut.RLock()
+	defer ci.mut.RUnlock()
+	return ci.token
+}
+
+// TokenExpires returns the time at which the current access token expires.
+func (ci *ClientInterceptor) TokenExpires() time.Time {
+	ci.mut.RLock()
+	defer ci.mut.RUnlock()
+	return ci.expires
+}
+
+// RefreshToken returns the last received refresh token.
+func (ci *ClientInterceptor) RefreshToken() string {
+	ci.mut.RLock()
+	defer ci.mut.RUnlock()
+	return ci.refresh
+}
+
+func (ci *ClientInterceptor) updateTokens(token, refresh string, expires time.Time) {
+	ci.mut.Lock()
+	ci.token = token
+	ci.refresh = refresh
+	ci.expires = expires
+	ci.mut.Unlock()
+}
@@ -6,6 +6,8 @@ package apiv1connect
 
 import (
 	"context"
+	"crypto/x509"
+	"net/http"
 
 	"github.com/roeldev/demo-chatroom/chatauth"
 	"github.com/roeldev/demo-chatroom/chatusers"
@@ -20,6 +22,17 @@ func getClaims(ctx context.Context) chatauth.Claims {
 	return chatauth.Claims{}
 }
 
+type salterKey struct{}
+
+// getSalter returns the [chatauth.SecretSalter] which binds a token to the
+// client of the current request.
+func getSalter(ctx context.Context) chatauth.SecretSalter {
+	if v := ctx.Value(salterKey{}); v != nil {
+		return v.(chatauth.SecretSalter)
+	}
+	return chatauth.NopSalter()
+}
+
 type userKey struct{}
 
 func getUser(ctx context.Context) knownUser {
@@ -37,3 +50,24 @@ type knownUser struct {
 func (u knownUser) String() string {
 	return chatusers.IdentifierString(u.ID, u.UserDetails)
 }
+
+type peerCertKey struct{}
+
+// PeerCertificateHandler adds the verified client certificate of the TLS
+// connection to the request's context, so it can be used to authenticate
+// bots.
+func PeerCertificateHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) != 0 && len(r.TLS.VerifiedChains[0]) != 0 {
+			r = r.WithContext(context.WithValue(r.Context(), peerCertKey{}, r.TLS.VerifiedChains[0][0]))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func getPeerCertificate(ctx context.Context) *x509.Certificate {
+	if v := ctx.Value(peerCertKey{}); v != nil {
+		return v.(*x509.Certificate)
+	}
+	return nil
+}
@@ -8,8 +8,24 @@ import "github.com/go-pogo/errors"
 
 const (
 	ErrInvalidToken      errors.Msg = "invalid token"
+	ErrRevokedToken      errors.Msg = "token is revoked"
+	ErrTokenExpired      errors.Msg = "token is expired"
+	ErrPermissionDenied  errors.Msg = "permission denied"
 	ErrInvalidUserID     errors.Msg = "invalid user id"
 	ErrInvalidChatID     errors.Msg = "invalid chat id"
 	ErrInvalidReceiverID errors.Msg = "invalid receiver id"
 	ErrChangeUserStatus  errors.Msg = "failed to change user status"
+	ErrUpdateUserDetails errors.Msg = "failed to update user details"
+	ErrUploadAvatar      errors.Msg = "failed to upload avatar"
+	ErrListUsers         errors.Msg = "failed to list users"
+	ErrRateLimited       errors.Msg = "too many requests"
+	ErrBlockedByReceiver errors.Msg = "receiver does not accept direct messages from this user"
+	ErrInvalidDifficulty errors.Msg = "invalid challenge difficulty"
+
+	ErrBotCredentialsRequired errors.Msg = "bots must join using their credentials"
+	ErrIDTokenRequired        errors.Msg = "an id token is required to join"
+	ErrIdentityLoginDisabled  errors.Msg = "login with an id token is disabled"
+	ErrAccountsDisabled       errors.Msg = "accounts are disabled"
+	ErrChallengeDisabled      errors.Msg = "challenges are disabled"
+	ErrAvatarsDisabled        errors.Msg = "avatar uploads are disabled"
 )
@@ -25,24 +25,28 @@ import (
 var _ EventsServiceHandler = (*EventsService)(nil)
 
 type EventsService struct {
-	log     zerolog.Logger
-	leaver  chatauth.Leaver
-	history chatevents.EventsLister
-	events  *eventHandler
+	log      zerolog.Logger
+	sessions chatauth.SessionTracker
+	history  chatevents.EventsLister
+	events   *eventHandler
 }
 
-func NewEventsService(log zerolog.Logger, history chatevents.EventsLister, broker *chatevents.EventsBroker, leaver chatauth.Leaver) *EventsService {
+func NewEventsService(log zerolog.Logger, history chatevents.EventsLister, broker *chatevents.EventsBroker, sessions chatauth.SessionTracker, blocks chatusers.BlockList) *EventsService {
+	if blocks == nil {
+		blocks = chatusers.NewBlockList()
+	}
+
 	svc := &EventsService{
-		log:     log,
-		leaver:  leaver,
-		history: history,
-		events:  newEventHandler(),
+		log:      log,
+		sessions: sessions,
+		history:  history,
+		events:   newEventHandler(blocks),
 	}
 	broker.Handle(svc.events)
 	return svc
 }
 
-func (svc *EventsService) PreviousEvents(_ context.Context, req *connect.Request[apiv1.PreviousEventsRequest]) (*connect.Response[apiv1.PreviousEventsResponse], error) {
+func (svc *EventsService) PreviousEvents(ctx context.Context, req *connect.Request[apiv1.PreviousEventsRequest]) (*connect.Response[apiv1.PreviousEventsResponse], error) {
 	var until time.Time
 	if req.Msg.UntilTime.IsValid() {
 		until = req.Msg.UntilTime.AsTime()
@@ -51,7 +55,12 @@ func (svc *EventsService) PreviousEvents(_ context.Context, req *connect.Request
 	events := svc.history.ListEvents(until, int(req.Msg.Limit))
 	history := make([]*apiv1.PreviousEventsResponse_PreviousEvent, 0, len(events))
 
+	uid := getUser(ctx).ID
 	for _, evt := range events {
+		if svc.events.isBlocked(uid, evt) {
+			continue
+		}
+
 		history = append(history, &apiv1.PreviousEventsResponse_PreviousEvent{
 			Time:  timestamppb.New(evt.Time),
 			Event: apiv1.NewPreviousEventsResponseEvent(evt.Type),
@@ -74,10 +83,13 @@ func (svc *EventsService) EventStream(ctx context.Context, _ *connect.Request[em
 		Msg("start event stream")
 
 	ch := svc.events.subscribe(user.ID)
+	if svc.sessions != nil {
+		svc.sessions.Connect(user.ID)
+	}
 	defer func() {
-		svc.events.unsubscribe(user.ID)
-		if svc.leaver != nil {
-			svc.leaver.Leave(user.ID, event.Disconnected)
+		svc.events.unsubscribe(user.ID, ch)
+		if svc.sessions != nil {
+			svc.sessions.Disconnect(user.ID)
 		}
 
 		svc.log.Debug().
@@ -113,7 +125,6 @@ streamLoop:
 				continue streamLoop
 			}
 
-			// todo: return on connect.CodeUnauthenticated
 			svc.log.Warn().
 				Bool("more", more).
 				Stringer("open", evt.Time.Sub(streamStart)).
@@ -131,11 +142,8 @@ streamLoop:
 				break streamLoop
 			}
 
-		//case <-time.After(time.Until(claims.ExpiresAt.Time)):
-		//	svc.log.Debug().Msg("token expired")
-		//	break streamLoop
-
 		case <-ctx.Done():
+			// the handler interceptor cancels ctx when the token expires
 			break streamLoop
 		}
 	}
@@ -145,16 +153,41 @@ streamLoop:
 type eventChan chan chatevents.Event
 
 type eventHandler struct {
-	mut  sync.RWMutex
-	subs map[chatusers.UserID]eventChan
+	mut    sync.RWMutex
+	subs   map[chatusers.UserID]eventChan
+	blocks chatusers.BlockList
 }
 
-func newEventHandler() *eventHandler {
+func newEventHandler(blocks chatusers.BlockList) *eventHandler {
 	return &eventHandler{
-		subs: make(map[chatusers.UserID]eventChan),
+		subs:   make(map[chatusers.UserID]eventChan),
+		blocks: blocks,
 	}
 }
 
+// isBlocked indicates if e is a chat, edit, typing or emoji event of a user
+// which is blocked by the subscribed user.
+func (eh *eventHandler) isBlocked(uid chatusers.UserID, e chatevents.Event) bool {
+	var sender chatusers.UserID
+	switch et := e.Type.(type) {
+	case *event.ChatEvent:
+		sender = et.UserID
+	case *event.ChatEditEvent:
+		sender = et.UserID
+	case *event.UserTypingEvent:
+		sender = et.UserID
+	case *event.EmojiReplyEvent:
+		sender = et.UserID
+	case *event.EmojiRemoveEvent:
+		sender = et.UserID
+	default:
+		return false
+	}
+	return eh.blocks.IsBlocked(uid, sender)
+}
+
+// subscribe returns a new channel which receives the events for the user. Any
+// previous channel of the user is closed.
 func (eh *eventHandler) subscribe(uid chatusers.UserID) eventChan {
 	eh.mut.Lock()
 	defer eh.mut.Unlock()
@@ -169,11 +202,13 @@ func (eh *eventHandler) subscribe(uid chatusers.UserID) eventChan {
 	return sub
 }
 
-func (eh *eventHandler) unsubscribe(uid chatusers.UserID) {
+// unsubscribe closes ch, unless it is already closed and replaced by a newer
+// subscription of the user.
+func (eh *eventHandler) unsubscribe(uid chatusers.UserID, ch eventChan) {
 	eh.mut.Lock()
 	defer eh.mut.Unlock()
 
-	if sub, ok := eh.subs[uid]; ok {
+	if sub, ok := eh.subs[uid]; ok && sub == ch {
 		delete(eh.subs, uid)
 		close(sub)
 	}
@@ -191,6 +226,9 @@ func (eh *eventHandler) HandleEvent(e chatevents.Event) {
 				continue
 			}
 		}
+		if eh.isBlocked(user, e) {
+			continue
+		}
 
 		ch <- e
 	}
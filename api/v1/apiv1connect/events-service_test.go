@@ -0,0 +1,89 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/chatevents"
+	"github.com/roeldev/demo-chatroom/chatevents/event"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHandler_HandleEvent(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+
+	blocked := map[string]event.Type{
+		"chat":         &event.ChatEvent{UserID: bob, Text: "hi"},
+		"chat edit":    &event.ChatEditEvent{UserID: bob, Text: "hello"},
+		"typing":       &event.UserTypingEvent{UserID: bob, IsTyping: true},
+		"emoji reply":  &event.EmojiReplyEvent{UserID: bob, Emoji: "x"},
+		"emoji remove": &event.EmojiRemoveEvent{UserID: bob},
+	}
+	for name, typ := range blocked {
+		t.Run(name, func(t *testing.T) {
+			blocks := chatusers.NewBlockList()
+			require.NoError(t, blocks.Block(alice, bob))
+
+			eh := newEventHandler(blocks)
+			aliceCh, bobCh := eh.subscribe(alice), eh.subscribe(bob)
+			eh.HandleEvent(chatevents.Event{Type: typ})
+
+			assert.Len(t, aliceCh, 0, "filtered for the blocking user")
+			assert.Len(t, bobCh, 1)
+		})
+	}
+
+	t.Run("status", func(t *testing.T) {
+		blocks := chatusers.NewBlockList()
+		require.NoError(t, blocks.Block(alice, bob))
+
+		eh := newEventHandler(blocks)
+		aliceCh := eh.subscribe(alice)
+		eh.HandleEvent(chatevents.Event{Type: &event.UserStatusEvent{UserID: bob, After: chatusers.Status_Busy}})
+		assert.Len(t, aliceCh, 1, "status events are not filtered")
+	})
+	t.Run("blocked user leaves and resumes", func(t *testing.T) {
+		blocks := chatusers.NewBlockList()
+		require.NoError(t, blocks.Block(alice, bob))
+
+		// bob leaves, his stream closes and is opened again when he resumes
+		// his session
+		eh := newEventHandler(blocks)
+		aliceCh, bobCh := eh.subscribe(alice), eh.subscribe(bob)
+		blocks.Forget(bob)
+		eh.unsubscribe(bob, bobCh)
+		eh.subscribe(bob)
+
+		for _, typ := range blocked {
+			eh.HandleEvent(chatevents.Event{Type: typ})
+		}
+		assert.Len(t, aliceCh, 0)
+	})
+}
+
+func TestEventHandler_subscribe(t *testing.T) {
+	uid := uuid.New()
+	eh := newEventHandler(chatusers.NewBlockList())
+
+	first := eh.subscribe(uid)
+	second := eh.subscribe(uid)
+	_, more := <-first
+	assert.False(t, more, "previous subscription is closed")
+
+	// the stream of the first subscription ends after the second started
+	eh.unsubscribe(uid, first)
+	eh.HandleEvent(chatevents.Event{Type: &event.ChatEvent{UserID: uuid.New(), Text: "hi"}})
+	if assert.Len(t, second, 1) {
+		assert.IsType(t, &event.ChatEvent{}, (<-second).Type)
+	}
+
+	eh.unsubscribe(uid, second)
+	_, more = <-second
+	assert.False(t, more)
+}
@@ -6,7 +6,6 @@ package apiv1connect
 
 import (
 	"context"
-	"net"
 	"strings"
 
 	"connectrpc.com/connect"
@@ -16,33 +15,98 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// CodeBindingMismatch is returned when a token is used by a different client
+// than it was issued to, e.g. because the client's IP address changed. The
+// client should renew its token using its refresh token.
+const CodeBindingMismatch = connect.CodeFailedPrecondition
+
 type handlerInterceptor struct {
-	log    zerolog.Logger
-	parser chatauth.Parser
-	users  chatusers.UsersStore
+	log     zerolog.Logger
+	parser  chatauth.Parser
+	revoked chatauth.RevocationChecker
+	users   chatusers.UsersStore
+	binder  *chatauth.Binder
+	limiter *RateLimiter
+	audit   *chatauth.Auditor
+
+	expiries *streamExpiries
 }
 
-func NewHandlerInterceptor(log zerolog.Logger, auth chatauth.Parser, users chatusers.UsersStore) connect.Interceptor {
+// NewHandlerInterceptor creates a [connect.Interceptor] which authorizes
+// calls to non-public procedures. When limiter is not nil, calls are rate
+// limited per user, or per ip address for public procedures. Rejected tokens
+// and denied calls are recorded by audit, when not nil.
+func NewHandlerInterceptor(log zerolog.Logger, auth chatauth.Parser, revoked chatauth.RevocationChecker, users chatusers.UsersStore, binder *chatauth.Binder, limiter *RateLimiter, audit *chatauth.Auditor) connect.Interceptor {
+	if binder == nil {
+		binder = chatauth.NewBinder(chatauth.BindBoth)
+	}
+
 	return &handlerInterceptor{
-		log:    log,
-		parser: auth,
-		users:  users,
+		log:     log,
+		parser:  auth,
+		revoked: revoked,
+		users:   users,
+		binder:  binder,
+		limiter: limiter,
+		audit:   audit,
+
+		expiries: newStreamExpiries(),
+	}
+}
+
+// verify parses the token and checks if it is not revoked. A rejected token
+// is recorded as rec.
+func (hi *handlerInterceptor) verify(token string, salter chatauth.SecretSalter, rec chatauth.AuditRecord) (chatauth.Claims, error) {
+	rec.Event = chatauth.AuditParseFailed
+	rec.Token = chatauth.RedactToken(token)
+
+	claims, err := hi.parser.Parse(token, salter)
+	if errors.Is(err, chatauth.ErrBindingMismatch) {
+		hi.audit.Record(rec.WithError(err))
+		return claims, connect.NewError(CodeBindingMismatch, err)
+	}
+	if err != nil {
+		hi.audit.Record(rec.WithError(err))
+		return claims, connect.NewError(connect.CodeUnauthenticated,
+			errors.Wrap(err, ErrInvalidToken),
+		)
+	}
+	if hi.revoked != nil && hi.revoked.IsRevoked(claims.ID) {
+		rec.TokenID = claims.ID
+		hi.audit.Record(rec.WithUser(claims.UserID).WithError(errors.New(ErrRevokedToken)))
+		return claims, connect.NewError(connect.CodeUnauthenticated,
+			errors.New(ErrRevokedToken),
+		)
 	}
+	return claims, nil
 }
 
-func (hi *handlerInterceptor) authorize(ctx context.Context, bearer string, salter chatauth.SecretSalter) (context.Context, error) {
+func (hi *handlerInterceptor) authorize(ctx context.Context, bearer string, salter chatauth.SecretSalter, rec chatauth.AuditRecord) (context.Context, error) {
 	if strings.HasPrefix(bearer, "bearer ") || strings.HasPrefix(bearer, "Bearer ") {
 		bearer = bearer[7:]
 	}
 
-	claims, err := hi.parser.Parse(bearer, salter)
+	claims, err := hi.verify(bearer, salter, rec)
 	if err != nil {
-		return nil, errors.Wrap(err, ErrInvalidToken)
+		return nil, err
 	}
 
 	user, err := hi.users.Get(claims.UserID)
 	if err != nil {
-		return nil, errors.Wrap(err, ErrInvalidUserID)
+		return nil, connect.NewError(connect.CodeUnauthenticated,
+			errors.Wrap(err, ErrInvalidUserID),
+		)
+	}
+	// the role in the token is outdated when the user's role has changed
+	// since the token was issued
+	if !isPermitted(rec.Procedure, user.Role) {
+		rec.Event = chatauth.AuditPermissionDenied
+		rec.TokenID = claims.ID
+		rec.Reason = "role " + user.Role.String()
+		hi.audit.Record(rec.WithUser(claims.UserID))
+		return nil, connect.NewError(connect.CodePermissionDenied,
+			errors.New(ErrPermissionDenied),
+		)
 	}
 
 	ctx = context.WithValue(ctx, claimsKey{}, claims)
@@ -59,12 +123,22 @@ func (hi *handlerInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFun
 			panic("apiv1connect: Use NewClientInterceptor() instead")
 		}
 
+		salter := hi.binder.Salter(req.Peer().Addr, req.Header())
+		ctx = context.WithValue(ctx, salterKey{}, salter)
+
 		var err error
-		if bearer := req.Header().Get("authorization"); bearer != "" {
-			ctx, err = hi.authorize(ctx, bearer, requestSecretSalter(req))
-			if err != nil {
-				return nil, connect.NewError(connect.CodeUnauthenticated, err)
-			}
+		procedure := req.Spec().Procedure
+		if isPublic(procedure) {
+			err = hi.limiter.check(procedure, hi.binder.ClientIP(req.Peer().Addr, req.Header()).String())
+		} else if ctx, err = hi.authorize(ctx,
+			req.Header().Get("authorization"),
+			salter,
+			newAuditRecord("", req.Spec(), req.Peer(), req.Header()),
+		); err == nil {
+			err = hi.limiter.check(procedure, getUser(ctx).ID.String())
+		}
+		if err != nil {
+			return nil, err
 		}
 
 		resp, err := next(ctx, req)
@@ -80,15 +154,46 @@ func (hi *handlerInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFun
 
 func (hi *handlerInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
 	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		procedure := conn.Spec().Procedure
+		salter := hi.binder.Salter(conn.Peer().Addr, conn.RequestHeader())
+		rec := newAuditRecord("", conn.Spec(), conn.Peer(), conn.RequestHeader())
 		ctx, err := hi.authorize(ctx,
 			conn.RequestHeader().Get("authorization"),
-			streamSecretSalter(conn),
+			salter,
+			rec,
 		)
 		if err != nil {
-			return connect.NewError(connect.CodeUnauthenticated, err)
+			return err
+		}
+
+		uid := getUser(ctx).ID
+		if err = hi.limiter.check(procedure, uid.String()); err != nil {
+			return err
+		}
+
+		// close the stream when its token expires, unless a renewed token is
+		// received over a stream of the same user
+		if claims := getClaims(ctx); claims.ExpiresAt != nil {
+			var stop func()
+			ctx, stop = hi.expiries.watch(ctx, uid, claims.ExpiresAt.Time)
+			defer stop()
+		}
+		conn = &renewingConn{
+			StreamingHandlerConn: conn,
+			hi:                   hi,
+			uid:                  uid,
+			salter:               salter,
+			rec:                  rec,
 		}
 
 		err = next(ctx, conn)
+		if err == nil {
+			// report why the stream is closed, e.g. because its token expired
+			var connectErr *connect.Error
+			if errors.As(context.Cause(ctx), &connectErr) {
+				err = connectErr
+			}
+		}
 		if err != nil {
 			hi.log.Warn().
 				Err(err).
@@ -101,45 +206,3 @@ func (hi *handlerInterceptor) WrapStreamingHandler(next connect.StreamingHandler
 func (hi *handlerInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
 	return next
 }
-
-var _ chatauth.SecretSalter = (*secretSalter)(nil)
-
-type secretSalter struct {
-	IPAddr    string
-	UserAgent string
-}
-
-func requestSecretSalter(req connect.AnyRequest) chatauth.SecretSalter {
-	return secretSalter{
-		IPAddr:    removePort(req.Peer().Addr),
-		UserAgent: req.Header().Get("user-agent"),
-	}
-}
-
-func streamSecretSalter(conn connect.StreamingHandlerConn) chatauth.SecretSalter {
-	return secretSalter{
-		IPAddr:    removePort(conn.Peer().Addr),
-		UserAgent: conn.RequestHeader().Get("user-agent"),
-	}
-}
-
-func removePort(addr string) string {
-	host, _, err := net.SplitHostPort(addr)
-
-	var addrErr *net.AddrError
-	if errors.As(err, &addrErr) {
-		return addrErr.Addr
-	}
-	return host
-}
-
-const separator byte = 33
-
-func (s secretSalter) SaltSecret(secret []byte) []byte {
-	secret = append(secret, separator)
-	secret = append(secret, s.IPAddr...)
-	secret = append(secret, separator)
-	secret = append(secret, s.UserAgent...)
-
-	return secret
-}
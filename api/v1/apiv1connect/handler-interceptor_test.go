@@ -0,0 +1,200 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/roeldev/demo-chatroom/api/v1"
+	"github.com/roeldev/demo-chatroom/chatauth"
+	"github.com/roeldev/demo-chatroom/chatevents"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// testServer serves the user, registry and admin services behind a handler
+// interceptor.
+type testServer struct {
+	auth     *chatauth.JWTAuth
+	users    chatusers.UsersStore
+	revoked  chatauth.RevocationStore
+	typing   chatusers.TypingIndicator
+	blocks   chatusers.BlockList
+	user     UserServiceClient
+	registry RegistryServiceClient
+	admin    AdminServiceClient
+}
+
+// nopAdministrator is an [Administrator] which does nothing.
+type nopAdministrator struct{}
+
+func (nopAdministrator) RotateKeys() error                                  { return nil }
+func (nopAdministrator) RevokeKey(string) error                             { return nil }
+func (nopAdministrator) KickUser(chatusers.UserID) error                    { return nil }
+func (nopAdministrator) SetUserRole(chatusers.UserID, chatusers.Role) error { return nil }
+func (nopAdministrator) SetChallengeDifficulty(uint8)                       {}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	auth, err := chatauth.NewJWTAuth(nil, 0)
+	require.NoError(t, err)
+
+	ts := &testServer{
+		auth:    auth,
+		users:   chatusers.NewUsersStore(4),
+		revoked: chatauth.NewRevocationStore(),
+		typing:  chatusers.NewTypingIndicator(time.Minute),
+		blocks:  chatusers.NewBlockList(),
+	}
+
+	interceptor := NewHandlerInterceptor(zerolog.Nop(), auth, ts.revoked, ts.users,
+		chatauth.NewBinder(chatauth.BindNone), nil, nil,
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(NewUserServiceHandler(
+		NewUserService(zerolog.Nop(), ts.users, nil, ts.typing, ts.blocks, nil, chatevents.NewEventsBroker()),
+		connect.WithInterceptors(interceptor),
+	))
+	mux.Handle(NewRegistryServiceHandler(
+		NewRegistryService(zerolog.Nop(), ts.users, ts.typing, ts.blocks),
+		connect.WithInterceptors(interceptor),
+	))
+	mux.Handle(NewAdminServiceHandler(
+		NewAdminService(zerolog.Nop(), nopAdministrator{}),
+		connect.WithInterceptors(interceptor),
+	))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	ts.user = NewUserServiceClient(srv.Client(), srv.URL)
+	ts.registry = NewRegistryServiceClient(srv.Client(), srv.URL)
+	ts.admin = NewAdminServiceClient(srv.Client(), srv.URL)
+	return ts
+}
+
+// join adds a user with name and role to the store and returns its id and the
+// claims and access token it is issued.
+func (ts *testServer) join(t *testing.T, name string, role chatusers.Role) (chatusers.UserID, *chatauth.Claims, string) {
+	t.Helper()
+
+	user, err := chatusers.NewUser(name, func(u *chatusers.User) error {
+		u.Role = role
+		return nil
+	})
+	require.NoError(t, err)
+	uid, err := ts.users.Add(*user)
+	require.NoError(t, err)
+
+	claims := chatauth.NewClaims(uid)
+	claims.Role = role
+	token, err := ts.auth.Sign(claims, chatauth.NopSalter())
+	require.NoError(t, err)
+	return uid, claims, token
+}
+
+// newRequest creates a request with the access token in its authorization
+// header.
+func newRequest[T any](msg *T, token string) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	req.Header().Set("authorization", "Bearer "+token)
+	return req
+}
+
+func TestHandlerInterceptor_revoked(t *testing.T) {
+	ts := newTestServer(t)
+	_, claims, token := ts.join(t, "alice", chatusers.Role_Member)
+
+	_, err := ts.user.ListBlocked(t.Context(), newRequest(&emptypb.Empty{}, token))
+	require.NoError(t, err)
+
+	ts.revoked.Revoke(claims.ID, claims.ExpiresAt.Time)
+	_, err = ts.user.ListBlocked(t.Context(), newRequest(&emptypb.Empty{}, token))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.ErrorContains(t, err, ErrRevokedToken.Error())
+}
+
+func TestHandlerInterceptor_permissions(t *testing.T) {
+	ts := newTestServer(t)
+
+	procedures := map[string]func(ctx context.Context, token string) error{
+		"ListBlocked": func(ctx context.Context, token string) error {
+			_, err := ts.user.ListBlocked(ctx, newRequest(&emptypb.Empty{}, token))
+			return err
+		},
+		"RotateKeys": func(ctx context.Context, token string) error {
+			_, err := ts.admin.RotateKeys(ctx, newRequest(&emptypb.Empty{}, token))
+			return err
+		},
+		"RevokeKey": func(ctx context.Context, token string) error {
+			_, err := ts.admin.RevokeKey(ctx, newRequest(&apiv1.RevokeKeyRequest{KeyId: "kid"}, token))
+			return err
+		},
+		"KickUser": func(ctx context.Context, token string) error {
+			_, err := ts.admin.KickUser(ctx, newRequest(&apiv1.KickUserRequest{}, token))
+			return err
+		},
+		"SetUserRole": func(ctx context.Context, token string) error {
+			_, err := ts.admin.SetUserRole(ctx, newRequest(&apiv1.SetUserRoleRequest{}, token))
+			return err
+		},
+		"SetChallengeDifficulty": func(ctx context.Context, token string) error {
+			_, err := ts.admin.SetChallengeDifficulty(ctx, newRequest(&apiv1.SetChallengeDifficultyRequest{}, token))
+			return err
+		},
+	}
+
+	tests := map[chatusers.Role][]string{
+		chatusers.Role_Member:    {"ListBlocked"},
+		chatusers.Role_Moderator: {"ListBlocked"},
+		chatusers.Role_Admin:     {"ListBlocked", "RotateKeys", "RevokeKey", "KickUser", "SetUserRole", "SetChallengeDifficulty"},
+	}
+	for role, permitted := range tests {
+		_, _, token := ts.join(t, role.String(), role)
+		for name, call := range procedures {
+			t.Run(role.String()+"/"+name, func(t *testing.T) {
+				err := call(t.Context(), token)
+				if slices.Contains(permitted, name) {
+					assert.NotEqual(t, connect.CodePermissionDenied, connect.CodeOf(err))
+				} else {
+					assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+				}
+			})
+		}
+	}
+
+	t.Run("demoted", func(t *testing.T) {
+		uid, _, token := ts.join(t, "demoted", chatusers.Role_Admin)
+		require.NoError(t, procedures["RotateKeys"](t.Context(), token))
+
+		// the token of the demoted admin is not revoked and still contains
+		// the admin role
+		_, _, err := ts.users.UpdateFunc(uid, func(user *chatusers.User) error {
+			user.Role = chatusers.Role_Member
+			return nil
+		})
+		require.NoError(t, err)
+
+		for name, call := range procedures {
+			err = call(t.Context(), token)
+			if name == "ListBlocked" {
+				assert.NoError(t, err, name)
+			} else {
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), name)
+			}
+		}
+	})
+}
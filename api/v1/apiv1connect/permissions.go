@@ -0,0 +1,60 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import "github.com/roeldev/demo-chatroom/chatusers"
+
+// publicProcedures do not require a valid access token. Renew is called with
+// a refresh token when the access token is (about to be) expired.
+var publicProcedures = map[string]struct{}{
+	AuthServiceGetChallengeProcedure: {},
+	AuthServiceJoinProcedure:         {},
+	AuthServiceJoinBotProcedure:      {},
+	AuthServiceRegisterProcedure:     {},
+	AuthServiceLoginProcedure:        {},
+	AuthServiceRenewProcedure:        {},
+}
+
+// permissions contains the roles which are permitted to call each procedure.
+// Procedures which are not listed are denied for everyone.
+var permissions = map[string]chatusers.Role{
+	AuthServiceKeepaliveProcedure: chatusers.Role_Any,
+	AuthServiceLeaveProcedure:     chatusers.Role_Any,
+
+	RegistryServiceActiveUsersProcedure: chatusers.Role_Any,
+	RegistryServiceListUsersProcedure:   chatusers.Role_Any,
+	RegistryServiceGetUserProcedure:     chatusers.Role_Any,
+
+	UserServiceUpdateDetailsProcedure:  chatusers.Role_Any,
+	UserServiceUpdateStatusProcedure:   chatusers.Role_Any,
+	UserServiceIndicateTypingProcedure: chatusers.Role_Any,
+	UserServiceSendChatProcedure:       chatusers.Role_Any,
+	UserServiceEditChatProcedure:       chatusers.Role_Any,
+	UserServiceEmojiReplyProcedure:     chatusers.Role_Any,
+	UserServiceBlockUserProcedure:      chatusers.Role_Any,
+	UserServiceUnblockUserProcedure:    chatusers.Role_Any,
+	UserServiceListBlockedProcedure:    chatusers.Role_Any,
+	UserServiceUploadAvatarProcedure:   chatusers.Role_Any,
+
+	EventsServicePreviousEventsProcedure: chatusers.Role_Any,
+	EventsServiceEventStreamProcedure:    chatusers.Role_Any,
+
+	AdminServiceRotateKeysProcedure:             chatusers.Role_Admin,
+	AdminServiceRevokeKeyProcedure:              chatusers.Role_Admin,
+	AdminServiceKickUserProcedure:               chatusers.Role_Admin,
+	AdminServiceSetUserRoleProcedure:            chatusers.Role_Admin,
+	AdminServiceSetChallengeDifficultyProcedure: chatusers.Role_Admin,
+}
+
+// isPublic indicates if procedure may be called without an access token.
+func isPublic(procedure string) bool {
+	_, ok := publicProcedures[procedure]
+	return ok
+}
+
+// isPermitted indicates if a user with role is allowed to call procedure.
+func isPermitted(procedure string, role chatusers.Role) bool {
+	return permissions[procedure].Has(role)
+}
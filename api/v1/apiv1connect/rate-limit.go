@@ -0,0 +1,216 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-pogo/errors"
+	"golang.org/x/time/rate"
+)
+
+// RetryAfterHeader is the error metadata key which contains the number of
+// seconds a client should wait before calling a rate limited procedure again.
+const RetryAfterHeader = "Retry-After"
+
+const ErrInvalidRateLimit errors.Msg = "invalid rate limit"
+
+// RateLimit is a token bucket which refills at Rate tokens per second, up to
+// a maximum of Burst tokens.
+type RateLimit struct {
+	Rate  rate.Limit
+	Burst int
+}
+
+// ParseRateLimit parses a rate limit in the form "<n>/<unit>[:<burst>]",
+// where unit is one of s, m or h. When burst is omitted, it equals n.
+// Examples: "5/s", "10/m:3".
+func ParseRateLimit(str string) (RateLimit, error) {
+	str = strings.TrimSpace(str)
+	n, unit, ok := strings.Cut(str, "/")
+	if !ok {
+		return RateLimit{}, errors.Wrapf(errors.New(ErrInvalidRateLimit), "%q", str)
+	}
+
+	var burst string
+	unit, burst, _ = strings.Cut(unit, ":")
+
+	count, err := strconv.ParseFloat(n, 64)
+	if err != nil || count < 0 {
+		return RateLimit{}, errors.Wrapf(errors.New(ErrInvalidRateLimit), "%q", str)
+	}
+
+	var per time.Duration
+	switch unit {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return RateLimit{}, errors.Wrapf(errors.New(ErrInvalidRateLimit), "unknown unit in %q", str)
+	}
+
+	rl := RateLimit{
+		Rate:  rate.Limit(count / per.Seconds()),
+		Burst: int(math.Ceil(count)),
+	}
+	if burst != "" {
+		if rl.Burst, err = strconv.Atoi(burst); err != nil || rl.Burst < 1 {
+			return RateLimit{}, errors.Wrapf(errors.New(ErrInvalidRateLimit), "invalid burst in %q", str)
+		}
+	}
+	return rl, nil
+}
+
+// String returns the rate limit in the form accepted by [ParseRateLimit].
+func (rl RateLimit) String() string {
+	count, unit := float64(rl.Rate), "s"
+	if count != math.Trunc(count) {
+		count, unit = count*60, "m"
+	}
+	if count != math.Round(count) {
+		count, unit = count*60, "h"
+	}
+	return strconv.FormatFloat(math.Round(count*1000)/1000, 'f', -1, 64) + "/" + unit + ":" + strconv.Itoa(rl.Burst)
+}
+
+func (rl RateLimit) MarshalText() ([]byte, error) { return []byte(rl.String()), nil }
+
+// UnmarshalText parses text using [ParseRateLimit].
+func (rl *RateLimit) UnmarshalText(text []byte) error {
+	v, err := ParseRateLimit(string(text))
+	if err != nil {
+		return err
+	}
+	*rl = v
+	return nil
+}
+
+// RateLimits contains the [RateLimit] of each procedure. A procedure is
+// either its full name, e.g. "/chatroom.api.v1.UserService/SendChat", or
+// only its method name, e.g. "SendChat". Its text form is a comma separated
+// list of procedures and their rate limits, e.g. "SendChat=5/s:10,Join=10/m".
+type RateLimits map[string]RateLimit
+
+// get returns the [RateLimit] of procedure, if any.
+func (rls RateLimits) get(procedure string) (RateLimit, bool) {
+	if rl, ok := rls[procedure]; ok {
+		return rl, true
+	}
+	rl, ok := rls[procedure[strings.LastIndexByte(procedure, '/')+1:]]
+	return rl, ok
+}
+
+// RateLimiter keeps a token bucket for each combination of procedure and
+// key, where key identifies the caller, e.g. its user id or ip address.
+type RateLimiter struct {
+	limits RateLimits
+	now    func() time.Time
+
+	mut       sync.Mutex
+	buckets   map[bucketKey]*rate.Limiter
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	procedure string
+	key       string
+}
+
+// sweepInterval is the interval at which buckets which are full, and thus
+// equal to new buckets, are removed.
+const sweepInterval = time.Minute
+
+// NewRateLimiter creates a new [RateLimiter] which enforces limits.
+// Procedures without a limit are not rate limited.
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	return &RateLimiter{
+		limits:  limits,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*rate.Limiter, 16),
+	}
+}
+
+// Allow takes a token from the bucket of procedure and key. When the bucket is
+// empty, it returns false and the duration after which a token is available.
+func (rl *RateLimiter) Allow(procedure, key string) (bool, time.Duration) {
+	limit, ok := rl.limits.get(procedure)
+	if !ok {
+		return true, 0
+	}
+
+	now := rl.now()
+	bk := bucketKey{procedure: procedure, key: key}
+
+	rl.mut.Lock()
+	defer rl.mut.Unlock()
+
+	if now.Sub(rl.lastSweep) >= sweepInterval {
+		rl.sweep(now)
+	}
+
+	lim, ok := rl.buckets[bk]
+	if !ok {
+		lim = rate.NewLimiter(limit.Rate, limit.Burst)
+		rl.buckets[bk] = lim
+	}
+
+	res := lim.ReserveN(now, 1)
+	if !res.OK() {
+		// burst is 0, the procedure is disabled
+		return false, 0
+	}
+	if delay := res.DelayFrom(now); delay > 0 {
+		res.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep removes all full buckets. It must be called while holding the lock.
+func (rl *RateLimiter) sweep(now time.Time) {
+	rl.lastSweep = now
+	for bk, lim := range rl.buckets {
+		if lim.TokensAt(now) >= float64(lim.Burst()) {
+			delete(rl.buckets, bk)
+		}
+	}
+}
+
+func (rl *RateLimiter) check(procedure, key string) error {
+	if rl == nil {
+		return nil
+	}
+	if ok, delay := rl.Allow(procedure, key); !ok {
+		err := connect.NewError(connect.CodeResourceExhausted, errors.New(ErrRateLimited))
+		if delay > 0 {
+			err.Meta().Set(RetryAfterHeader, strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		}
+		return err
+	}
+	return nil
+}
+
+// RetryAfter returns the duration a client should wait before retrying when
+// err is the result of a rate limited call.
+func RetryAfter(err error) (time.Duration, bool) {
+	var connErr *connect.Error
+	if !errors.As(err, &connErr) || connErr.Code() != connect.CodeResourceExhausted {
+		return 0, false
+	}
+
+	sec, err := strconv.Atoi(connErr.Meta().Get(RetryAfterHeader))
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(sec) * time.Second, true
+}
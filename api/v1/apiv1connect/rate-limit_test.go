@@ -0,0 +1,76 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := map[string]RateLimit{
+		"5/s":    {Rate: 5, Burst: 5},
+		"10/m:3": {Rate: rate.Limit(10.0 / 60), Burst: 3},
+		"1/h":    {Rate: rate.Limit(1.0 / 3600), Burst: 1},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, err := ParseRateLimit(input)
+			require.NoError(t, err)
+			assert.Equal(t, want, have)
+			assert.Equal(t, input, have.String()[:len(input)])
+		})
+	}
+
+	for _, input := range []string{"", "5", "5/d", "x/s", "5/s:0"} {
+		_, err := ParseRateLimit(input)
+		assert.ErrorIs(t, err, ErrInvalidRateLimit, input)
+	}
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Now()
+	rl := NewRateLimiter(RateLimits{
+		"SendChat": {Rate: 1, Burst: 2},
+	})
+	rl.now = func() time.Time { return now }
+
+	const proc = "/chatroom.api.v1.UserService/SendChat"
+	for i := 0; i < 2; i++ {
+		ok, _ := rl.Allow(proc, "alice")
+		assert.True(t, ok)
+	}
+
+	ok, delay := rl.Allow(proc, "alice")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, delay)
+
+	ok, _ = rl.Allow(proc, "bob")
+	assert.True(t, ok, "buckets are per key")
+	ok, _ = rl.Allow("/chatroom.api.v1.UserService/EditChat", "alice")
+	assert.True(t, ok, "procedures without limit are not limited")
+
+	now = now.Add(time.Second)
+	ok, _ = rl.Allow(proc, "alice")
+	assert.True(t, ok)
+
+	t.Run("retry after", func(t *testing.T) {
+		err := rl.check(proc, "alice")
+		require.Error(t, err)
+
+		have, ok := RetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, have)
+	})
+	t.Run("sweep", func(t *testing.T) {
+		now = now.Add(sweepInterval)
+		rl.Allow(proc, "carol")
+		assert.Len(t, rl.buckets, 1)
+	})
+}
@@ -6,8 +6,10 @@ package apiv1connect
 
 import (
 	"context"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/go-pogo/errors"
 	"github.com/roeldev/demo-chatroom/api/v1"
 	"github.com/roeldev/demo-chatroom/chatusers"
 	"github.com/rs/zerolog"
@@ -18,32 +20,125 @@ import (
 var _ RegistryServiceHandler = (*RegistryService)(nil)
 
 type RegistryService struct {
-	log   zerolog.Logger
-	users chatusers.UsersStore
+	log    zerolog.Logger
+	users  chatusers.UsersStore
+	typing chatusers.TypingIndicator
+	blocks chatusers.BlockList
 }
 
-func NewRegistryService(log zerolog.Logger, users chatusers.UsersStore) *RegistryService {
+func NewRegistryService(log zerolog.Logger, users chatusers.UsersStore, typing chatusers.TypingIndicator, blocks chatusers.BlockList) *RegistryService {
+	if typing == nil {
+		typing = chatusers.NewTypingIndicator(0)
+	}
+	if blocks == nil {
+		blocks = chatusers.NewBlockList()
+	}
+
 	return &RegistryService{
-		log:   log,
-		users: users,
+		log:    log,
+		users:  users,
+		typing: typing,
+		blocks: blocks,
 	}
 }
 
-func (svc *RegistryService) ActiveUsers(_ context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[apiv1.ActiveUsersResponse], error) {
+func (svc *RegistryService) ActiveUsers(ctx context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[apiv1.ActiveUsersResponse], error) {
+	now := time.Now()
 	users := svc.users.All()
 	response := make([]*apiv1.ActiveUsersResponse_User, 0, len(users))
 
 	for uid, user := range users {
-		response = append(response, &apiv1.ActiveUsersResponse_User{
-			Id:      apiv1.NewUUID(uid),
-			Details: apiv1.NewUserDetails(user.UserDetails),
-			Flags:   apiv1.NewUserFlags(user.Flags),
-			Status:  apiv1.NewUserStatus(user.Status),
-		})
+		response = append(response, newRegistryUser(now, uid, user))
+	}
+
+	// typing in direct messages is only visible to the receiver, typing of
+	// blocked users is not visible at all
+	uid := getUser(ctx).ID
+	typing := make([]*apiv1.ActiveUsersResponse_Typing, 0, 4)
+	for _, t := range svc.typing.Typing() {
+		if svc.blocks.IsBlocked(uid, t.UserID) {
+			continue
+		}
+		if t.IsGlobal() || t.ReceiverID == uid {
+			typing = append(typing, &apiv1.ActiveUsersResponse_Typing{
+				UserId:     apiv1.NewUUID(t.UserID),
+				ReceiverId: apiv1.NewUUID(t.ReceiverID),
+			})
+		}
 	}
 
 	return connect.NewResponse(&apiv1.ActiveUsersResponse{
-		Time:  timestamppb.Now(),
-		Users: response,
+		Time:   timestamppb.New(now),
+		Users:  response,
+		Typing: typing,
 	}), nil
 }
+
+func (svc *RegistryService) ListUsers(_ context.Context, req *connect.Request[apiv1.ListUsersRequest]) (*connect.Response[apiv1.ListUsersResponse], error) {
+	flags, err := apiv1.ToChatUserFlags(req.Msg.Flags)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	statuses, err := apiv1.ToChatUserStatuses(req.Msg.Statuses)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	after, err := chatusers.ParseCursor(req.Msg.PageToken)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	list, next, err := svc.users.List(chatusers.ListQuery{
+		Prefix:   req.Msg.Prefix,
+		Flags:    flags,
+		Statuses: statuses,
+		After:    after,
+		Limit:    int(min(req.Msg.PageSize, chatusers.MaxListLimit)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrListUsers)
+	}
+
+	now := time.Now()
+	response := make([]*apiv1.ActiveUsersResponse_User, 0, len(list))
+	for _, entry := range list {
+		response = append(response, newRegistryUser(now, entry.ID, entry.User))
+	}
+
+	return connect.NewResponse(&apiv1.ListUsersResponse{
+		Users:         response,
+		NextPageToken: next.String(),
+	}), nil
+}
+
+func (svc *RegistryService) GetUser(_ context.Context, req *connect.Request[apiv1.GetUserRequest]) (*connect.Response[apiv1.ActiveUsersResponse_User], error) {
+	uid, err := req.Msg.UserId.ParseUUID()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(ErrInvalidUserID))
+	}
+
+	user, err := svc.users.Get(uid)
+	if err != nil {
+		if errors.Is(err, chatusers.ErrUserNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+
+	return connect.NewResponse(newRegistryUser(time.Now(), uid, user)), nil
+}
+
+func newRegistryUser(now time.Time, uid chatusers.UserID, user chatusers.User) *apiv1.ActiveUsersResponse_User {
+	// a custom status may expire before its timer cleared it
+	if user.Custom.IsExpired(now) {
+		user.Custom = chatusers.CustomStatus{}
+	}
+
+	return &apiv1.ActiveUsersResponse_User{
+		Id:           apiv1.NewUUID(uid),
+		Details:      apiv1.NewUserDetails(user.UserDetails),
+		Flags:        apiv1.NewUserFlags(user.Flags),
+		Status:       apiv1.NewUserStatus(user.Status),
+		CustomStatus: apiv1.NewCustomStatus(user.Custom),
+	}
+}
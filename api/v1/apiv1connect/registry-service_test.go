@@ -0,0 +1,33 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/api/v1"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestRegistryService_ActiveUsers(t *testing.T) {
+	ts := newTestServer(t)
+	alice, _, token := ts.join(t, "alice", chatusers.Role_Member)
+	bob, _, _ := ts.join(t, "bob", chatusers.Role_Member)
+	carol, _, _ := ts.join(t, "carol", chatusers.Role_Member)
+
+	require.NoError(t, ts.blocks.Block(alice, bob))
+	ts.typing.IndicateTyping(bob, uuid.Nil, true, func(bool) {})
+	ts.typing.IndicateTyping(carol, uuid.Nil, true, func(bool) {})
+
+	res, err := ts.registry.ActiveUsers(t.Context(), newRequest(&emptypb.Empty{}, token))
+	require.NoError(t, err)
+	if assert.Len(t, res.Msg.Typing, 1, "typing of blocked users is filtered") {
+		assert.Equal(t, apiv1.NewUUID(carol).String(), res.Msg.Typing[0].UserId.String())
+	}
+}
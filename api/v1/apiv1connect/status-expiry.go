@@ -0,0 +1,51 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"sync"
+	"time"
+
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+// statusExpiries clears the custom statuses of users when they expire. Each
+// user has at most one timer, setting a new custom status replaces it.
+type statusExpiries struct {
+	mut    sync.Mutex
+	timers map[chatusers.UserID]*time.Timer
+}
+
+func newStatusExpiries() *statusExpiries {
+	return &statusExpiries{
+		timers: make(map[chatusers.UserID]*time.Timer, 8),
+	}
+}
+
+// schedule calls clear when expires is reached. It stops any previously
+// scheduled timer of the user, and only does so when expires is zero.
+func (se *statusExpiries) schedule(uid chatusers.UserID, expires time.Time, clear func()) {
+	se.mut.Lock()
+	defer se.mut.Unlock()
+
+	if timer, ok := se.timers[uid]; ok {
+		timer.Stop()
+		delete(se.timers, uid)
+	}
+	if expires.IsZero() {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(expires), func() {
+		se.mut.Lock()
+		if se.timers[uid] == timer {
+			delete(se.timers, uid)
+		}
+		se.mut.Unlock()
+		clear()
+	})
+	se.timers[uid] = timer
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusExpiries(t *testing.T) {
+	se := newStatusExpiries()
+	uid := uuid.New()
+
+	replaced := make(chan struct{}, 1)
+	se.schedule(uid, time.Now().Add(20*time.Millisecond), func() { replaced <- struct{}{} })
+
+	cleared := make(chan struct{}, 1)
+	se.schedule(uid, time.Now().Add(10*time.Millisecond), func() { cleared <- struct{}{} })
+
+	select {
+	case <-cleared:
+	case <-time.After(time.Second):
+		t.Fatal("custom status should be cleared")
+	}
+	select {
+	case <-replaced:
+		t.Fatal("replaced timer should not fire")
+	case <-time.After(40 * time.Millisecond):
+	}
+
+	se.mut.Lock()
+	assert.Empty(t, se.timers)
+	se.mut.Unlock()
+
+	t.Run("never expires", func(t *testing.T) {
+		se.schedule(uid, time.Now().Add(time.Hour), func() {})
+		se.schedule(uid, time.Time{}, func() { t.Fatal("should not be called") })
+
+		se.mut.Lock()
+		assert.Empty(t, se.timers)
+		se.mut.Unlock()
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-pogo/errors"
+	"github.com/roeldev/demo-chatroom/chatauth"
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+// streamExpiries cancels the contexts of open streams when the access token
+// they are opened with expires. Renewing the token of a user, extends the
+// lifetime of all of its open streams.
+type streamExpiries struct {
+	mut     sync.Mutex
+	streams map[chatusers.UserID]map[*time.Timer]struct{}
+}
+
+func newStreamExpiries() *streamExpiries {
+	return &streamExpiries{
+		streams: make(map[chatusers.UserID]map[*time.Timer]struct{}, 8),
+	}
+}
+
+// watch returns a context which is canceled with a [connect.CodeUnauthenticated]
+// error when expires is reached. The returned stop func must be called when
+// the stream is closed.
+func (se *streamExpiries) watch(ctx context.Context, uid chatusers.UserID, expires time.Time) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	timer := time.AfterFunc(time.Until(expires), func() {
+		cancel(connect.NewError(connect.CodeUnauthenticated, errors.New(ErrTokenExpired)))
+	})
+
+	se.mut.Lock()
+	if se.streams[uid] == nil {
+		se.streams[uid] = make(map[*time.Timer]struct{}, 2)
+	}
+	se.streams[uid][timer] = struct{}{}
+	se.mut.Unlock()
+
+	return ctx, func() {
+		timer.Stop()
+		cancel(nil)
+
+		se.mut.Lock()
+		delete(se.streams[uid], timer)
+		if len(se.streams[uid]) == 0 {
+			delete(se.streams, uid)
+		}
+		se.mut.Unlock()
+	}
+}
+
+// extend resets the expiry of all open streams of the user to expires.
+func (se *streamExpiries) extend(uid chatusers.UserID, expires time.Time) {
+	se.mut.Lock()
+	defer se.mut.Unlock()
+
+	for timer := range se.streams[uid] {
+		// a timer which already fired has canceled its stream, resetting it
+		// is harmless as the stream is closing
+		timer.Reset(time.Until(expires))
+	}
+}
+
+// tokenRenewer is implemented by stream messages which can contain a
+// renewed access token, e.g. [apiv1.KeepaliveRequest].
+type tokenRenewer interface {
+	GetToken() string
+}
+
+// renewingConn is a [connect.StreamingHandlerConn] which extends the lifetime
+// of the user's streams when it receives a renewed access token.
+type renewingConn struct {
+	connect.StreamingHandlerConn
+	hi     *handlerInterceptor
+	uid    chatusers.UserID
+	salter chatauth.SecretSalter
+	rec    chatauth.AuditRecord
+}
+
+func (rc *renewingConn) Receive(msg any) error {
+	if err := rc.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+
+	tr, ok := msg.(tokenRenewer)
+	if !ok || tr.GetToken() == "" {
+		return nil
+	}
+
+	claims, err := rc.hi.verify(tr.GetToken(), rc.salter, rc.rec)
+	if err != nil {
+		return err
+	}
+	if claims.UserID != rc.uid {
+		return connect.NewError(connect.CodePermissionDenied, errors.New(ErrInvalidUserID))
+	}
+	if claims.ExpiresAt != nil {
+		rc.hi.expiries.extend(rc.uid, claims.ExpiresAt.Time)
+	}
+	return nil
+}
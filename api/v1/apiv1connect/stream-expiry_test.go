@@ -0,0 +1,43 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamExpiries(t *testing.T) {
+	se := newStreamExpiries()
+	uid := uuid.New()
+
+	ctx, stop := se.watch(context.Background(), uid, time.Now().Add(20*time.Millisecond))
+	defer stop()
+	other, stopOther := se.watch(context.Background(), uuid.New(), time.Now().Add(20*time.Millisecond))
+	defer stopOther()
+
+	se.extend(uid, time.Now().Add(time.Hour))
+
+	<-other.Done()
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(context.Cause(other)))
+	assert.NoError(t, ctx.Err(), "extended stream should remain open")
+
+	se.extend(uid, time.Now())
+	select {
+	case <-ctx.Done():
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(context.Cause(ctx)))
+	case <-time.After(time.Second):
+		t.Fatal("stream should be closed")
+	}
+
+	stop()
+	stopOther()
+	assert.Empty(t, se.streams)
+}
@@ -6,11 +6,14 @@ package apiv1connect
 
 import (
 	"context"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/go-pogo/errors"
 	"github.com/google/uuid"
 	"github.com/roeldev/demo-chatroom/api/v1"
+	"github.com/roeldev/demo-chatroom/chataccounts"
+	"github.com/roeldev/demo-chatroom/chatavatars"
 	"github.com/roeldev/demo-chatroom/chatevents"
 	"github.com/roeldev/demo-chatroom/chatevents/event"
 	"github.com/roeldev/demo-chatroom/chatusers"
@@ -20,50 +23,166 @@ import (
 
 var _ UserServiceHandler = (*UserService)(nil)
 
+// errStatusChanged aborts clearing an expired custom status which has been
+// changed in the meantime.
+const errStatusChanged errors.Msg = "custom status has changed"
+
 type UserService struct {
-	log    zerolog.Logger
-	users  chatusers.UsersStore
-	typing chatusers.TypingIndicator
-	event  chatevents.Publisher
+	log      zerolog.Logger
+	users    chatusers.UsersStore
+	reserved chataccounts.NameReserver
+	typing   chatusers.TypingIndicator
+	blocks   chatusers.BlockList
+	avatar   *chatavatars.Avatars
+	expiry   *statusExpiries
+	event    chatevents.Publisher
 }
 
-func NewUserService(log zerolog.Logger, users chatusers.UsersStore, typing chatusers.TypingIndicator, pub chatevents.Publisher) *UserService {
+// NewUserService creates a new [UserService]. Users cannot change their name
+// to a name which is reserved by reserved, when it is not nil.
+func NewUserService(log zerolog.Logger, users chatusers.UsersStore, reserved chataccounts.NameReserver, typing chatusers.TypingIndicator, blocks chatusers.BlockList, avatars *chatavatars.Avatars, pub chatevents.Publisher) *UserService {
 	if typing == nil {
 		typing = chatusers.NewTypingIndicator(0)
 	}
+	if blocks == nil {
+		blocks = chatusers.NewBlockList()
+	}
 
-	return &UserService{
-		log:    log,
-		users:  users,
-		typing: typing,
-		event:  pub,
+	svc := &UserService{
+		log:      log,
+		users:    users,
+		reserved: reserved,
+		typing:   typing,
+		blocks:   blocks,
+		avatar:   avatars,
+		expiry:   newStatusExpiries(),
+		event:    pub,
 	}
+	svc.resumeExpiries()
+	return svc
 }
 
-func (svc *UserService) UpdateDetails(_ context.Context, req *connect.Request[apiv1.UpdateDetailsRequest]) (*connect.Response[emptypb.Empty], error) {
-	//TODO implement me
-	panic("implement me")
+// resumeExpiries schedules the expiry of custom statuses of users which are
+// persisted before a restart.
+func (svc *UserService) resumeExpiries() {
+	for uid, user := range svc.users.All() {
+		if expires := user.Custom.Expires; !expires.IsZero() {
+			svc.expiry.schedule(uid, expires, func() {
+				svc.expireCustomStatus(uid, expires)
+			})
+		}
+	}
+}
+
+func (svc *UserService) UpdateDetails(ctx context.Context, req *connect.Request[apiv1.UpdateDetailsRequest]) (*connect.Response[emptypb.Empty], error) {
+	details, err := apiv1.ToUserDetails(req.Msg.Details)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// only change the details of the stored user, the user from the context
+	// may be outdated
+	var invalid error
+	uid := getUser(ctx).ID
+	before, after, err := svc.users.UpdateFunc(uid, func(user *chatusers.User) error {
+		if svc.reserved != nil &&
+			chatusers.NameKey(details.Name) != chatusers.NameKey(user.Name) &&
+			svc.reserved.IsReserved(details.Name) {
+			return errors.New(chataccounts.ErrNameReserved)
+		}
+
+		updated, err := user.WithDetails(details)
+		if err != nil {
+			invalid = err
+			return err
+		}
+		*user = updated
+		return nil
+	})
+	switch {
+	case invalid != nil:
+		return nil, connect.NewError(connect.CodeInvalidArgument, invalid)
+	case errors.Is(err, chatusers.ErrNameAlreadyExists),
+		errors.Is(err, chataccounts.ErrNameReserved):
+		return nil, connect.NewError(connect.CodeAlreadyExists, err)
+	case err != nil:
+		return nil, errors.Wrap(err, ErrUpdateUserDetails)
+	}
+
+	go svc.event.Publish(&event.UserUpdateEvent{
+		UserID: uid,
+		Before: before.UserDetails,
+		After:  after.UserDetails,
+	})
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
 func (svc *UserService) UpdateStatus(ctx context.Context, req *connect.Request[apiv1.UpdateStatusRequest]) (*connect.Response[emptypb.Empty], error) {
-	user := getUser(ctx)
-	before := user.Status
+	status, err := req.Msg.Status.ToChatUserStatus()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
-	user.Status = req.Msg.Status.ToChatUserStatus()
-	if err := svc.users.Update(user.ID, user.User); err != nil {
+	custom := apiv1.ToCustomStatus(req.Msg.CustomStatus)
+	if err := custom.Validate(time.Now()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	uid := getUser(ctx).ID
+	before, after, err := svc.users.UpdateFunc(uid, func(user *chatusers.User) error {
+		user.Status = status
+		user.Custom = custom
+		return nil
+	})
+	if err != nil {
 		return nil, errors.Wrap(err, ErrChangeUserStatus)
 	}
 
+	svc.expiry.schedule(uid, custom.Expires, func() {
+		svc.expireCustomStatus(uid, custom.Expires)
+	})
+
 	go svc.event.Publish(&event.UserStatusEvent{
-		UserID:      user.ID,
-		UserDetails: user.UserDetails,
-		Before:      before,
-		After:       user.Status,
+		UserID:      uid,
+		UserDetails: after.UserDetails,
+		Before:      before.Status,
+		After:       after.Status,
+		Custom:      after.Custom,
 	})
 
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
+// expireCustomStatus clears the custom status of the user, unless it has been
+// changed since it was set to expire at expires.
+func (svc *UserService) expireCustomStatus(uid chatusers.UserID, expires time.Time) {
+	_, user, err := svc.users.UpdateFunc(uid, func(user *chatusers.User) error {
+		if !user.Custom.Expires.Equal(expires) {
+			return errors.New(errStatusChanged)
+		}
+		user.Custom = chatusers.CustomStatus{}
+		return nil
+	})
+	if errors.Is(err, chatusers.ErrUserNotFound) || errors.Is(err, errStatusChanged) {
+		// the user left or changed its custom status
+		return
+	}
+	if err != nil {
+		svc.log.Warn().Err(err).
+			Stringer("user_id", uid).
+			Msg("failed to clear expired custom status")
+		return
+	}
+
+	svc.event.Publish(&event.UserStatusEvent{
+		UserID:      uid,
+		UserDetails: user.UserDetails,
+		Before:      user.Status,
+		After:       user.Status,
+	})
+}
+
 func (svc *UserService) IndicateTyping(ctx context.Context, req *connect.Request[apiv1.IndicateTypingRequest]) (*connect.Response[emptypb.Empty], error) {
 	receiver, err := req.Msg.ReceiverId.ParseUUID()
 	if err != nil {
@@ -71,7 +190,7 @@ func (svc *UserService) IndicateTyping(ctx context.Context, req *connect.Request
 	}
 
 	user := getUser(ctx)
-	svc.typing.IndicateTyping(user.ID, req.Msg.Typing, func(typing bool) {
+	svc.typing.IndicateTyping(user.ID, receiver, req.Msg.Typing, func(typing bool) {
 		svc.event.Publish(&event.UserTypingEvent{
 			UserID:      user.ID,
 			UserDetails: user.UserDetails,
@@ -90,6 +209,10 @@ func (svc *UserService) SendChat(ctx context.Context, req *connect.Request[apiv1
 	}
 
 	user := getUser(ctx)
+	if receiver != uuid.Nil && svc.blocks.IsBlocked(receiver, user.ID) {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New(ErrBlockedByReceiver))
+	}
+
 	go svc.event.Publish(&event.ChatEvent{
 		ChatID:      uuid.New(),
 		UserID:      user.ID,
@@ -101,7 +224,7 @@ func (svc *UserService) SendChat(ctx context.Context, req *connect.Request[apiv1
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
-func (svc *UserService) EditChat(_ context.Context, req *connect.Request[apiv1.EditChatRequest]) (*connect.Response[emptypb.Empty], error) {
+func (svc *UserService) EditChat(ctx context.Context, req *connect.Request[apiv1.EditChatRequest]) (*connect.Response[emptypb.Empty], error) {
 	chat, receiver, err := req.Msg.Chat.ParseUUIDs()
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
@@ -112,6 +235,7 @@ func (svc *UserService) EditChat(_ context.Context, req *connect.Request[apiv1.E
 
 	go svc.event.Publish(&event.ChatEditEvent{
 		ChatID:     chat,
+		UserID:     getUser(ctx).ID,
 		ReceiverID: receiver,
 		Text:       req.Msg.Text,
 	})
@@ -139,3 +263,81 @@ func (svc *UserService) EmojiReply(ctx context.Context, req *connect.Request[api
 
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
+
+func (svc *UserService) BlockUser(ctx context.Context, req *connect.Request[apiv1.BlockUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	blocked, err := req.Msg.UserId.ParseUUID()
+	if err != nil || blocked == uuid.Nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(ErrInvalidUserID))
+	}
+	if !svc.users.Has(blocked) {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New(chatusers.ErrUserNotFound))
+	}
+
+	user := getUser(ctx)
+	if err = svc.blocks.Block(user.ID, blocked); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	svc.log.Info().
+		Stringer("user", user).
+		Stringer("blocked", blocked).
+		Msg("blocked user")
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (svc *UserService) UnblockUser(ctx context.Context, req *connect.Request[apiv1.UnblockUserRequest]) (*connect.Response[emptypb.Empty], error) {
+	blocked, err := req.Msg.UserId.ParseUUID()
+	if err != nil || blocked == uuid.Nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(ErrInvalidUserID))
+	}
+
+	svc.blocks.Unblock(getUser(ctx).ID, blocked)
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (svc *UserService) ListBlocked(ctx context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[apiv1.ListBlockedResponse], error) {
+	blocked := svc.blocks.Blocked(getUser(ctx).ID)
+	res := make([]*apiv1.UUID, 0, len(blocked))
+	for _, uid := range blocked {
+		res = append(res, apiv1.NewUUID(uid))
+	}
+
+	return connect.NewResponse(&apiv1.ListBlockedResponse{
+		UserIds: res,
+	}), nil
+}
+
+// UploadAvatar stores the image and makes it the user's picture.
+func (svc *UserService) UploadAvatar(ctx context.Context, req *connect.Request[apiv1.UploadAvatarRequest]) (*connect.Response[apiv1.UploadAvatarResponse], error) {
+	if svc.avatar == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New(ErrAvatarsDisabled))
+	}
+
+	picture, err := svc.avatar.Upload(req.Msg.Image)
+	if err != nil {
+		if errors.Is(err, chatavatars.ErrAvatarTooLarge) || errors.Is(err, chatavatars.ErrInvalidImage) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, errors.Wrap(err, ErrUploadAvatar)
+	}
+
+	uid := getUser(ctx).ID
+	before, after, err := svc.users.UpdateFunc(uid, func(user *chatusers.User) error {
+		user.Picture = picture
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrUploadAvatar)
+	}
+
+	go svc.event.Publish(&event.UserUpdateEvent{
+		UserID: uid,
+		Before: before.UserDetails,
+		After:  after.UserDetails,
+	})
+
+	return connect.NewResponse(&apiv1.UploadAvatarResponse{
+		Picture: picture,
+	}), nil
+}
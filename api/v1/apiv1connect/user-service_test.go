@@ -0,0 +1,105 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1connect
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	"github.com/roeldev/demo-chatroom/api/v1"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_UpdateDetails(t *testing.T) {
+	t.Run("partial", func(t *testing.T) {
+		ts := newTestServer(t)
+		uid, _, token := ts.join(t, "alice", chatusers.Role_Moderator)
+		_, want, err := ts.users.UpdateFunc(uid, func(user *chatusers.User) error {
+			user.Picture = "/avatars/alice.png"
+			user.Status = chatusers.Status_Busy
+			user.Flags = chatusers.Flag_NoDirectMessages
+			user.Custom = chatusers.CustomStatus{Text: "In a meeting", Emoji: "📅"}
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = ts.user.UpdateDetails(t.Context(), newRequest(&apiv1.UpdateDetailsRequest{
+			Details: &apiv1.UserDetails{
+				Name:    "Alice",
+				Profile: &apiv1.Profile{Pronouns: "she/her"},
+			},
+		}, token))
+		require.NoError(t, err)
+
+		have, err := ts.users.Get(uid)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", have.Name)
+		assert.Equal(t, "she/her", have.Profile.Pronouns)
+
+		// fields which are not part of the details are untouched
+		assert.Equal(t, want.Picture, have.Picture)
+		assert.Equal(t, want.Color1, have.Color1)
+		assert.Equal(t, want.Role, have.Role)
+		assert.Equal(t, want.Status, have.Status)
+		assert.Equal(t, want.Flags, have.Flags)
+		assert.Equal(t, want.Custom, have.Custom)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		ts := newTestServer(t)
+		uid, _, token := ts.join(t, "bob", chatusers.Role_Member)
+
+		const n = 20
+		statuses := []apiv1.UserStatus{apiv1.UserStatus_USER_STATUS_AWAY, apiv1.UserStatus_USER_STATUS_BUSY}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range n {
+				_, err := ts.user.UpdateDetails(t.Context(), newRequest(&apiv1.UpdateDetailsRequest{
+					Details: &apiv1.UserDetails{Name: "Bob " + strconv.Itoa(i)},
+				}, token))
+				assert.NoError(t, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range n {
+				_, err := ts.user.UpdateStatus(t.Context(), newRequest(&apiv1.UpdateStatusRequest{
+					Status: statuses[i%2],
+				}, token))
+				assert.NoError(t, err)
+			}
+		}()
+		wg.Wait()
+
+		// both updates survive, regardless of their order
+		have, err := ts.users.Get(uid)
+		require.NoError(t, err)
+		assert.Equal(t, "Bob "+strconv.Itoa(n-1), have.Name)
+		assert.Equal(t, chatusers.Status_Busy, have.Status)
+	})
+}
+
+func TestUserService_UpdateStatus(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		ts := newTestServer(t)
+		uid, _, token := ts.join(t, "alice", chatusers.Role_Member)
+
+		_, err := ts.user.UpdateStatus(t.Context(), newRequest(&apiv1.UpdateStatusRequest{
+			Status: apiv1.UserStatus(42),
+		}, token))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		have, err := ts.users.Get(uid)
+		require.NoError(t, err)
+		assert.Equal(t, chatusers.Status_Default, have.Status)
+	})
+}
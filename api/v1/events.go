@@ -50,8 +50,10 @@ func NewEventStreamResponseEvent(typ event.Type) EventStreamResponseEvent {
 	case *event.UserStatusEvent:
 		return &EventStreamResponse_UserStatus{
 			UserStatus: &UserStatusEvent{
-				User:   NewEventUser(et),
-				Before: NewUserStatus(et.Before),
+				User:         NewEventUser(et),
+				Status:       NewUserStatus(et.After),
+				Before:       NewUserStatus(et.Before),
+				CustomStatus: NewCustomStatus(et.Custom),
 			},
 		}
 
@@ -82,7 +84,6 @@ func NewEventStreamResponseEvent(typ event.Type) EventStreamResponseEvent {
 	default:
 		panic("apiv1.NewEventStreamResponseEvent: " + reflect.TypeOf(et).String() + " is not implemented")
 	}
-	return nil
 }
 
 type PreviousEventsResponseEvent = isPreviousEventsResponse_PreviousEvent_Event
@@ -123,5 +124,4 @@ func NewPreviousEventsResponseEvent(typ event.Type) PreviousEventsResponseEvent
 	default:
 		panic("apiv1.NewPreviousEventsResponseEvent: " + reflect.TypeOf(et).String() + " is not implemented")
 	}
-	return nil
 }
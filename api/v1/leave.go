@@ -14,6 +14,9 @@ func NewLeaveReason(reason event.LeaveReason) LeaveReason {
 	case event.Disconnected:
 		return LeaveReason_LEAVE_REASON_DISCONNECTED
 
+	case event.Kicked:
+		return LeaveReason_LEAVE_REASON_KICKED
+
 	default:
 		panic("apiv1: LeaveReason " + itoa(int64(reason)) + " is invalid")
 	}
@@ -6,26 +6,85 @@ package apiv1
 
 import (
 	"strconv"
+	"time"
 
+	"github.com/go-pogo/errors"
 	"github.com/roeldev/demo-chatroom/chatusers"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	ErrInvalidUserFlag   errors.Msg = "invalid user flag"
+	ErrInvalidUserStatus errors.Msg = "invalid user status"
+	ErrInvalidUserRole   errors.Msg = "invalid user role"
 )
 
 func FromJoinRequest(x *JoinRequest) chatusers.UserOption {
 	return func(u *chatusers.User) error {
-		u.Name = x.User.Name
-		u.Initials = x.User.Initials
-
-		var err error
-		if u.Color1, err = x.User.Color1.Decode(); err != nil {
+		if err := fromUserDetails(u, x.User); err != nil {
 			return err
 		}
-		if u.Color2, err = x.User.Color2.Decode(); err != nil {
+
+		var err error
+		u.Flags, err = ToChatUserFlags(x.Flags)
+		return err
+	}
+}
+
+// FromJoinBotRequest sets the details of the bot, which is always flagged as
+// bot and has the bot role.
+func FromJoinBotRequest(x *JoinBotRequest) chatusers.UserOption {
+	return func(u *chatusers.User) error {
+		if err := fromUserDetails(u, x.User); err != nil {
 			return err
 		}
 
-		u.Flags = x.Flags.ToChatUserFlags()
+		u.Flags = chatusers.Flag_IsBot
+		u.Role = chatusers.Role_Bot
+		return nil
+	}
+}
+
+func FromRegisterRequest(x *RegisterRequest) chatusers.UserOption {
+	return func(u *chatusers.User) error {
+		return fromUserDetails(u, x.User)
+	}
+}
+
+func FromLoginRequest(x *LoginRequest) chatusers.UserOption {
+	return func(u *chatusers.User) error {
+		return fromUserDetails(u, x.User)
+	}
+}
+
+func fromUserDetails(u *chatusers.User, x *UserDetails) error {
+	if x == nil {
 		return nil
 	}
+
+	u.Name = x.Name
+	u.Initials = x.Initials
+	u.Profile = chatusers.Profile{
+		Pronouns: x.Profile.GetPronouns(),
+		Bio:      x.Profile.GetBio(),
+		TimeZone: x.Profile.GetTimeZone(),
+	}
+
+	var err error
+	if u.Color1, err = x.Color1.Decode(); err != nil {
+		return err
+	}
+	if u.Color2, err = x.Color2.Decode(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ToUserDetails decodes x into [chatusers.UserDetails].
+func ToUserDetails(x *UserDetails) (chatusers.UserDetails, error) {
+	var u chatusers.User
+	err := fromUserDetails(&u, x)
+	return u.UserDetails, err
 }
 
 func NewUserDetails(u chatusers.UserDetails) *UserDetails {
@@ -34,38 +93,115 @@ func NewUserDetails(u chatusers.UserDetails) *UserDetails {
 		Initials: u.Initials,
 		Color1:   NewColor(u.Color1),
 		Color2:   NewColor(u.Color2),
+		Picture:  u.Picture,
+		Profile:  NewProfile(u.Profile),
 	}
 }
 
-func NewUserFlags(flag chatusers.Flag) UserFlag {
-	switch flag {
-	case chatusers.Flag_None:
-		return UserFlag_USER_FLAG_NONE
+// NewProfile returns nil when the profile is empty.
+func NewProfile(p chatusers.Profile) *Profile {
+	if p == (chatusers.Profile{}) {
+		return nil
+	}
 
-	case chatusers.Flag_IsBot:
-		return UserFlag_USER_FLAG_IS_BOT
+	return &Profile{
+		Pronouns: p.Pronouns,
+		Bio:      p.Bio,
+		TimeZone: p.TimeZone,
+	}
+}
 
-	case chatusers.Flag_NoDirectMessages:
-		return UserFlag_USER_FLAG_NO_DM
+// NewCustomStatus returns nil when the custom status is empty.
+func NewCustomStatus(cs chatusers.CustomStatus) *CustomStatus {
+	if cs.IsZero() {
+		return nil
+	}
 
-	default:
-		panic("apiv1: Flag " + itoa(int64(flag)) + " is invalid")
+	res := &CustomStatus{
+		Text:  cs.Text,
+		Emoji: cs.Emoji,
+	}
+	if !cs.Expires.IsZero() {
+		res.ExpiresAt = timestamppb.New(cs.Expires)
+	}
+	return res
+}
+
+// ToCustomStatus decodes x into a [chatusers.CustomStatus]. An empty expiry
+// time means the custom status never expires. The expiry time is truncated to
+// milliseconds, the precision it is persisted with.
+func ToCustomStatus(x *CustomStatus) chatusers.CustomStatus {
+	if x == nil {
+		return chatusers.CustomStatus{}
 	}
+
+	res := chatusers.CustomStatus{
+		Text:  x.Text,
+		Emoji: x.Emoji,
+	}
+	if x.ExpiresAt != nil {
+		res.Expires = x.ExpiresAt.AsTime().Truncate(time.Millisecond)
+	}
+	return res
+}
+
+// NewUserFlags returns the set of [UserFlag]s which are set in flags.
+func NewUserFlags(flags chatusers.Flag) []UserFlag {
+	res := make([]UserFlag, 0, 2)
+	if flags.Has(chatusers.Flag_IsBot) {
+		res = append(res, UserFlag_USER_FLAG_IS_BOT)
+	}
+	if flags.Has(chatusers.Flag_NoDirectMessages) {
+		res = append(res, UserFlag_USER_FLAG_NO_DM)
+	}
+	return res
 }
 
-func (x UserFlag) ToChatUserFlags() chatusers.Flag {
+// ToChatUserFlags combines the set of [UserFlag]s into a [chatusers.Flag]. It
+// returns an [ErrInvalidUserFlag] error when a flag is unknown.
+func ToChatUserFlags(flags []UserFlag) (chatusers.Flag, error) {
+	res := chatusers.Flag_None
+	for _, x := range flags {
+		flag, err := x.ToChatUserFlag()
+		if err != nil {
+			return chatusers.Flag_None, err
+		}
+		res |= flag
+	}
+	return res, nil
+}
+
+func (x UserFlag) ToChatUserFlag() (chatusers.Flag, error) {
 	switch x {
 	case UserFlag_USER_FLAG_NONE:
-		return chatusers.Flag_None
+		return chatusers.Flag_None, nil
 
 	case UserFlag_USER_FLAG_IS_BOT:
-		return chatusers.Flag_IsBot
+		return chatusers.Flag_IsBot, nil
 
 	case UserFlag_USER_FLAG_NO_DM:
-		return chatusers.Flag_NoDirectMessages
+		return chatusers.Flag_NoDirectMessages, nil
 
 	default:
-		panic("apiv1: UserFlag " + itoa(int64(x)) + " is invalid")
+		return chatusers.Flag_None, errors.New(ErrInvalidUserFlag)
+	}
+}
+
+// ToChatUserRole converts the [UserRole] into a [chatusers.Role]. It returns an
+// [ErrInvalidUserRole] error when the role is unknown or none.
+func (x UserRole) ToChatUserRole() (chatusers.Role, error) {
+	switch x {
+	case UserRole_USER_ROLE_MEMBER:
+		return chatusers.Role_Member, nil
+
+	case UserRole_USER_ROLE_MODERATOR:
+		return chatusers.Role_Moderator, nil
+
+	case UserRole_USER_ROLE_ADMIN:
+		return chatusers.Role_Admin, nil
+
+	default:
+		return chatusers.Role_None, errors.New(ErrInvalidUserRole)
 	}
 }
 
@@ -88,22 +224,38 @@ func NewUserStatus(stat chatusers.Status) UserStatus {
 	}
 }
 
-func (x UserStatus) ToChatUserStatus() chatusers.Status {
+// ToChatUserStatuses converts the [UserStatus]es into [chatusers.Status]es. It
+// returns an [ErrInvalidUserStatus] error when a status is unknown.
+func ToChatUserStatuses(statuses []UserStatus) ([]chatusers.Status, error) {
+	res := make([]chatusers.Status, 0, len(statuses))
+	for _, x := range statuses {
+		stat, err := x.ToChatUserStatus()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, stat)
+	}
+	return res, nil
+}
+
+// ToChatUserStatus converts the [UserStatus] into a [chatusers.Status]. It
+// returns an [ErrInvalidUserStatus] error when the status is unknown.
+func (x UserStatus) ToChatUserStatus() (chatusers.Status, error) {
 	switch x {
 	case UserStatus_USER_STATUS_DEFAULT:
-		return chatusers.Status_Default
+		return chatusers.Status_Default, nil
 
 	case UserStatus_USER_STATUS_UNRESPONSIVE:
-		return chatusers.Status_Unresponsive
+		return chatusers.Status_Unresponsive, nil
 
 	case UserStatus_USER_STATUS_BUSY:
-		return chatusers.Status_Busy
+		return chatusers.Status_Busy, nil
 
 	case UserStatus_USER_STATUS_AWAY:
-		return chatusers.Status_Away
+		return chatusers.Status_Away, nil
 
 	default:
-		panic("apiv1: UserStatus " + itoa(int64(x)) + " is invalid")
+		return chatusers.Status_Default, errors.New(ErrInvalidUserStatus)
 	}
 }
 
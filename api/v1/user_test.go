@@ -0,0 +1,78 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package apiv1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserFlags(t *testing.T) {
+	tests := map[string]struct {
+		flags chatusers.Flag
+		want  []UserFlag
+	}{
+		"none": {
+			flags: chatusers.Flag_None,
+			want:  []UserFlag{},
+		},
+		"bot": {
+			flags: chatusers.Flag_IsBot,
+			want:  []UserFlag{UserFlag_USER_FLAG_IS_BOT},
+		},
+		"bot without dm": {
+			flags: chatusers.Flag_IsBot | chatusers.Flag_NoDirectMessages,
+			want:  []UserFlag{UserFlag_USER_FLAG_IS_BOT, UserFlag_USER_FLAG_NO_DM},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := NewUserFlags(tc.flags)
+			assert.Equal(t, tc.want, have)
+
+			flags, err := ToChatUserFlags(have)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.flags, flags)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		flags, err := ToChatUserFlags([]UserFlag{UserFlag_USER_FLAG_NO_DM, 42})
+		assert.ErrorIs(t, err, ErrInvalidUserFlag)
+		assert.Equal(t, chatusers.Flag_None, flags)
+	})
+}
+
+func TestToChatUserStatuses(t *testing.T) {
+	have, err := ToChatUserStatuses([]UserStatus{UserStatus_USER_STATUS_BUSY, UserStatus_USER_STATUS_AWAY})
+	assert.NoError(t, err)
+	assert.Equal(t, []chatusers.Status{chatusers.Status_Busy, chatusers.Status_Away}, have)
+
+	_, err = ToChatUserStatuses([]UserStatus{42})
+	assert.ErrorIs(t, err, ErrInvalidUserStatus)
+}
+
+func TestUserRole_ToChatUserRole(t *testing.T) {
+	have, err := UserRole_USER_ROLE_MODERATOR.ToChatUserRole()
+	assert.NoError(t, err)
+	assert.Equal(t, chatusers.Role_Moderator, have)
+
+	for _, x := range []UserRole{UserRole_USER_ROLE_NONE, 42} {
+		_, err = x.ToChatUserRole()
+		assert.ErrorIs(t, err, ErrInvalidUserRole)
+	}
+}
+
+func TestToCustomStatus(t *testing.T) {
+	expires := time.Date(2025, 6, 1, 12, 0, 0, 123456789, time.UTC)
+	have := ToCustomStatus(&CustomStatus{Text: "Lunch", ExpiresAt: timestamppb.New(expires)})
+	assert.Equal(t, time.UnixMilli(expires.UnixMilli()).UTC(), have.Expires, "truncated to milliseconds")
+	assert.Equal(t, chatusers.CustomStatus{}, ToCustomStatus(nil))
+}
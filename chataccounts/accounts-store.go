@@ -0,0 +1,216 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chataccounts
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+var _ AccountsStore = (*accountsStore)(nil)
+
+type accountsStore struct {
+	mut      sync.RWMutex
+	file     string
+	hasher   PasswordHasher
+	reserved map[string]struct{}
+	accounts map[AccountID]Account
+	names    map[string]AccountID
+	dummy    string // hash to verify against when an account does not exist
+}
+
+// NewAccountsStore creates a new in-memory [AccountsStore]. The reserved
+// names cannot be registered.
+func NewAccountsStore(hasher PasswordHasher, reserved ...string) AccountsStore {
+	as, _ := NewFileAccountsStore("", hasher, reserved...)
+	return as
+}
+
+// NewFileAccountsStore creates a new [AccountsStore] which stores its
+// accounts in file. Existing accounts are loaded from file when it exists.
+func NewFileAccountsStore(file string, hasher PasswordHasher, reserved ...string) (AccountsStore, error) {
+	if hasher == nil {
+		hasher = DefaultArgon2id
+	}
+
+	as := &accountsStore{
+		file:     file,
+		hasher:   hasher,
+		reserved: make(map[string]struct{}, len(reserved)),
+		accounts: make(map[AccountID]Account, 8),
+		names:    make(map[string]AccountID, 8),
+	}
+	for _, name := range reserved {
+		as.reserved[nameKey(name)] = struct{}{}
+	}
+
+	var err error
+	if as.dummy, err = hasher.HashPassword(uuid.NewString()); err != nil {
+		return nil, err
+	}
+	if file == "" {
+		return as, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return as, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var list []Account
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, acc := range list {
+		as.accounts[acc.ID] = acc
+		as.names[nameKey(acc.Name)] = acc.ID
+	}
+	return as, nil
+}
+
+func nameKey(name string) string { return chatusers.NameKey(name) }
+
+func (as *accountsStore) IsReserved(name string) bool {
+	key := nameKey(name)
+
+	as.mut.RLock()
+	defer as.mut.RUnlock()
+
+	if _, ok := as.reserved[key]; ok {
+		return true
+	}
+	_, ok := as.names[key]
+	return ok
+}
+
+func (as *accountsStore) Register(name, password string) (Account, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Account{}, errors.New(ErrAccountNameRequired)
+	}
+	if err := ValidatePassword(password); err != nil {
+		return Account{}, err
+	}
+	if as.IsReserved(name) {
+		return Account{}, errors.New(ErrNameReserved)
+	}
+
+	hash, err := as.hasher.HashPassword(password)
+	if err != nil {
+		return Account{}, err
+	}
+
+	as.mut.Lock()
+	defer as.mut.Unlock()
+
+	key := nameKey(name)
+	if _, ok := as.names[key]; ok {
+		return Account{}, errors.New(ErrNameReserved)
+	}
+
+	acc := Account{
+		ID:           uuid.New(),
+		Name:         name,
+		PasswordHash: hash,
+		Role:         chatusers.Role_Member,
+		Created:      time.Now(),
+	}
+	as.accounts[acc.ID] = acc
+	as.names[key] = acc.ID
+
+	if err = as.save(); err != nil {
+		delete(as.accounts, acc.ID)
+		delete(as.names, key)
+		return Account{}, err
+	}
+	return acc, nil
+}
+
+func (as *accountsStore) Authenticate(name, password string) (Account, error) {
+	as.mut.RLock()
+	id, ok := as.names[nameKey(name)]
+	acc := as.accounts[id]
+	as.mut.RUnlock()
+
+	if !ok {
+		// verify anyway, so the response time does not reveal whether the
+		// account exists
+		_ = VerifyPassword(as.dummy, password)
+		return Account{}, errors.New(ErrInvalidCredentials)
+	}
+	if err := VerifyPassword(acc.PasswordHash, password); err != nil {
+		return Account{}, errors.New(ErrInvalidCredentials)
+	}
+	return acc, nil
+}
+
+func (as *accountsStore) Get(id AccountID) (Account, error) {
+	as.mut.RLock()
+	defer as.mut.RUnlock()
+
+	acc, ok := as.accounts[id]
+	if !ok {
+		return acc, errors.New(ErrAccountNotFound)
+	}
+	return acc, nil
+}
+
+func (as *accountsStore) Delete(id AccountID) error {
+	as.mut.Lock()
+	defer as.mut.Unlock()
+
+	acc, ok := as.accounts[id]
+	if !ok {
+		return errors.New(ErrAccountNotFound)
+	}
+
+	key := nameKey(acc.Name)
+	delete(as.accounts, id)
+	delete(as.names, key)
+
+	if err := as.save(); err != nil {
+		as.accounts[id] = acc
+		as.names[key] = id
+		return err
+	}
+	return nil
+}
+
+// save writes all accounts to file. It must be called while holding the
+// write lock.
+func (as *accountsStore) save() error {
+	if as.file == "" {
+		return nil
+	}
+
+	list := make([]Account, 0, len(as.accounts))
+	for _, acc := range as.accounts {
+		list = append(list, acc)
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	tmp := as.file + ".tmp"
+	if err = os.MkdirAll(filepath.Dir(as.file), 0o750); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp, as.file))
+}
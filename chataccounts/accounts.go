@@ -0,0 +1,75 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chataccounts
+
+import (
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+const (
+	ErrAccountNotFound     errors.Msg = "account does not exist"
+	ErrInvalidCredentials  errors.Msg = "invalid name or password"
+	ErrNameReserved        errors.Msg = "name is reserved"
+	ErrAccountNameRequired errors.Msg = "account name should not be empty"
+)
+
+type AccountID = uuid.UUID
+
+// Account is a registered user which logs in with a password. Its name is
+// reserved, so guests cannot join with it.
+type Account struct {
+	ID           AccountID      `json:"id"`
+	Name         string         `json:"name"`
+	PasswordHash string         `json:"password_hash"`
+	Role         chatusers.Role `json:"role,omitempty"`
+	Created      time.Time      `json:"created"`
+}
+
+// NameReserver indicates if a name is reserved and cannot be used by guests.
+type NameReserver interface {
+	IsReserved(name string) bool
+}
+
+type AccountsStore interface {
+	NameReserver
+	// Register creates a new account with the provided name and password.
+	Register(name, password string) (Account, error)
+	// Authenticate returns the account when the password matches.
+	Authenticate(name, password string) (Account, error)
+	Get(id AccountID) (Account, error)
+	// Delete removes the account, which releases its name.
+	Delete(id AccountID) error
+}
+
+type Config struct {
+	// Enabled enables the registration of and login with accounts.
+	Enabled bool `env:"ACCOUNTS_ENABLED"`
+	// File is the JSON file the accounts are stored in. Accounts are only
+	// kept in memory when empty.
+	File string `env:"ACCOUNTS_FILE"`
+	// PasswordHash is the algorithm used to hash new passwords: argon2id or
+	// bcrypt. Passwords hashed with either algorithm can be verified.
+	PasswordHash string `env:"ACCOUNTS_PASSWORD_HASH" default:"argon2id"`
+	// ReservedNames cannot be used by guests or registered as account.
+	ReservedNames []string `env:"ACCOUNTS_RESERVED_NAMES"`
+}
+
+// NewAccountsStore creates a new [AccountsStore] based on the config. It
+// returns nil when accounts are not enabled.
+func (conf Config) NewAccountsStore() (AccountsStore, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+
+	hasher, err := NewPasswordHasher(conf.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileAccountsStore(conf.File, hasher, conf.ReservedNames...)
+}
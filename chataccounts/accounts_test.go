@@ -0,0 +1,81 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chataccounts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testArgon2id = Argon2id{Time: 1, Memory: 64, Threads: 1, KeyLen: 16, SaltLen: 8}
+
+func TestVerifyPassword(t *testing.T) {
+	for name, hasher := range map[string]PasswordHasher{
+		"argon2id": testArgon2id,
+		"bcrypt":   Bcrypt{Cost: bcrypt.MinCost},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hash, err := hasher.HashPassword("correct horse")
+			require.NoError(t, err)
+
+			assert.NoError(t, VerifyPassword(hash, "correct horse"))
+			assert.ErrorIs(t, VerifyPassword(hash, "battery staple"), ErrPasswordMismatch)
+		})
+	}
+
+	assert.ErrorIs(t, VerifyPassword("plain", "plain"), ErrUnknownHashFormat)
+}
+
+func TestAccountsStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "accounts.json")
+	store, err := NewFileAccountsStore(file, testArgon2id, "admin")
+	require.NoError(t, err)
+
+	_, err = store.Register("Admin", "password123")
+	assert.True(t, errors.Is(err, ErrNameReserved))
+	_, err = store.Register("Bob", "short")
+	assert.True(t, errors.Is(err, ErrPasswordTooShort))
+
+	acc, err := store.Register("Bob", "password123")
+	require.NoError(t, err)
+	assert.True(t, store.IsReserved("bob"))
+
+	_, err = store.Register("BOB", "password123")
+	assert.True(t, errors.Is(err, ErrNameReserved))
+
+	_, err = store.Authenticate("bob", "wrong password")
+	assert.True(t, errors.Is(err, ErrInvalidCredentials))
+	_, err = store.Authenticate("alice", "password123")
+	assert.True(t, errors.Is(err, ErrInvalidCredentials))
+
+	have, err := store.Authenticate("bob", "password123")
+	assert.NoError(t, err)
+	assert.Equal(t, acc.ID, have.ID)
+
+	t.Run("reload", func(t *testing.T) {
+		store, err := NewFileAccountsStore(file, testArgon2id)
+		require.NoError(t, err)
+		assert.True(t, store.IsReserved("Bob"))
+
+		have, err := store.Get(acc.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bob", have.Name)
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(acc.ID))
+		assert.False(t, store.IsReserved("bob"))
+		assert.True(t, errors.Is(store.Delete(acc.ID), ErrAccountNotFound))
+
+		store, err := NewFileAccountsStore(file, testArgon2id)
+		require.NoError(t, err)
+		_, err = store.Get(acc.ID)
+		assert.True(t, errors.Is(err, ErrAccountNotFound))
+	})
+}
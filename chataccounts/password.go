@@ -0,0 +1,161 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chataccounts
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/go-pogo/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ErrPasswordTooShort    errors.Msg = "password is too short"
+	ErrPasswordTooLong     errors.Msg = "password is too long"
+	ErrPasswordMismatch    errors.Msg = "password does not match"
+	ErrUnknownHashFormat   errors.Msg = "unknown password hash format"
+	ErrUnsupportedHashAlgo errors.Msg = "unsupported password hash algorithm"
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength is the maximum number of bytes of a password, which
+	// is limited by bcrypt.
+	MaxPasswordLength = 72
+)
+
+// PasswordHasher hashes passwords for storage.
+type PasswordHasher interface {
+	HashPassword(password string) (string, error)
+}
+
+// NewPasswordHasher returns the [PasswordHasher] for the algorithm, which is
+// either "argon2id" or "bcrypt".
+func NewPasswordHasher(algo string) (PasswordHasher, error) {
+	switch strings.ToLower(algo) {
+	case "argon2id", "argon2", "":
+		return DefaultArgon2id, nil
+	case "bcrypt":
+		return Bcrypt{Cost: bcrypt.DefaultCost}, nil
+	default:
+		return nil, errors.Wrapf(errors.New(ErrUnsupportedHashAlgo), "%q", algo)
+	}
+}
+
+// ValidatePassword checks if password meets the length requirements.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return errors.New(ErrPasswordTooShort)
+	}
+	if len(password) > MaxPasswordLength {
+		return errors.New(ErrPasswordTooLong)
+	}
+	return nil
+}
+
+// VerifyPassword checks if password matches the argon2id or bcrypt encoded
+// hash.
+func VerifyPassword(hash, password string) error {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return verifyArgon2id(hash, password)
+	case strings.HasPrefix(hash, "$2a$"),
+		strings.HasPrefix(hash, "$2b$"),
+		strings.HasPrefix(hash, "$2y$"):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			return errors.New(ErrPasswordMismatch)
+		}
+		return nil
+	default:
+		return errors.New(ErrUnknownHashFormat)
+	}
+}
+
+// Argon2id hashes passwords using argon2id and encodes them in the PHC string
+// format.
+type Argon2id struct {
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+// DefaultArgon2id uses the parameters recommended by RFC 9106 for memory
+// constrained environments.
+var DefaultArgon2id = Argon2id{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+	KeyLen:  32,
+	SaltLen: 16,
+}
+
+var b64 = base64.RawStdEncoding
+
+func (a Argon2id) HashPassword(password string) (string, error) {
+	salt := make([]byte, a.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, a.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.Memory, a.Time, a.Threads,
+		b64.EncodeToString(salt),
+		b64.EncodeToString(key),
+	), nil
+}
+
+func verifyArgon2id(hash, password string) error {
+	// $argon2id$v=19$m=65536,t=3,p=4$salt$key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return errors.New(ErrUnknownHashFormat)
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return errors.New(ErrUnknownHashFormat)
+	}
+
+	var a Argon2id
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &a.Memory, &a.Time, &a.Threads); err != nil {
+		return errors.New(ErrUnknownHashFormat)
+	}
+
+	salt, err := b64.DecodeString(parts[4])
+	if err != nil {
+		return errors.New(ErrUnknownHashFormat)
+	}
+	want, err := b64.DecodeString(parts[5])
+	if err != nil {
+		return errors.New(ErrUnknownHashFormat)
+	}
+
+	have := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, uint32(len(want)))
+	if subtle.ConstantTimeCompare(have, want) != 1 {
+		return errors.New(ErrPasswordMismatch)
+	}
+	return nil
+}
+
+// Bcrypt hashes passwords using bcrypt.
+type Bcrypt struct {
+	Cost int
+}
+
+func (b Bcrypt) HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(hash), nil
+}
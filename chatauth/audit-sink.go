@@ -0,0 +1,161 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/go-pogo/errors"
+)
+
+var (
+	_ AuditSink = (*WriterAuditSink)(nil)
+	_ AuditSink = (*FileAuditSink)(nil)
+)
+
+// WriterAuditSink writes [AuditRecord]s as JSON lines to an [io.Writer], e.g.
+// [os.Stdout].
+type WriterAuditSink struct {
+	mut sync.Mutex
+	enc *json.Encoder
+}
+
+func NewWriterAuditSink(w io.Writer) *WriterAuditSink {
+	return &WriterAuditSink{enc: json.NewEncoder(w)}
+}
+
+func (ws *WriterAuditSink) WriteAudit(rec AuditRecord) error {
+	ws.mut.Lock()
+	defer ws.mut.Unlock()
+	return errors.WithStack(ws.enc.Encode(rec))
+}
+
+// FileAuditSink writes [AuditRecord]s as JSON lines to a file. The file is
+// rotated when it exceeds its maximum size, the rotated files are named
+// <file>.1 up to <file>.<maxBackups>, where <file>.1 is the most recent.
+type FileAuditSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mut  sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileAuditSink opens or creates the file at path. The file is never
+// rotated when maxSize is zero or less, and truncated instead of rotated when
+// maxBackups is zero or less.
+func NewFileAuditSink(path string, maxSize int64, maxBackups int) (*FileAuditSink, error) {
+	fs := &FileAuditSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := fs.open(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (fs *FileAuditSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(fs.path), 0o750); err != nil {
+		return errors.WithStack(err)
+	}
+
+	file, err := os.OpenFile(fs.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.WithStack(err)
+	}
+
+	fs.file = file
+	fs.size = stat.Size()
+	return nil
+}
+
+func (fs *FileAuditSink) WriteAudit(rec AuditRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	data = append(data, '\n')
+
+	fs.mut.Lock()
+	defer fs.mut.Unlock()
+
+	if fs.file == nil {
+		return errors.WithStack(os.ErrClosed)
+	}
+
+	var rotateErr error
+	if fs.maxSize > 0 && fs.size > 0 && fs.size+int64(len(data)) > fs.maxSize {
+		rotateErr = fs.rotate()
+	}
+
+	n, err := fs.file.Write(data)
+	fs.size += int64(n)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	// the record is written to the current file when rotating failed
+	return rotateErr
+}
+
+// rotate shifts the backups and replaces the current file with a new one. The
+// current file is truncated instead when no backups are kept. The current
+// file is kept when rotating fails. It must be called while holding the lock.
+func (fs *FileAuditSink) rotate() error {
+	if fs.maxBackups <= 0 {
+		if err := fs.file.Truncate(0); err != nil {
+			return errors.WithStack(err)
+		}
+		fs.size = 0
+		return nil
+	}
+
+	_ = os.Remove(fs.backup(fs.maxBackups))
+	for i := fs.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(fs.backup(i), fs.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+	}
+	if err := os.Rename(fs.path, fs.backup(1)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	prev := fs.file
+	if err := fs.open(); err != nil {
+		// keep writing to the current file under its original name
+		_ = os.Rename(fs.backup(1), fs.path)
+		return err
+	}
+	return errors.WithStack(prev.Close())
+}
+
+func (fs *FileAuditSink) backup(n int) string { return fs.path + "." + strconv.Itoa(n) }
+
+func (fs *FileAuditSink) Close() error {
+	fs.mut.Lock()
+	defer fs.mut.Unlock()
+
+	if fs.file == nil {
+		return nil
+	}
+
+	err := fs.file.Close()
+	fs.file = nil
+	return errors.WithStack(err)
+}
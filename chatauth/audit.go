@@ -0,0 +1,118 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/rs/zerolog"
+)
+
+// AuditEvent is the type of security relevant event of an [AuditRecord].
+type AuditEvent string
+
+const (
+	AuditJoin             AuditEvent = "join"
+	AuditJoinFailed       AuditEvent = "join_failed"
+	AuditRenew            AuditEvent = "renew"
+	AuditRenewFailed      AuditEvent = "renew_failed"
+	AuditLeave            AuditEvent = "leave"
+	AuditParseFailed      AuditEvent = "parse_failed"
+	AuditRevoke           AuditEvent = "revoke"
+	AuditPermissionDenied AuditEvent = "permission_denied"
+)
+
+// AuditRecord is a single entry in the audit log. It never contains a full
+// token, use [RedactToken] to include a token.
+type AuditRecord struct {
+	Time      time.Time  `json:"time"`
+	Event     AuditEvent `json:"event"`
+	UserID    string     `json:"user_id,omitempty"`
+	TokenID   string     `json:"token_id,omitempty"`
+	Token     string     `json:"token,omitempty"`
+	Peer      string     `json:"peer,omitempty"`
+	UserAgent string     `json:"user_agent,omitempty"`
+	Procedure string     `json:"procedure,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+}
+
+// WithUser sets the user id of the record, unless uid is empty.
+func (rec AuditRecord) WithUser(uid chatusers.UserID) AuditRecord {
+	if uid != uuid.Nil {
+		rec.UserID = uid.String()
+	}
+	return rec
+}
+
+// WithError sets the reason of the record to the error's message.
+func (rec AuditRecord) WithError(err error) AuditRecord {
+	if err != nil {
+		rec.Reason = err.Error()
+	}
+	return rec
+}
+
+// RedactToken returns a fingerprint of token which can be used to correlate
+// audit records, without revealing the token itself.
+func RedactToken(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// AuditSink writes [AuditRecord]s to its destination.
+type AuditSink interface {
+	WriteAudit(rec AuditRecord) error
+}
+
+// Auditor records [AuditRecord]s to an [AuditSink]. A nil Auditor discards
+// all records.
+type Auditor struct {
+	log  zerolog.Logger
+	sink AuditSink
+	now  func() time.Time
+}
+
+// NewAuditor creates a new [Auditor] which writes to sink. Failures to write
+// a record are logged to log.
+func NewAuditor(log zerolog.Logger, sink AuditSink) *Auditor {
+	if sink == nil {
+		panic("chatauth.NewAuditor: sink must not be nil")
+	}
+
+	return &Auditor{
+		log:  log,
+		sink: sink,
+		now:  time.Now,
+	}
+}
+
+// Record writes rec to the sink. Its time is set when empty.
+func (a *Auditor) Record(rec AuditRecord) {
+	if a == nil {
+		return
+	}
+	if rec.Time.IsZero() {
+		rec.Time = a.now()
+	}
+	if err := a.sink.WriteAudit(rec); err != nil {
+		a.log.Err(err).
+			Str("event", string(rec.Event)).
+			Msg("failed to write audit record")
+	}
+}
+
+// WithAuditor sets the [Auditor] which records token revocations and users
+// leaving the chatroom.
+func WithAuditor(a *Auditor) ManagerOption {
+	return func(man *Manager) { man.audit = a }
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrInvalidBindingPolicy errors.Msg = "invalid binding policy"
+	ErrInvalidTrustedProxy  errors.Msg = "invalid trusted proxy"
+)
+
+// BindingPolicy determines which properties of the client a token is bound
+// to.
+type BindingPolicy uint8
+
+const (
+	BindNone      BindingPolicy = 0
+	BindIPSubnet  BindingPolicy = 1 << 0
+	BindUserAgent BindingPolicy = 1 << 1
+	BindBoth                    = BindIPSubnet | BindUserAgent
+)
+
+// Prefix lengths of the subnet a token is bound to when using BindIPSubnet.
+// This allows the client's address to change within its network.
+const (
+	BindIPv4PrefixLen = 24
+	BindIPv6PrefixLen = 64
+)
+
+func (bp BindingPolicy) Has(p BindingPolicy) bool { return bp&p != 0 }
+
+func (bp BindingPolicy) String() string {
+	switch bp {
+	case BindNone:
+		return "none"
+	case BindIPSubnet:
+		return "ip"
+	case BindUserAgent:
+		return "user-agent"
+	case BindBoth:
+		return "both"
+	default:
+		return "invalid"
+	}
+}
+
+func (bp BindingPolicy) MarshalText() ([]byte, error) { return []byte(bp.String()), nil }
+
+func (bp *BindingPolicy) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "none":
+		*bp = BindNone
+	case "ip", "ip-subnet":
+		*bp = BindIPSubnet
+	case "user-agent", "ua":
+		*bp = BindUserAgent
+	case "both", "":
+		*bp = BindBoth
+	default:
+		return errors.Wrapf(errors.New(ErrInvalidBindingPolicy), "%q", text)
+	}
+	return nil
+}
+
+// Binder creates the [SecretSalter] that binds a token to the client of a
+// request, according to its [BindingPolicy]. The client's IP address is taken
+// from the X-Forwarded-For or Forwarded headers when the request is received
+// from a trusted proxy.
+type Binder struct {
+	policy  BindingPolicy
+	proxies []netip.Prefix
+}
+
+// NewBinder creates a new [Binder] which trusts the forwarding headers set by
+// the provided trusted proxies.
+func NewBinder(policy BindingPolicy, trustedProxies ...netip.Prefix) *Binder {
+	return &Binder{
+		policy:  policy,
+		proxies: trustedProxies,
+	}
+}
+
+// ParseTrustedProxies parses a list of CIDRs and/or single IP addresses.
+func ParseTrustedProxies(list []string) ([]netip.Prefix, error) {
+	res := make([]netip.Prefix, 0, len(list))
+	for _, str := range list {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+		if !strings.Contains(str, "/") {
+			addr, err := netip.ParseAddr(str)
+			if err != nil {
+				return nil, errors.Wrap(err, ErrInvalidTrustedProxy)
+			}
+			res = append(res, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(str)
+		if err != nil {
+			return nil, errors.Wrap(err, ErrInvalidTrustedProxy)
+		}
+		res = append(res, prefix.Masked())
+	}
+	return res, nil
+}
+
+func (b *Binder) Policy() BindingPolicy { return b.policy }
+
+// ClientIP returns the IP address of the client. The forwarding headers are
+// followed from right to left for as long as the address they were received
+// from is a trusted proxy.
+func (b *Binder) ClientIP(remoteAddr string, h http.Header) netip.Addr {
+	addr := parseAddr(remoteAddr)
+	if !b.trusted(addr) {
+		return addr
+	}
+
+	hops := forwardedFor(h)
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !b.trusted(addr) || !hops[i].IsValid() {
+			break
+		}
+		addr = hops[i]
+	}
+	return addr
+}
+
+// Salter returns the [SecretSalter] which binds a token to the client.
+func (b *Binder) Salter(remoteAddr string, h http.Header) SecretSalter {
+	var s bindingSalter
+	if b.policy.Has(BindIPSubnet) {
+		if ip := b.ClientIP(remoteAddr, h); ip.IsValid() {
+			bits := BindIPv4PrefixLen
+			if ip.Is6() {
+				bits = BindIPv6PrefixLen
+			}
+			prefix, _ := ip.Prefix(bits)
+			s.subnet = prefix.String()
+		}
+	}
+	if b.policy.Has(BindUserAgent) {
+		s.userAgent = h.Get("user-agent")
+	}
+	return s
+}
+
+func (b *Binder) trusted(addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	for _, prefix := range b.proxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor returns the client addresses from the Forwarded header, or
+// from the X-Forwarded-For header when the former is not present. Invalid or
+// obfuscated addresses are returned as the zero [netip.Addr].
+func forwardedFor(h http.Header) []netip.Addr {
+	var res []netip.Addr
+	if values := h.Values("forwarded"); len(values) != 0 {
+		for _, value := range values {
+			for _, elem := range strings.Split(value, ",") {
+				var addr netip.Addr
+				for _, pair := range strings.Split(elem, ";") {
+					k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+					if ok && strings.EqualFold(k, "for") {
+						addr = parseAddr(strings.Trim(v, `"`))
+						break
+					}
+				}
+				res = append(res, addr)
+			}
+		}
+		return res
+	}
+
+	for _, value := range h.Values("x-forwarded-for") {
+		for _, elem := range strings.Split(value, ",") {
+			res = append(res, parseAddr(strings.TrimSpace(elem)))
+		}
+	}
+	return res
+}
+
+// parseAddr parses an IP address with an optional port.
+func parseAddr(str string) netip.Addr {
+	if host, _, err := net.SplitHostPort(str); err == nil {
+		str = host
+	}
+
+	addr, _ := netip.ParseAddr(strings.Trim(str, "[]"))
+	return addr.Unmap()
+}
+
+const separator byte = 33
+
+type bindingSalter struct {
+	subnet    string
+	userAgent string
+}
+
+func (s bindingSalter) SaltSecret(secret []byte) []byte {
+	if s.subnet == "" && s.userAgent == "" {
+		return secret
+	}
+
+	secret = append(secret, separator)
+	secret = append(secret, s.subnet...)
+	secret = append(secret, separator)
+	secret = append(secret, s.userAgent...)
+	return secret
+}
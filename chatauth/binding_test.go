@@ -0,0 +1,84 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinder_ClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+	binder := NewBinder(BindBoth, proxies...)
+
+	tests := map[string]struct {
+		remote string
+		header http.Header
+		want   string
+	}{
+		"direct": {
+			remote: "203.0.113.7:1234",
+			want:   "203.0.113.7",
+		},
+		"untrusted proxy": {
+			remote: "203.0.113.7:1234",
+			header: http.Header{"X-Forwarded-For": {"198.51.100.1"}},
+			want:   "203.0.113.7",
+		},
+		"trusted proxy": {
+			remote: "10.1.2.3:1234",
+			header: http.Header{"X-Forwarded-For": {"198.51.100.1"}},
+			want:   "198.51.100.1",
+		},
+		"proxy chain": {
+			remote: "10.1.2.3:1234",
+			header: http.Header{"X-Forwarded-For": {"1.2.3.4, 198.51.100.1, 192.168.1.1"}},
+			want:   "198.51.100.1",
+		},
+		"forwarded": {
+			remote: "10.1.2.3:1234",
+			header: http.Header{"Forwarded": {`for=192.0.2.60;proto=http, for="[2001:db8::1]:4711"`}},
+			want:   "2001:db8::1",
+		},
+		"ipv6 remote": {
+			remote: "[2001:db8::2]:1234",
+			want:   "2001:db8::2",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, netip.MustParseAddr(tc.want), binder.ClientIP(tc.remote, tc.header))
+		})
+	}
+}
+
+func TestBinder_Salter(t *testing.T) {
+	h := http.Header{"User-Agent": {"foo"}}
+	other := http.Header{"User-Agent": {"bar"}}
+
+	tests := map[BindingPolicy]struct {
+		sameSubnet, otherSubnet, otherAgent bool
+	}{
+		BindNone:      {true, true, true},
+		BindIPSubnet:  {true, false, true},
+		BindUserAgent: {true, true, false},
+		BindBoth:      {true, false, false},
+	}
+	for policy, tc := range tests {
+		t.Run(policy.String(), func(t *testing.T) {
+			binder := NewBinder(policy)
+			want := binding(binder.Salter("203.0.113.7:1", h))
+
+			assert.Equal(t, tc.sameSubnet, want == binding(binder.Salter("203.0.113.8:1", h)), "same subnet")
+			assert.Equal(t, tc.otherSubnet, want == binding(binder.Salter("198.51.100.1:1", h)), "other subnet")
+			assert.Equal(t, tc.otherAgent, want == binding(binder.Salter("203.0.113.7:1", other)), "other user agent")
+		})
+	}
+}
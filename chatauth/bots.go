@@ -0,0 +1,124 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrInvalidBotAccount     errors.Msg = "invalid bot account"
+	ErrInvalidBotCredentials errors.Msg = "invalid bot credentials"
+)
+
+// BotAccount is a pre-registered bot which can join the chatroom by
+// presenting an API key or a client certificate.
+type BotAccount struct {
+	// ID identifies the bot account.
+	ID string `json:"id"`
+	// Name is the display name of the bot. When empty, the bot may choose its
+	// own name when joining.
+	Name string `json:"name,omitempty"`
+	// APIKeySHA256 is the hex encoded SHA-256 hash of the bot's API key.
+	APIKeySHA256 string `json:"api_key_sha256,omitempty"`
+	// CertCommonName is the common name of the (verified) client certificate
+	// the bot presents.
+	CertCommonName string `json:"cert_common_name,omitempty"`
+}
+
+// Bots contains the pre-registered bot accounts.
+type Bots struct {
+	accounts []botAccount
+}
+
+type botAccount struct {
+	BotAccount
+	keyHash []byte
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash of key, as expected by
+// [BotAccount].APIKeySHA256.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewBots validates the accounts and returns a new [Bots].
+func NewBots(accounts ...BotAccount) (*Bots, error) {
+	bots := &Bots{accounts: make([]botAccount, 0, len(accounts))}
+	for _, acc := range accounts {
+		if acc.ID == "" || (acc.APIKeySHA256 == "" && acc.CertCommonName == "") {
+			return nil, errors.Wrapf(errors.New(ErrInvalidBotAccount), "%q", acc.ID)
+		}
+
+		entry := botAccount{BotAccount: acc}
+		if acc.APIKeySHA256 != "" {
+			hash, err := hex.DecodeString(acc.APIKeySHA256)
+			if err != nil || len(hash) != sha256.Size {
+				return nil, errors.Wrapf(errors.New(ErrInvalidBotAccount), "%q: invalid api key hash", acc.ID)
+			}
+			entry.keyHash = hash
+		}
+		bots.accounts = append(bots.accounts, entry)
+	}
+	return bots, nil
+}
+
+// LoadBotsFile reads a JSON file containing a list of [BotAccount]s.
+func LoadBotsFile(file string) (*Bots, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var accounts []BotAccount
+	if err = json.Unmarshal(data, &accounts); err != nil {
+		return nil, errors.Wrap(err, ErrInvalidBotAccount)
+	}
+	return NewBots(accounts...)
+}
+
+// Len returns the number of bot accounts.
+func (b *Bots) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.accounts)
+}
+
+// ByAPIKey returns the [BotAccount] which belongs to the API key.
+func (b *Bots) ByAPIKey(key string) (BotAccount, error) {
+	if b != nil && key != "" {
+		sum := sha256.Sum256([]byte(key))
+		for _, acc := range b.accounts {
+			if acc.keyHash != nil && subtle.ConstantTimeCompare(sum[:], acc.keyHash) == 1 {
+				return acc.BotAccount, nil
+			}
+		}
+	}
+	return BotAccount{}, errors.New(ErrInvalidBotCredentials)
+}
+
+// ByCertificate returns the [BotAccount] which belongs to the client
+// certificate. The certificate must already be verified by the TLS
+// handshake.
+func (b *Bots) ByCertificate(cert *x509.Certificate) (BotAccount, error) {
+	if b != nil && cert != nil && cert.Subject.CommonName != "" {
+		for _, acc := range b.accounts {
+			if strings.EqualFold(acc.CertCommonName, cert.Subject.CommonName) {
+				return acc.BotAccount, nil
+			}
+		}
+	}
+	return BotAccount{}, errors.New(ErrInvalidBotCredentials)
+}
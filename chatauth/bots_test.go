@@ -0,0 +1,51 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBots(t *testing.T) {
+	bots, err := NewBots(
+		BotAccount{ID: "welcome", Name: "Welcome-bot", APIKeySHA256: HashAPIKey("secret")},
+		BotAccount{ID: "chatter", CertCommonName: "chatter-bot"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bots.Len())
+
+	t.Run("api key", func(t *testing.T) {
+		acc, err := bots.ByAPIKey("secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "welcome", acc.ID)
+
+		_, err = bots.ByAPIKey("invalid")
+		assert.True(t, errors.Is(err, ErrInvalidBotCredentials))
+		_, err = bots.ByAPIKey("")
+		assert.True(t, errors.Is(err, ErrInvalidBotCredentials))
+	})
+	t.Run("certificate", func(t *testing.T) {
+		acc, err := bots.ByCertificate(&x509.Certificate{
+			Subject: pkix.Name{CommonName: "chatter-bot"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "chatter", acc.ID)
+
+		_, err = bots.ByCertificate(nil)
+		assert.True(t, errors.Is(err, ErrInvalidBotCredentials))
+	})
+	t.Run("invalid account", func(t *testing.T) {
+		_, err := NewBots(BotAccount{ID: "foo"})
+		assert.True(t, errors.Is(err, ErrInvalidBotAccount))
+		_, err = NewBots(BotAccount{ID: "foo", APIKeySHA256: "bar"})
+		assert.True(t, errors.Is(err, ErrInvalidBotAccount))
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrInvalidChallenge  errors.Msg = "invalid challenge"
+	ErrChallengeExpired  errors.Msg = "challenge is expired"
+	ErrChallengeReused   errors.Msg = "challenge is already used"
+	ErrChallengeRequired errors.Msg = "a solved challenge is required to join"
+	ErrInsufficientWork  errors.Msg = "challenge solution does not meet the difficulty"
+)
+
+const (
+	// MaxChallengeDifficulty is the maximum number of leading zero bits a
+	// challenge can require.
+	MaxChallengeDifficulty = 32
+
+	DefaultChallengeLifetime = 2 * time.Minute
+)
+
+// Challenge is a hashcash-style proof-of-work challenge. It is solved by
+// finding a counter for which the SHA-256 hash of "<nonce>:<counter>" has at
+// least Difficulty leading zero bits.
+type Challenge struct {
+	Nonce      string
+	Difficulty uint8
+	Expires    time.Time
+}
+
+// Challenger issues and verifies [Challenge]s. Its nonces are signed, so no
+// state is kept for issued challenges. Solved challenges are remembered until
+// they expire, so they can be used only once.
+type Challenger struct {
+	secret     []byte
+	lifetime   time.Duration
+	now        func() time.Time
+	difficulty uint8
+
+	mut  sync.Mutex
+	used map[string]time.Time // nonce => expires
+}
+
+// NewChallenger creates a new [Challenger] which issues challenges with the
+// provided difficulty, which are valid for lifetime.
+func NewChallenger(difficulty uint8, lifetime time.Duration) (*Challenger, error) {
+	if lifetime <= 0 {
+		lifetime = DefaultChallengeLifetime
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &Challenger{
+		secret:     secret,
+		lifetime:   lifetime,
+		now:        time.Now,
+		difficulty: min(difficulty, MaxChallengeDifficulty),
+		used:       make(map[string]time.Time, 16),
+	}, nil
+}
+
+// Difficulty returns the difficulty of newly issued challenges. A solved
+// challenge is not required when zero.
+func (c *Challenger) Difficulty() uint8 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.difficulty
+}
+
+// SetDifficulty changes the difficulty of newly issued challenges, e.g. to
+// slow down clients when the join rate spikes. Previously issued challenges
+// keep their difficulty.
+func (c *Challenger) SetDifficulty(difficulty uint8) {
+	c.mut.Lock()
+	c.difficulty = min(difficulty, MaxChallengeDifficulty)
+	c.mut.Unlock()
+}
+
+// Issue returns a new [Challenge].
+func (c *Challenger) Issue() (Challenge, error) {
+	// nonce payload: random (16) | expires (8) | difficulty (1)
+	payload := make([]byte, 16, 25)
+	if _, err := rand.Read(payload); err != nil {
+		return Challenge{}, errors.WithStack(err)
+	}
+
+	chal := Challenge{
+		Difficulty: c.Difficulty(),
+		Expires:    c.now().Add(c.lifetime).Truncate(time.Second),
+	}
+	payload = binary.BigEndian.AppendUint64(payload, uint64(chal.Expires.Unix()))
+	payload = append(payload, chal.Difficulty)
+
+	chal.Nonce = b64(payload) + "." + b64(c.sign(payload))
+	return chal, nil
+}
+
+func (c *Challenger) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Verify checks if counter solves the challenge with nonce. A challenge can
+// only be solved once.
+func (c *Challenger) Verify(nonce string, counter uint64) error {
+	enc, sig, ok := strings.Cut(nonce, ".")
+	if !ok {
+		return errors.New(ErrInvalidChallenge)
+	}
+
+	payload, err := unb64(enc)
+	if err != nil || len(payload) != 25 {
+		return errors.New(ErrInvalidChallenge)
+	}
+	mac, err := unb64(sig)
+	if err != nil || !hmac.Equal(mac, c.sign(payload)) {
+		return errors.New(ErrInvalidChallenge)
+	}
+
+	now := c.now()
+	expires := time.Unix(int64(binary.BigEndian.Uint64(payload[16:24])), 0)
+	if !now.Before(expires) {
+		return errors.New(ErrChallengeExpired)
+	}
+	if leadingZeroBits(challengeHash(nonce, counter)) < int(payload[24]) {
+		return errors.New(ErrInsufficientWork)
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for n, exp := range c.used {
+		if !now.Before(exp) {
+			delete(c.used, n)
+		}
+	}
+	if _, ok = c.used[nonce]; ok {
+		return errors.New(ErrChallengeReused)
+	}
+	c.used[nonce] = expires
+	return nil
+}
+
+// SolveChallenge finds the counter which solves the challenge with nonce and
+// difficulty. The expected number of attempts is 2^difficulty.
+func SolveChallenge(nonce string, difficulty uint8) uint64 {
+	var counter uint64
+	for leadingZeroBits(challengeHash(nonce, counter)) < int(difficulty) {
+		counter++
+	}
+	return counter
+}
+
+func challengeHash(nonce string, counter uint64) [sha256.Size]byte {
+	return sha256.Sum256([]byte(nonce + ":" + strconv.FormatUint(counter, 10)))
+}
+
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	var n int
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
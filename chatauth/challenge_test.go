@@ -0,0 +1,55 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallenger(t *testing.T) {
+	c, err := NewChallenger(8, time.Minute)
+	require.NoError(t, err)
+
+	chal, err := c.Issue()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(8), chal.Difficulty)
+
+	counter := SolveChallenge(chal.Nonce, chal.Difficulty)
+	if counter > 0 {
+		assert.ErrorIs(t, c.Verify(chal.Nonce, counter-1), ErrInsufficientWork)
+	}
+	assert.NoError(t, c.Verify(chal.Nonce, counter))
+	assert.ErrorIs(t, c.Verify(chal.Nonce, counter), ErrChallengeReused)
+
+	t.Run("tampered", func(t *testing.T) {
+		assert.ErrorIs(t, c.Verify("x"+chal.Nonce, counter), ErrInvalidChallenge)
+		assert.ErrorIs(t, c.Verify("foo", 0), ErrInvalidChallenge)
+
+		other, err := NewChallenger(8, time.Minute)
+		require.NoError(t, err)
+		assert.ErrorIs(t, other.Verify(chal.Nonce, counter), ErrInvalidChallenge)
+	})
+	t.Run("expired", func(t *testing.T) {
+		chal, err := c.Issue()
+		require.NoError(t, err)
+
+		c.now = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { c.now = time.Now }()
+		assert.ErrorIs(t, c.Verify(chal.Nonce, SolveChallenge(chal.Nonce, chal.Difficulty)), ErrChallengeExpired)
+	})
+	t.Run("difficulty", func(t *testing.T) {
+		chal, err := c.Issue()
+		require.NoError(t, err)
+
+		c.SetDifficulty(255)
+		assert.Equal(t, uint8(MaxChallengeDifficulty), c.Difficulty())
+		// previously issued challenges keep their difficulty
+		assert.NoError(t, c.Verify(chal.Nonce, SolveChallenge(chal.Nonce, chal.Difficulty)))
+	})
+}
@@ -5,14 +5,25 @@
 package chatauth
 
 import (
+	"time"
+
+	"github.com/go-pogo/errors"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/roeldev/demo-chatroom/chatusers"
 )
 
+const (
+	ErrMissingUserID errors.Msg = "missing UserID"
+	ErrTokenExpired  errors.Msg = "token time has expired"
+)
+
 type Claims struct {
 	jwt.RegisteredClaims
 
-	UserID chatusers.UserID `json:"uid"`
+	UserID  chatusers.UserID `json:"uid"`
+	Role    chatusers.Role   `json:"rol,omitempty"`
+	Binding string           `json:"bnd,omitempty"`
 }
 
 func NewClaims(uid chatusers.UserID) *Claims {
@@ -21,16 +32,29 @@ func NewClaims(uid chatusers.UserID) *Claims {
 	}
 }
 
-func RenewClaims(old Claims) *Claims {
-	return &Claims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    old.RegisteredClaims.Issuer,
-			Subject:   old.RegisteredClaims.Subject,
-			Audience:  old.RegisteredClaims.Audience,
-			NotBefore: old.ExpiresAt,
-			ID:        old.RegisteredClaims.ID,
-		},
-
-		UserID: old.UserID,
+// prepareClaims sets the id, issued at and expires at times of claims.
+func prepareClaims(claims *Claims, expires time.Duration) error {
+	if claims.UserID == uuid.Nil {
+		return errors.New(ErrMissingUserID)
+	}
+
+	if claims.RegisteredClaims.ID == "" {
+		claims.RegisteredClaims.ID = uuid.NewString()
+	}
+	if claims.RegisteredClaims.IssuedAt == nil {
+		claims.RegisteredClaims.IssuedAt = jwt.NewNumericDate(time.Now())
+	}
+	if expires != 0 {
+		claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(
+			claims.RegisteredClaims.IssuedAt.Add(expires),
+		)
+	}
+	return nil
+}
+
+func checkExpiry(claims Claims, expires time.Duration) error {
+	if expires > 0 && (claims.ExpiresAt == nil || claims.ExpiresAt.Before(time.Now())) {
+		return errors.New(ErrTokenExpired)
 	}
+	return nil
 }
@@ -0,0 +1,167 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"os"
+	"time"
+)
+
+type Config struct {
+	// TokenLifetime is how long an access token is valid.
+	TokenLifetime time.Duration `env:"AUTH_TOKEN_LIFETIME" default:"15m"`
+	// RefreshTokenLifetime is how long a refresh token is valid to renew the
+	// access token with.
+	RefreshTokenLifetime time.Duration `env:"AUTH_REFRESH_TOKEN_LIFETIME" default:"24h"`
+	// RefreshFile is the file the hashes of issued refresh tokens are
+	// persisted in, so they can be redeemed after a restart. Refresh tokens
+	// are kept in memory only when empty.
+	RefreshFile string `env:"AUTH_REFRESH_FILE"`
+	// RevocationFile is the file the ids of revoked access tokens are
+	// persisted in, so they remain revoked after a restart. Revoked ids are
+	// kept in memory only when empty.
+	RevocationFile string `env:"AUTH_REVOCATION_FILE"`
+	// SessionGracePeriod is how long a user, whose streams are all closed,
+	// is kept in the chatroom as unresponsive so it can resume its session.
+	SessionGracePeriod time.Duration `env:"AUTH_SESSION_GRACE_PERIOD" default:"30s"`
+	// TokenBinding determines to which properties of the client a token is
+	// bound: none, ip, user-agent or both.
+	TokenBinding BindingPolicy `env:"AUTH_TOKEN_BINDING" default:"both"`
+	// TrustedProxies are the CIDRs or IP addresses of proxies whose
+	// X-Forwarded-For and Forwarded headers are trusted.
+	TrustedProxies []string `env:"AUTH_TRUSTED_PROXIES"`
+	// KeyFiles are PEM encoded Ed25519, RSA or ECDSA private keys. The first
+	// key is used to sign tokens, all keys are used to verify tokens. When
+	// empty, tokens are signed using a randomly generated HMAC secret.
+	KeyFiles []string `env:"AUTH_KEY_FILES"`
+	// KeyRotateInterval is the interval at which a new signing key is
+	// generated. Rotation on a schedule is disabled when zero.
+	KeyRotateInterval time.Duration `env:"AUTH_KEY_ROTATE_INTERVAL"`
+	// KeyRetainCount is the maximum number of previous keys which are still
+	// accepted to verify tokens after rotation.
+	KeyRetainCount int `env:"AUTH_KEY_RETAIN_COUNT" default:"2"`
+	// KeyringFile is the file the signing keys are persisted in, so rotated
+	// keys and a generated HMAC secret survive a restart. When it exists, its
+	// keys are used instead of the key files. Keys are kept in memory only
+	// when empty, which invalidates all tokens on restart.
+	KeyringFile string `env:"AUTH_KEYRING_FILE"`
+	// BotsFile is a JSON file with the pre-registered [BotAccount]s. Bots
+	// cannot join when empty.
+	BotsFile string `env:"AUTH_BOTS_FILE"`
+	// OIDCIssuer is the URL of the OpenID provider whose ID tokens are
+	// accepted to join the chatroom. Login with an ID token is disabled when
+	// empty.
+	OIDCIssuer string `env:"AUTH_OIDC_ISSUER"`
+	// OIDCClientID is the expected audience of the ID tokens.
+	OIDCClientID string `env:"AUTH_OIDC_CLIENT_ID"`
+	// AllowAnonymous allows users to join without an ID token.
+	AllowAnonymous bool `env:"AUTH_ALLOW_ANONYMOUS" default:"true"`
+	// ChallengeDifficulty is the number of leading zero bits of the
+	// proof-of-work challenge users must solve to join. No challenge is
+	// required when zero.
+	ChallengeDifficulty uint8 `env:"AUTH_CHALLENGE_DIFFICULTY"`
+	// ChallengeLifetime is how long an issued challenge can be solved.
+	ChallengeLifetime time.Duration `env:"AUTH_CHALLENGE_LIFETIME" default:"2m"`
+	// AuditLog is where audit records are written to, either "stdout" or the
+	// path of a file. Auditing is disabled when empty.
+	AuditLog string `env:"AUTH_AUDIT_LOG" default:"stdout"`
+	// AuditLogMaxSize is the size in bytes at which the audit log file is
+	// rotated.
+	AuditLogMaxSize int64 `env:"AUTH_AUDIT_LOG_MAX_SIZE" default:"10485760"`
+	// AuditLogMaxBackups is the number of rotated audit log files to keep.
+	AuditLogMaxBackups int `env:"AUTH_AUDIT_LOG_MAX_BACKUPS" default:"5"`
+}
+
+// NewKeyring creates a new [Keyring] with the keys from the configured keyring
+// file or key files. A HMAC key is generated when neither contain any keys.
+func (conf Config) NewKeyring() (*Keyring, error) {
+	keys := make([]*Key, 0, len(conf.KeyFiles))
+	for _, file := range conf.KeyFiles {
+		key, err := LoadKeyFile(file)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		key, err := GenerateSecretKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeyring(keys,
+		WithRetainKeys(conf.KeyRetainCount),
+		WithTokenLifetime(conf.TokenLifetime),
+		WithKeyringFile(conf.KeyringFile),
+	)
+}
+
+// LoadBots loads the bot accounts from the configured bots file.
+func (conf Config) LoadBots() (*Bots, error) {
+	if conf.BotsFile == "" {
+		return NewBots()
+	}
+	return LoadBotsFile(conf.BotsFile)
+}
+
+// NewIdentityVerifier returns an [IdentityVerifier] for the configured OIDC
+// issuer, or nil when no issuer is configured.
+func (conf Config) NewIdentityVerifier() IdentityVerifier {
+	if conf.OIDCIssuer == "" {
+		return nil
+	}
+	return NewOIDCVerifier(conf.OIDCIssuer, conf.OIDCClientID, nil)
+}
+
+// HasPersistentTokens indicates if issued access and refresh tokens remain
+// valid after a restart. This requires the signing keys to be loaded from or
+// persisted in files, and the refresh tokens and revoked ids to be persisted.
+func (conf Config) HasPersistentTokens() bool {
+	return (conf.KeyringFile != "" || len(conf.KeyFiles) != 0) &&
+		conf.RefreshFile != "" && conf.RevocationFile != ""
+}
+
+// NewRefreshStore creates a [RefreshStore] which persists its tokens in the
+// configured refresh file, if any.
+func (conf Config) NewRefreshStore() (RefreshStore, error) {
+	return NewFileRefreshStore(conf.RefreshFile, conf.RefreshTokenLifetime)
+}
+
+// NewRevocationStore creates a [RevocationStore] which persists the revoked
+// ids in the configured revocation file, if any.
+func (conf Config) NewRevocationStore() (RevocationStore, error) {
+	return NewFileRevocationStore(conf.RevocationFile)
+}
+
+// NewChallenger creates a [Challenger] with the configured difficulty and
+// lifetime.
+func (conf Config) NewChallenger() (*Challenger, error) {
+	return NewChallenger(conf.ChallengeDifficulty, conf.ChallengeLifetime)
+}
+
+// NewBinder creates a [Binder] with the configured binding policy and trusted
+// proxies.
+func (conf Config) NewBinder() (*Binder, error) {
+	proxies, err := ParseTrustedProxies(conf.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	return NewBinder(conf.TokenBinding, proxies...), nil
+}
+
+// NewAuditSink creates the [AuditSink] for the configured audit log, or nil
+// when auditing is disabled.
+func (conf Config) NewAuditSink() (AuditSink, error) {
+	switch conf.AuditLog {
+	case "":
+		return nil, nil
+	case "stdout":
+		return NewWriterAuditSink(os.Stdout), nil
+	default:
+		return NewFileAuditSink(conf.AuditLog, conf.AuditLogMaxSize, conf.AuditLogMaxBackups)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrUnsupportedKeyType errors.Msg = "unsupported key type"
+	ErrInvalidJWK         errors.Msg = "invalid jwk"
+)
+
+// JWKSPath is the well-known path where the [JWKSet] is published.
+const JWKSPath = "/.well-known/jwks.json"
+
+// JWKSProvider provides the public keys which are used to verify tokens.
+type JWKSProvider interface {
+	JWKSet() JWKSet
+}
+
+// JWK is a JSON Web Key as described in RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKSet is a JSON Web Key Set as described in RFC 7517.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK creates a [JWK] from an Ed25519, RSA or ECDSA public key.
+func NewJWK(pub crypto.PublicKey) (JWK, error) {
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       b64(k),
+		}, nil
+
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType: "RSA",
+			N:       b64(k.N.Bytes()),
+			E:       b64(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+
+	case *ecdsa.PublicKey:
+		ecdh, err := k.ECDH()
+		if err != nil {
+			return JWK{}, errors.WithStack(err)
+		}
+
+		// uncompressed point: 0x04 || X || Y
+		point := ecdh.Bytes()[1:]
+		size := len(point) / 2
+
+		return JWK{
+			KeyType: "EC",
+			Curve:   k.Curve.Params().Name,
+			X:       b64(point[:size]),
+			Y:       b64(point[size:]),
+		}, nil
+
+	default:
+		return JWK{}, errors.New(ErrUnsupportedKeyType)
+	}
+}
+
+// PublicKey returns the Ed25519, RSA or ECDSA public key of the JWK.
+func (jwk JWK) PublicKey() (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "OKP":
+		if jwk.Curve != "Ed25519" {
+			return nil, errors.New(ErrUnsupportedKeyType)
+		}
+		x, err := unb64(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New(ErrInvalidJWK)
+		}
+		return ed25519.PublicKey(x), nil
+
+	case "RSA":
+		n, err := unb64(jwk.N)
+		if err != nil || len(n) == 0 {
+			return nil, errors.New(ErrInvalidJWK)
+		}
+		e, err := unb64(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New(ErrInvalidJWK)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New(ErrUnsupportedKeyType)
+		}
+
+		x, err := unb64(jwk.X)
+		if err != nil {
+			return nil, errors.New(ErrInvalidJWK)
+		}
+		y, err := unb64(jwk.Y)
+		if err != nil || len(x) != len(y) {
+			return nil, errors.New(ErrInvalidJWK)
+		}
+
+		// uncompressed point: 0x04 || X || Y
+		point := append(append([]byte{4}, x...), y...)
+		key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+		if err != nil {
+			return nil, errors.Wrap(err, ErrInvalidJWK)
+		}
+		return key, nil
+
+	default:
+		return nil, errors.New(ErrUnsupportedKeyType)
+	}
+}
+
+// Thumbprint returns the RFC 7638 SHA-256 thumbprint of the JWK.
+func (jwk JWK) Thumbprint() string {
+	// required members in lexicographic order, without whitespace
+	var members []string
+	switch jwk.KeyType {
+	case "OKP":
+		members = []string{"crv", jwk.Curve, "kty", jwk.KeyType, "x", jwk.X}
+	case "RSA":
+		members = []string{"e", jwk.E, "kty", jwk.KeyType, "n", jwk.N}
+	case "EC":
+		members = []string{"crv", jwk.Curve, "kty", jwk.KeyType, "x", jwk.X, "y", jwk.Y}
+	}
+
+	buf := make([]byte, 0, 128)
+	buf = append(buf, '{')
+	for i := 0; i < len(members); i += 2 {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, '"')
+		buf = append(buf, members[i]...)
+		buf = append(buf, `":"`...)
+		buf = append(buf, members[i+1]...)
+		buf = append(buf, '"')
+	}
+	buf = append(buf, '}')
+
+	sum := sha256.Sum256(buf)
+	return b64(sum[:])
+}
+
+// JWKSHandler returns a [http.Handler] which serves the [JWKSet] of the
+// provided [JWKSProvider].
+func JWKSHandler(p JWKSProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_ = json.NewEncoder(w).Encode(p.JWKSet())
+	})
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func unb64(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) }
@@ -5,72 +5,141 @@
 package chatauth
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"time"
 
 	"github.com/go-pogo/errors"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 )
 
-var _ SignerParser = (*JWTAuth)(nil)
+const ErrBindingMismatch errors.Msg = "token binding does not match"
 
+const (
+	DefaultTokenLifetime = time.Hour
+	DefaultRetainKeys    = 2
+)
+
+var (
+	_ SignerParser = (*JWTAuth)(nil)
+	_ JWKSProvider = (*JWTAuth)(nil)
+)
+
+// JWTAuth signs and parses tokens using the keys from a [Keyring]. Tokens are
+// signed with the keyring's current key and its id is set as the kid header.
+//
+// A hash of the salt provided by a [SecretSalter] is added to the token's
+// claims, binding the token to e.g. the client's IP address. Parse returns
+// ErrBindingMismatch when the binding of a token does not match.
 type JWTAuth struct {
-	secret  []byte
+	keys    *Keyring
 	expires time.Duration
 }
 
-func NewJWTAuth() (*JWTAuth, error) {
+// NewJWTAuth creates a new [JWTAuth] using the provided [Keyring]. When keys
+// is nil, a keyring with a randomly generated HMAC secret is used. Tokens are
+// valid for lifetime, or [DefaultTokenLifetime] when it is zero.
+func NewJWTAuth(keys *Keyring, lifetime time.Duration) (*JWTAuth, error) {
+	if keys == nil {
+		key, err := GenerateSecretKey()
+		if err != nil {
+			return nil, err
+		}
+		if keys, err = NewKeyring([]*Key{key}); err != nil {
+			return nil, err
+		}
+	}
+
+	if lifetime <= 0 {
+		lifetime = DefaultTokenLifetime
+	}
+
 	return &JWTAuth{
-		expires: time.Hour,
+		keys:    keys,
+		expires: lifetime,
 	}, nil
 }
 
+func (auth *JWTAuth) Keyring() *Keyring { return auth.keys }
+
+func (auth *JWTAuth) JWKSet() JWKSet { return auth.keys.JWKSet() }
+
 func (auth *JWTAuth) Sign(claims *Claims, salter SecretSalter) (string, error) {
 	if claims == nil {
 		panic("chatauth.JWTAuth: claims must not be nil")
 	}
-	if claims.UserID == uuid.Nil {
-		return "", errors.New("missing UserID")
+	if err := prepareClaims(claims, auth.expires); err != nil {
+		return "", err
 	}
 
-	if claims.RegisteredClaims.IssuedAt == nil {
-		claims.RegisteredClaims.IssuedAt = jwt.NewNumericDate(time.Now())
-	}
-	if auth.expires != 0 {
-		claims.RegisteredClaims.ExpiresAt = jwt.NewNumericDate(
-			claims.RegisteredClaims.IssuedAt.Add(auth.expires),
-		)
-	}
+	key := auth.keys.Current()
+	claims.Binding = binding(salter)
+
+	tok := jwt.NewWithClaims(key.method, claims)
+	tok.Header["kid"] = key.id
 
-	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).
-		SignedString(auth.saltSecret(salter))
+	str, err := tok.SignedString(key.signKey())
 	if err != nil {
 		return "", errors.WithStack(err)
 	}
-	return tok, nil
+	return str, nil
 }
 
 func (auth *JWTAuth) Parse(token string, salter SecretSalter) (Claims, error) {
 	var claims Claims
 	_, err := jwt.ParseWithClaims(token, &claims,
-		func(_ *jwt.Token) (any, error) { return auth.saltSecret(salter), nil },
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		func(tok *jwt.Token) (any, error) {
+			kid, _ := tok.Header["kid"].(string)
+			key, err := auth.keys.Find(kid)
+			if err != nil {
+				return nil, err
+			}
+			if key.method.Alg() != tok.Method.Alg() {
+				return nil, jwt.ErrTokenSignatureInvalid
+			}
+			return key.verifyKey(), nil
+		},
+		jwt.WithValidMethods(auth.methods()),
 		jwt.WithIssuedAt(),
 	)
 	if err != nil {
 		return claims, errors.WithStack(err)
 	}
 
-	if auth.expires > 0 && claims.ExpiresAt.Before(time.Now()) {
-		return claims, errors.New("token time has expired")
+	if err = checkExpiry(claims, auth.expires); err != nil {
+		return claims, err
+	}
+	if subtle.ConstantTimeCompare(
+		[]byte(claims.Binding),
+		[]byte(binding(salter)),
+	) != 1 {
+		return claims, errors.New(ErrBindingMismatch)
 	}
 
 	return claims, nil
 }
 
-func (auth *JWTAuth) saltSecret(salter SecretSalter) []byte {
+func (auth *JWTAuth) methods() []string {
+	keys := auth.keys.Keys()
+	res := make([]string, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, key.method.Alg())
+	}
+	return res
+}
+
+// binding returns a hash of the salt provided by salter, or an empty string
+// when there is nothing to salt with.
+func binding(salter SecretSalter) string {
 	if salter == nil {
-		return auth.secret
+		return ""
 	}
-	return salter.SaltSecret(auth.secret)
+
+	salt := salter.SaltSecret(nil)
+	if len(salt) == 0 {
+		return ""
+	}
+
+	sum := sha256.Sum256(salt)
+	return b64(sum[:])
 }
@@ -0,0 +1,137 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSalter string
+
+func (s testSalter) SaltSecret(secret []byte) []byte { return append(secret, s...) }
+
+func generateKeys(t *testing.T) map[string]crypto.Signer {
+	_, ed, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rs, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return map[string]crypto.Signer{
+		"EdDSA": ed,
+		"RS256": rs,
+		"ES256": ec,
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	for alg, signer := range generateKeys(t) {
+		t.Run(alg, func(t *testing.T) {
+			der, err := x509.MarshalPKCS8PrivateKey(signer)
+			require.NoError(t, err)
+
+			have, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: der,
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, signer.Public(), have.Public())
+		})
+	}
+}
+
+func newTestAuth(t *testing.T, keys ...*Key) *JWTAuth {
+	kr, err := NewKeyring(keys)
+	require.NoError(t, err)
+	auth, err := NewJWTAuth(kr, 0)
+	require.NoError(t, err)
+	return auth
+}
+
+func TestJWTAuth(t *testing.T) {
+	t.Run("HS256", func(t *testing.T) {
+		auth, err := NewJWTAuth(nil, 0)
+		require.NoError(t, err)
+
+		uid := uuid.New()
+		claims := NewClaims(uid)
+		claims.Role = chatusers.Role_Moderator
+		token, err := auth.Sign(claims, testSalter("foo"))
+		require.NoError(t, err)
+
+		have, err := auth.Parse(token, testSalter("foo"))
+		assert.NoError(t, err)
+		assert.Equal(t, uid, have.UserID)
+		assert.Equal(t, chatusers.Role_Moderator, have.Role)
+		assert.NotEmpty(t, have.Binding)
+
+		_, err = auth.Parse(token, testSalter("bar"))
+		assert.ErrorIs(t, err, ErrBindingMismatch)
+		assert.Empty(t, auth.JWKSet().Keys)
+	})
+
+	for alg, signer := range generateKeys(t) {
+		t.Run(alg, func(t *testing.T) {
+			key, err := NewKey(signer)
+			require.NoError(t, err)
+			assert.Equal(t, alg, key.Method().Alg())
+
+			auth := newTestAuth(t, key)
+
+			uid := uuid.New()
+			token, err := auth.Sign(NewClaims(uid), testSalter("foo"))
+			require.NoError(t, err)
+
+			t.Run("parse", func(t *testing.T) {
+				claims, err := auth.Parse(token, testSalter("foo"))
+				assert.NoError(t, err)
+				assert.Equal(t, uid, claims.UserID)
+			})
+			t.Run("binding mismatch", func(t *testing.T) {
+				_, err := auth.Parse(token, testSalter("bar"))
+				assert.ErrorIs(t, err, ErrBindingMismatch)
+			})
+			t.Run("unknown key", func(t *testing.T) {
+				otherKey, err := NewKey(generateKeys(t)[alg])
+				require.NoError(t, err)
+
+				_, err = newTestAuth(t, otherKey).Parse(token, testSalter("foo"))
+				assert.ErrorIs(t, err, ErrUnknownKeyID)
+			})
+			t.Run("jwks", func(t *testing.T) {
+				set := auth.JWKSet()
+				require.Len(t, set.Keys, 1)
+				assert.Equal(t, key.ID(), set.Keys[0].KeyID)
+				assert.Equal(t, alg, set.Keys[0].Algorithm)
+			})
+		})
+	}
+}
+
+func TestJWK_Thumbprint(t *testing.T) {
+	// example from RFC 7638, section 3.1
+	jwk := JWK{
+		KeyType: "RSA",
+		N: "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn6" +
+			"4tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91Cb" +
+			"OpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E: "AQAB",
+	}
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", jwk.Thumbprint())
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// keyringFileEntry is the JSON representation of a keyringEntry.
+type keyringFileEntry struct {
+	ID         string    `json:"id"`
+	Secret     []byte    `json:"secret,omitempty"`
+	PrivateKey string    `json:"private_key,omitempty"` // PEM encoded PKCS #8
+	Retired    time.Time `json:"retired,omitzero"`
+}
+
+// readKeyringFile reads the entries of a keyring from file. It returns no
+// entries when file does not exist.
+func readKeyringFile(file string) ([]keyringEntry, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var list []keyringFileEntry
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	entries := make([]keyringEntry, 0, len(list))
+	for _, fe := range list {
+		entry := keyringEntry{retired: fe.Retired}
+		if fe.PrivateKey == "" {
+			entry.Key = &Key{
+				id:     fe.ID,
+				method: jwt.SigningMethodHS256,
+				secret: fe.Secret,
+			}
+		} else {
+			signer, err := ParsePrivateKey([]byte(fe.PrivateKey))
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to load key `%s` from keyring file", fe.ID)
+			}
+			if entry.Key, err = NewKey(signer); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// writeKeyringFile writes entries to file, which is replaced atomically.
+func writeKeyringFile(file string, entries []keyringEntry) error {
+	list := make([]keyringFileEntry, 0, len(entries))
+	for _, entry := range entries {
+		fe := keyringFileEntry{
+			ID:      entry.id,
+			Retired: entry.retired,
+		}
+		if entry.IsAsymmetric() {
+			der, err := x509.MarshalPKCS8PrivateKey(entry.signer)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			fe.PrivateKey = string(pem.EncodeToMemory(&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: der,
+			}))
+		} else {
+			fe.Secret = entry.secret
+		}
+		list = append(list, fe)
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	tmp := file + ".tmp"
+	if err = os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp, file))
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrNoKeys       errors.Msg = "at least one key is required"
+	ErrUnknownKeyID errors.Msg = "unknown key id"
+)
+
+var _ JWKSProvider = (*Keyring)(nil)
+
+// Keyring holds the [Key] which is used to sign new tokens, and the previous
+// keys which are still accepted to verify tokens that were signed before the
+// last rotation(s). A previous key is kept until all tokens it has signed are
+// expired, or until more than retain keys have been rotated after it.
+type Keyring struct {
+	mut      sync.RWMutex
+	keys     []keyringEntry // newest first
+	generate KeyGenerator
+	retain   int
+	ttl      time.Duration
+	file     string
+}
+
+type keyringEntry struct {
+	*Key
+	retired time.Time
+}
+
+type KeyringOption func(kr *Keyring)
+
+// WithKeyGenerator sets the [KeyGenerator] that is used to generate a new key
+// on rotation. By default, keys are generated of the same type as the current
+// signing key.
+func WithKeyGenerator(gen KeyGenerator) KeyringOption {
+	return func(kr *Keyring) { kr.generate = gen }
+}
+
+// WithRetainKeys sets the maximum number of previous keys that are kept after
+// rotation.
+func WithRetainKeys(n int) KeyringOption {
+	return func(kr *Keyring) { kr.retain = n }
+}
+
+// WithTokenLifetime sets how long a previous key is kept after rotation. It
+// should be at least the lifetime of the tokens signed by the key.
+func WithTokenLifetime(dur time.Duration) KeyringOption {
+	return func(kr *Keyring) { kr.ttl = dur }
+}
+
+// WithKeyringFile persists the keys in file after each change, so rotated
+// keys survive a restart. When file exists, its keys are used instead of the
+// keys provided to [NewKeyring].
+func WithKeyringFile(file string) KeyringOption {
+	return func(kr *Keyring) { kr.file = file }
+}
+
+// NewKeyring creates a new [Keyring]. The first key is used to sign tokens,
+// any other keys are considered previous keys which are only used to verify
+// tokens.
+func NewKeyring(keys []*Key, opts ...KeyringOption) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New(ErrNoKeys)
+	}
+
+	kr := &Keyring{
+		retain: DefaultRetainKeys,
+		ttl:    DefaultTokenLifetime,
+	}
+	for _, opt := range opts {
+		opt(kr)
+	}
+
+	if kr.file != "" {
+		var err error
+		if kr.keys, err = readKeyringFile(kr.file); err != nil {
+			return nil, err
+		}
+	}
+	if len(kr.keys) == 0 {
+		now := time.Now()
+		kr.keys = make([]keyringEntry, 0, len(keys))
+		for i, key := range keys {
+			entry := keyringEntry{Key: key}
+			if i > 0 {
+				entry.retired = now
+			}
+			kr.keys = append(kr.keys, entry)
+		}
+		if err := kr.save(kr.keys); err != nil {
+			return nil, err
+		}
+	}
+	if kr.generate == nil {
+		kr.generate = kr.keys[0].Generator()
+	}
+	return kr, nil
+}
+
+// Current returns the [Key] which is used to sign new tokens.
+func (kr *Keyring) Current() *Key {
+	kr.mut.RLock()
+	defer kr.mut.RUnlock()
+	return kr.keys[0].Key
+}
+
+// Find returns the [Key] with the provided key id, as long as it is still
+// valid to verify tokens with.
+func (kr *Keyring) Find(kid string) (*Key, error) {
+	kr.mut.RLock()
+	defer kr.mut.RUnlock()
+
+	now := time.Now()
+	for _, entry := range kr.keys {
+		if entry.id != kid {
+			continue
+		}
+		if kr.expired(entry, now) {
+			break
+		}
+		return entry.Key, nil
+	}
+	return nil, errors.New(ErrUnknownKeyID)
+}
+
+// Keys returns all keys that are still valid to verify tokens with, the
+// current signing key first.
+func (kr *Keyring) Keys() []*Key {
+	kr.mut.RLock()
+	defer kr.mut.RUnlock()
+
+	now := time.Now()
+	res := make([]*Key, 0, len(kr.keys))
+	for _, entry := range kr.keys {
+		if !kr.expired(entry, now) {
+			res = append(res, entry.Key)
+		}
+	}
+	return res
+}
+
+// Rotate generates a new signing key and retires the current one. It returns
+// the retired and new keys.
+func (kr *Keyring) Rotate() (old, cur *Key, err error) {
+	cur, err = kr.generate()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to generate key")
+	}
+
+	kr.mut.Lock()
+	defer kr.mut.Unlock()
+
+	old = kr.keys[0].Key
+	keys := kr.rotated(cur, time.Now())
+	if err = kr.save(keys); err != nil {
+		return nil, nil, err
+	}
+
+	kr.keys = keys
+	return old, cur, nil
+}
+
+// Revoke removes the [Key] with the provided key id from the keyring, so any
+// token signed with it is rejected immediately. When it is the current key, it
+// is replaced by a newly generated signing key.
+func (kr *Keyring) Revoke(kid string) error {
+	// the replacement is generated up front, so the check whether kid is the
+	// current key and the rotation happen under the same lock
+	next, err := kr.generate()
+	if err != nil {
+		return errors.Wrap(err, "unable to generate key")
+	}
+
+	kr.mut.Lock()
+	defer kr.mut.Unlock()
+
+	keys := kr.keys
+	current := keys[0].id == kid
+	if current {
+		keys = kr.rotated(next, time.Now())
+	}
+
+	res := make([]keyringEntry, 0, len(keys))
+	for i, entry := range keys {
+		if i == 0 || entry.id != kid {
+			res = append(res, entry)
+		}
+	}
+	if !current && len(res) == len(keys) {
+		return errors.New(ErrUnknownKeyID)
+	}
+	if err = kr.save(res); err != nil {
+		return err
+	}
+
+	kr.keys = res
+	return nil
+}
+
+// RotateEvery calls Rotate at every interval until ctx is canceled. The
+// provided callback is called after each rotation.
+func (kr *Keyring) RotateEvery(ctx context.Context, interval time.Duration, fn func(old, cur *Key, err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			old, cur, err := kr.Rotate()
+			if fn != nil {
+				fn(old, cur, err)
+			}
+		}
+	}
+}
+
+// JWKSet returns the public keys of all asymmetric keys which are still
+// valid to verify tokens with.
+func (kr *Keyring) JWKSet() JWKSet {
+	keys := kr.Keys()
+	set := JWKSet{Keys: make([]JWK, 0, len(keys))}
+	for _, key := range keys {
+		if key.IsAsymmetric() {
+			set.Keys = append(set.Keys, key.jwk)
+		}
+	}
+	return set
+}
+
+// rotated returns the keys of the keyring after cur became the signing key and
+// the current key was retired at now. It must be called while holding the
+// lock.
+func (kr *Keyring) rotated(cur *Key, now time.Time) []keyringEntry {
+	keys := make([]keyringEntry, 0, kr.retain+1)
+	keys = append(keys, keyringEntry{Key: cur})
+	for i, entry := range kr.keys {
+		if len(keys) > kr.retain {
+			break
+		}
+		if i == 0 {
+			entry.retired = now
+		}
+		if !kr.expired(entry, now) {
+			keys = append(keys, entry)
+		}
+	}
+	return keys
+}
+
+// save writes keys to the keyring file, if any.
+func (kr *Keyring) save(keys []keyringEntry) error {
+	if kr.file == "" {
+		return nil
+	}
+	return writeKeyringFile(kr.file, keys)
+}
+
+func (kr *Keyring) expired(entry keyringEntry, now time.Time) bool {
+	return !entry.retired.IsZero() && kr.ttl > 0 && entry.retired.Add(kr.ttl).Before(now)
+}
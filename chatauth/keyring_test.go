@@ -0,0 +1,136 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring_Rotate(t *testing.T) {
+	key, err := GenerateEd25519Key()
+	require.NoError(t, err)
+
+	kr, err := NewKeyring([]*Key{key}, WithRetainKeys(1))
+	require.NoError(t, err)
+
+	auth, err := NewJWTAuth(kr, 0)
+	require.NoError(t, err)
+
+	uid := uuid.New()
+	token, err := auth.Sign(NewClaims(uid), nil)
+	require.NoError(t, err)
+
+	old, cur, err := kr.Rotate()
+	require.NoError(t, err)
+	assert.Same(t, key, old)
+	assert.Same(t, cur, kr.Current())
+	assert.Equal(t, cur.Method(), key.Method())
+	assert.Len(t, kr.JWKSet().Keys, 2)
+
+	t.Run("previous key", func(t *testing.T) {
+		claims, err := auth.Parse(token, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, uid, claims.UserID)
+	})
+	t.Run("exceed retain", func(t *testing.T) {
+		_, _, err = kr.Rotate()
+		require.NoError(t, err)
+		assert.Len(t, kr.Keys(), 2)
+
+		_, err = auth.Parse(token, nil)
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+	})
+}
+
+func TestKeyring_Find(t *testing.T) {
+	cur, err := GenerateSecretKey()
+	require.NoError(t, err)
+	prev, err := GenerateSecretKey()
+	require.NoError(t, err)
+
+	kr, err := NewKeyring([]*Key{cur, prev}, WithTokenLifetime(time.Millisecond))
+	require.NoError(t, err)
+
+	have, err := kr.Find(cur.ID())
+	assert.NoError(t, err)
+	assert.Same(t, cur, have)
+
+	time.Sleep(2 * time.Millisecond)
+	_, err = kr.Find(prev.ID())
+	assert.ErrorIs(t, err, ErrUnknownKeyID)
+}
+
+func TestKeyring_Revoke(t *testing.T) {
+	key, err := GenerateSecretKey()
+	require.NoError(t, err)
+	kr, err := NewKeyring([]*Key{key}, WithRetainKeys(10))
+	require.NoError(t, err)
+
+	t.Run("current key", func(t *testing.T) {
+		kid := kr.Current().ID()
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Go(func() { _, _, _ = kr.Rotate() })
+		}
+
+		assert.NoError(t, kr.Revoke(kid))
+		wg.Wait()
+
+		_, err := kr.Find(kid)
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+		assert.NotEqual(t, kid, kr.Current().ID())
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		assert.ErrorIs(t, kr.Revoke("foo"), ErrUnknownKeyID)
+	})
+}
+
+func TestWithKeyringFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "keyring.json")
+
+	for name, gen := range map[string]KeyGenerator{
+		"HS256": GenerateSecretKey,
+		"EdDSA": GenerateEd25519Key,
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, err := gen()
+			require.NoError(t, err)
+
+			kr, err := NewKeyring([]*Key{key}, WithKeyringFile(file))
+			require.NoError(t, err)
+			_, cur, err := kr.Rotate()
+			require.NoError(t, err)
+
+			auth, err := NewJWTAuth(kr, 0)
+			require.NoError(t, err)
+			token, err := auth.Sign(NewClaims(uuid.New()), testSalter("foo"))
+			require.NoError(t, err)
+
+			// the provided key is ignored once the keyring file exists
+			other, err := gen()
+			require.NoError(t, err)
+			restored, err := NewKeyring([]*Key{other}, WithKeyringFile(file))
+			require.NoError(t, err)
+			assert.Equal(t, cur.ID(), restored.Current().ID())
+			assert.Len(t, restored.Keys(), 2)
+
+			auth, err = NewJWTAuth(restored, 0)
+			require.NoError(t, err)
+			_, err = auth.Parse(token, testSalter("foo"))
+			assert.NoError(t, err)
+
+			require.NoError(t, os.Remove(file))
+		})
+	}
+}
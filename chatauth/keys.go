@@ -0,0 +1,227 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+
+	"github.com/go-pogo/errors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ErrNoPEMData          errors.Msg = "no PEM data found"
+	ErrInvalidPrivateKey  errors.Msg = "invalid private key"
+	ErrUnsupportedKeySize errors.Msg = "unsupported key size"
+)
+
+// KeyGenerator generates a new [Key].
+type KeyGenerator func() (*Key, error)
+
+// Key is either an asymmetric private key or a HMAC secret, which is used to
+// sign tokens. The ID of an asymmetric key is the RFC 7638 thumbprint of its
+// public key.
+type Key struct {
+	id     string
+	method jwt.SigningMethod
+	signer crypto.Signer
+	secret []byte
+	jwk    JWK
+}
+
+// NewSecretKey creates a HMAC [Key] from secret.
+func NewSecretKey(secret []byte) *Key {
+	id := make([]byte, 12)
+	_, _ = rand.Read(id)
+
+	return &Key{
+		id:     b64(id),
+		method: jwt.SigningMethodHS256,
+		secret: secret,
+	}
+}
+
+// GenerateSecretKey generates a HMAC [Key] with a random secret.
+func GenerateSecretKey() (*Key, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return NewSecretKey(secret), nil
+}
+
+// GenerateEd25519Key generates a new Ed25519 [Key].
+func GenerateEd25519Key() (*Key, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return NewKey(priv)
+}
+
+// NewKey creates a [Key] from an Ed25519, RSA or ECDSA private key.
+func NewKey(signer crypto.Signer) (*Key, error) {
+	method, err := signingMethod(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	jwk, err := NewJWK(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	jwk.KeyID = jwk.Thumbprint()
+	jwk.Use = "sig"
+	jwk.Algorithm = method.Alg()
+
+	return &Key{
+		id:     jwk.KeyID,
+		method: method,
+		signer: signer,
+		jwk:    jwk,
+	}, nil
+}
+
+// LoadKeyFile reads a PEM encoded private key from file and creates a [Key]
+// from it.
+func LoadKeyFile(file string) (*Key, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	signer, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to load key file `%s`", file)
+	}
+	return NewKey(signer)
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #8, PKCS #1 (RSA) or SEC 1 (EC)
+// private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(ErrNoPEMData)
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidPrivateKey)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New(ErrUnsupportedKeyType)
+	}
+	return signer, nil
+}
+
+// Generator returns a [KeyGenerator] which generates keys of the same type
+// and size as k.
+func (k *Key) Generator() KeyGenerator {
+	switch pub := k.Public().(type) {
+	case ed25519.PublicKey:
+		return GenerateEd25519Key
+
+	case *rsa.PublicKey:
+		return func() (*Key, error) {
+			priv, err := rsa.GenerateKey(rand.Reader, pub.N.BitLen())
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			return NewKey(priv)
+		}
+
+	case *ecdsa.PublicKey:
+		return func() (*Key, error) {
+			priv, err := ecdsa.GenerateKey(pub.Curve, rand.Reader)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			return NewKey(priv)
+		}
+
+	default:
+		return GenerateSecretKey
+	}
+}
+
+func (k *Key) ID() string { return k.id }
+
+func (k *Key) Method() jwt.SigningMethod { return k.method }
+
+// IsAsymmetric indicates if the key is a private key instead of a secret.
+func (k *Key) IsAsymmetric() bool { return k.signer != nil }
+
+// Public returns the public key of an asymmetric key, or nil otherwise.
+func (k *Key) Public() crypto.PublicKey {
+	if k.signer == nil {
+		return nil
+	}
+	return k.signer.Public()
+}
+
+// JWK returns the public key as [JWK]. It is empty for secret keys.
+func (k *Key) JWK() JWK { return k.jwk }
+
+func (k *Key) signKey() any {
+	if k.signer != nil {
+		return k.signer
+	}
+	return k.secret
+}
+
+func (k *Key) verifyKey() any {
+	if k.signer != nil {
+		return k.signer.Public()
+	}
+	return k.secret
+}
+
+func signingMethod(pub crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+
+	case *rsa.PublicKey:
+		if k.Size() < 256 {
+			return nil, errors.New(ErrUnsupportedKeySize)
+		}
+		return jwt.SigningMethodRS256, nil
+
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, errors.New(ErrUnsupportedKeySize)
+
+	default:
+		return nil, errors.New(ErrUnsupportedKeyType)
+	}
+}
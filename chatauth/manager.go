@@ -5,7 +5,11 @@
 package chatauth
 
 import (
+	"sync"
+	"time"
+
 	"connectrpc.com/connect"
+	"github.com/go-pogo/errors"
 	"github.com/google/uuid"
 	"github.com/roeldev/demo-chatroom/chatevents"
 	"github.com/roeldev/demo-chatroom/chatevents/event"
@@ -16,29 +20,67 @@ type Leaver interface {
 	Leave(uid chatusers.UserID, reason event.LeaveReason)
 }
 
+// Tokens are the access and refresh tokens issued on join and renew.
+type Tokens struct {
+	Access         string
+	AccessExpires  time.Time
+	Refresh        string
+	RefreshExpires time.Time
+}
+
 type Manager struct {
 	Signer
-	users chatusers.UsersStore
-	event chatevents.Publisher
+	users    chatusers.UsersStore
+	event    chatevents.Publisher
+	revoked  RevocationStore
+	refresh  RefreshStore
+	grace    time.Duration
+	audit    *Auditor
+	presence *Presence
+
+	mut    sync.Mutex
+	tokens map[chatusers.UserID]map[string]time.Time // jti => expires
+
+	sessMut  sync.Mutex
+	sessions map[chatusers.UserID]*session
 }
 
-func NewManager(signer Signer, users chatusers.UsersStore, pub chatevents.Publisher) *Manager {
-	return &Manager{
-		Signer: signer,
-		users:  users,
-		event:  pub,
+func NewManager(signer Signer, users chatusers.UsersStore, pub chatevents.Publisher, revoked RevocationStore, refresh RefreshStore, opts ...ManagerOption) *Manager {
+	if revoked == nil {
+		revoked = NewRevocationStore()
+	}
+	if refresh == nil {
+		refresh = NewRefreshStore(DefaultRefreshTokenLifetime)
+	}
+
+	man := &Manager{
+		Signer:   signer,
+		users:    users,
+		event:    pub,
+		revoked:  revoked,
+		refresh:  refresh,
+		tokens:   make(map[chatusers.UserID]map[string]time.Time, 8),
+		sessions: make(map[chatusers.UserID]*session, 8),
+	}
+	for _, opt := range opts {
+		opt(man)
 	}
+	if man.presence == nil {
+		man.presence = NewPresence(users, pub, 0, 0)
+	}
+	return man
 }
 
-func (man *Manager) Join(user chatusers.User, salter SecretSalter) (chatusers.UserID, string, error) {
+func (man *Manager) Join(user chatusers.User, salter SecretSalter) (chatusers.UserID, Tokens, error) {
 	uid, err := man.users.Add(user)
 	if err != nil {
-		return uuid.Nil, "", err
+		return uuid.Nil, Tokens{}, err
 	}
 
-	token, err := man.Signer.Sign(NewClaims(uid), salter)
+	tokens, err := man.issue(uid, user.Role, "", salter)
 	if err != nil {
-		return uid, "", connect.NewError(connect.CodeInternal, err)
+		man.users.Delete(uid)
+		return uid, Tokens{}, connect.NewError(connect.CodeInternal, err)
 	}
 
 	man.event.Publish(&event.UserJoinEvent{
@@ -46,10 +88,168 @@ func (man *Manager) Join(user chatusers.User, salter SecretSalter) (chatusers.Us
 		UserDetails: user.UserDetails,
 		UserFlags:   user.Flags,
 	})
-	return uid, token, nil
+	return uid, tokens, nil
 }
 
+// Renew redeems the single-use refresh token and issues a new access token
+// and refresh token. When the refresh token is reused, all tokens of its
+// user are revoked.
+func (man *Manager) Renew(refresh string, salter SecretSalter) (chatusers.UserID, Tokens, error) {
+	rt, err := man.refresh.Redeem(refresh)
+	if errors.Is(err, ErrRefreshTokenReused) {
+		man.revokeAll(rt.UserID, err.Error())
+	}
+	if err != nil {
+		return rt.UserID, Tokens{}, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	user, err := man.users.Get(rt.UserID)
+	if err != nil {
+		return rt.UserID, Tokens{}, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	tokens, err := man.issue(rt.UserID, user.Role, rt.Family, salter)
+	if err != nil {
+		return rt.UserID, Tokens{}, connect.NewError(connect.CodeInternal, err)
+	}
+	return rt.UserID, tokens, nil
+}
+
+func (man *Manager) issue(uid chatusers.UserID, role chatusers.Role, family string, salter SecretSalter) (Tokens, error) {
+	claims := NewClaims(uid)
+	claims.Role = role
+	access, err := man.sign(claims, salter)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	refresh, rt, err := man.refresh.Issue(uid, family)
+	if err != nil {
+		man.Revoke(*claims)
+		return Tokens{}, err
+	}
+
+	tokens := Tokens{
+		Access:         access,
+		Refresh:        refresh,
+		RefreshExpires: rt.Expires,
+	}
+	if claims.ExpiresAt != nil {
+		tokens.AccessExpires = claims.ExpiresAt.Time
+	}
+	return tokens, nil
+}
+
+func (man *Manager) sign(claims *Claims, salter SecretSalter) (string, error) {
+	token, err := man.Signer.Sign(claims, salter)
+	if err != nil {
+		return "", err
+	}
+
+	man.mut.Lock()
+	defer man.mut.Unlock()
+
+	tokens := man.tokens[claims.UserID]
+	if tokens == nil {
+		tokens = make(map[string]time.Time, 2)
+		man.tokens[claims.UserID] = tokens
+	}
+
+	now := time.Now()
+	for jti, exp := range tokens {
+		if !exp.IsZero() && exp.Before(now) {
+			delete(tokens, jti)
+		}
+	}
+
+	var expires time.Time
+	if claims.ExpiresAt != nil {
+		expires = claims.ExpiresAt.Time
+	}
+	tokens[claims.ID] = expires
+	return token, nil
+}
+
+// Revoke revokes the token with the provided claims, it is rejected
+// immediately by any following request.
+func (man *Manager) Revoke(claims Claims) {
+	var expires time.Time
+	if claims.ExpiresAt != nil {
+		expires = claims.ExpiresAt.Time
+	}
+	man.revoked.Revoke(claims.ID, expires)
+
+	man.mut.Lock()
+	delete(man.tokens[claims.UserID], claims.ID)
+	man.mut.Unlock()
+
+	man.audit.Record(AuditRecord{
+		Event:   AuditRevoke,
+		TokenID: claims.ID,
+	}.WithUser(claims.UserID))
+}
+
+// RevokeAll revokes all access and refresh tokens which are issued to the
+// user.
+func (man *Manager) RevokeAll(uid chatusers.UserID) {
+	man.revokeAll(uid, "revoke all")
+}
+
+func (man *Manager) revokeAll(uid chatusers.UserID, reason string) {
+	man.refresh.RevokeUser(uid)
+	man.revokeAccess(uid)
+
+	man.audit.Record(AuditRecord{
+		Event:  AuditRevoke,
+		Reason: reason,
+	}.WithUser(uid))
+}
+
+func (man *Manager) revokeAccess(uid chatusers.UserID) {
+	man.mut.Lock()
+	tokens := man.tokens[uid]
+	delete(man.tokens, uid)
+	man.mut.Unlock()
+
+	for jti, expires := range tokens {
+		man.revoked.Revoke(jti, expires)
+	}
+}
+
+// SetRole changes the role of the user. Its access tokens are revoked so the
+// new role is used once the client renews its token.
+func (man *Manager) SetRole(uid chatusers.UserID, role chatusers.Role) error {
+	before, _, err := man.users.UpdateFunc(uid, func(user *chatusers.User) error {
+		user.Role = role
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if before.Role == role {
+		return nil
+	}
+
+	man.revokeAccess(uid)
+	man.audit.Record(AuditRecord{
+		Event:  AuditRevoke,
+		Reason: "role changed to " + role.String(),
+	}.WithUser(uid))
+	return nil
+}
+
+func (man *Manager) IsRevoked(jti string) bool { return man.revoked.IsRevoked(jti) }
+
+// Auditor returns the [Auditor] set using [WithAuditor], or nil.
+func (man *Manager) Auditor() *Auditor { return man.audit }
+
 func (man *Manager) Leave(uid chatusers.UserID, reason event.LeaveReason) {
+	man.endSession(uid)
+	man.revokeAll(uid, "leave")
+	man.audit.Record(AuditRecord{
+		Event:  AuditLeave,
+		Reason: reason.String(),
+	}.WithUser(uid))
 	if user, ok := man.users.Delete(uid); ok {
 		man.event.Publish(&event.UserLeaveEvent{
 			UserID:      uid,
@@ -58,3 +258,13 @@ func (man *Manager) Leave(uid chatusers.UserID, reason event.LeaveReason) {
 		})
 	}
 }
+
+// Kick removes the user from the chatroom and revokes all of its tokens.
+func (man *Manager) Kick(uid chatusers.UserID) error {
+	if !man.users.Has(uid) {
+		return errors.New(chatusers.ErrUserNotFound)
+	}
+
+	man.Leave(uid, event.Kicked)
+	return nil
+}
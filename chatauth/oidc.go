@@ -0,0 +1,279 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	ErrInvalidIDToken     errors.Msg = "invalid id token"
+	ErrDiscoveryFailed    errors.Msg = "oidc discovery failed"
+	ErrIssuerMismatch     errors.Msg = "issuer does not match"
+	ErrMissingIdentityKey errors.Msg = "unable to find key to verify id token"
+)
+
+// DiscoveryPath is the well-known path of an OpenID provider's configuration.
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// minJWKSRefresh limits how often the issuer's keys are fetched when a token
+// is signed with an unknown key.
+const minJWKSRefresh = time.Minute
+
+// fetchTimeout limits how long fetching the issuer's configuration and keys
+// may take.
+const fetchTimeout = 10 * time.Second
+
+var _ IdentityVerifier = (*OIDCVerifier)(nil)
+
+// Identity contains the details of a user that are verified by an external
+// identity provider.
+type Identity struct {
+	Issuer  string
+	Subject string
+	Name    string
+	Picture string
+	Email   string
+}
+
+// IdentityVerifier verifies an identity token and returns the [Identity] it
+// contains.
+type IdentityVerifier interface {
+	VerifyIdentity(ctx context.Context, token string) (Identity, error)
+}
+
+// IDTokenClaims are the claims of an OIDC ID token.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	Email             string `json:"email,omitempty"`
+}
+
+// DisplayName returns the best available name of the user.
+func (c IDTokenClaims) DisplayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	if name := strings.TrimSpace(c.GivenName + " " + c.FamilyName); name != "" {
+		return name
+	}
+	return c.PreferredUsername
+}
+
+// OIDCVerifier verifies ID tokens which are issued by an OpenID provider. The
+// provider's configuration and keys are discovered on first use.
+type OIDCVerifier struct {
+	issuer   string
+	clientID string
+	client   *http.Client
+	group    singleflight.Group
+
+	mut     sync.Mutex
+	jwksURI string
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+// NewOIDCVerifier creates a new [OIDCVerifier] which accepts ID tokens from
+// issuer with clientID as audience. When client is nil,
+// [http.DefaultClient] is used.
+func NewOIDCVerifier(issuer, clientID string, client *http.Client) *OIDCVerifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &OIDCVerifier{
+		issuer:   strings.TrimSuffix(issuer, "/"),
+		clientID: clientID,
+		client:   client,
+	}
+}
+
+func (v *OIDCVerifier) Issuer() string { return v.issuer }
+
+func (v *OIDCVerifier) VerifyIdentity(ctx context.Context, token string) (Identity, error) {
+	claims, err := v.Verify(ctx, token)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	return Identity{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Name:    claims.DisplayName(),
+		Picture: claims.Picture,
+		Email:   claims.Email,
+	}, nil
+}
+
+// Verify verifies the signature and claims of the ID token.
+func (v *OIDCVerifier) Verify(ctx context.Context, token string) (IDTokenClaims, error) {
+	var claims IDTokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims,
+		func(tok *jwt.Token) (any, error) {
+			kid, _ := tok.Header["kid"].(string)
+			return v.key(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(v.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return claims, errors.Wrap(err, ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return claims, errors.New(ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// key returns the public key with the provided key id. The issuer's keys are
+// (re)fetched when the key is unknown. Concurrent callers share a single
+// fetch, which is not bound to the context of any of them.
+func (v *OIDCVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mut.Lock()
+	key, ok := v.find(kid)
+	recent := v.fetchedRecently()
+	v.mut.Unlock()
+
+	if ok {
+		return key, nil
+	}
+	if recent {
+		return nil, errors.New(ErrMissingIdentityKey)
+	}
+
+	ch := v.group.DoChan("keys", func() (any, error) {
+		v.mut.Lock()
+		recent := v.fetchedRecently()
+		v.mut.Unlock()
+		if recent {
+			// keys were fetched while waiting to get here
+			return nil, nil
+		}
+
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+		return nil, v.fetchKeys(fetchCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.WithStack(context.Cause(ctx))
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+	}
+
+	v.mut.Lock()
+	defer v.mut.Unlock()
+	if key, ok = v.find(kid); ok {
+		return key, nil
+	}
+	return nil, errors.New(ErrMissingIdentityKey)
+}
+
+// fetchedRecently indicates if the keys were fetched less than
+// [minJWKSRefresh] ago. The caller must hold v.mut.
+func (v *OIDCVerifier) fetchedRecently() bool {
+	return !v.fetched.IsZero() && time.Since(v.fetched) < minJWKSRefresh
+}
+
+func (v *OIDCVerifier) find(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// fetchKeys fetches the issuer's keys, discovering the location of the key
+// set first when it is not yet known. It must not be called while holding
+// v.mut.
+func (v *OIDCVerifier) fetchKeys(ctx context.Context) error {
+	v.mut.Lock()
+	jwksURI := v.jwksURI
+	v.mut.Unlock()
+
+	if jwksURI == "" {
+		var conf struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := v.getJSON(ctx, v.issuer+DiscoveryPath, &conf); err != nil {
+			return errors.Wrap(err, ErrDiscoveryFailed)
+		}
+		if strings.TrimSuffix(conf.Issuer, "/") != v.issuer {
+			return errors.Wrap(errors.New(ErrIssuerMismatch), ErrDiscoveryFailed)
+		}
+		if conf.JWKSURI == "" {
+			return errors.New(ErrDiscoveryFailed)
+		}
+		jwksURI = conf.JWKSURI
+	}
+
+	var set JWKSet
+	if err := v.getJSON(ctx, jwksURI, &set); err != nil {
+		return err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// skip keys of unsupported types
+		if key, err := jwk.PublicKey(); err == nil {
+			keys[jwk.KeyID] = key
+		}
+	}
+
+	v.mut.Lock()
+	v.jwksURI = jwksURI
+	v.keys = keys
+	v.fetched = time.Now()
+	v.mut.Unlock()
+	return nil
+}
+
+func (v *OIDCVerifier) getJSON(ctx context.Context, url string, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Newf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return errors.WithStack(json.NewDecoder(resp.Body).Decode(dest))
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testIssuer struct {
+	*httptest.Server
+	key     *Key
+	fetches atomic.Int32
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := NewKey(pk)
+	require.NoError(t, err)
+
+	iss := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+DiscoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   iss.URL,
+			"jwks_uri": iss.URL + JWKSPath,
+		})
+	})
+	mux.Handle("GET "+JWKSPath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		iss.fetches.Add(1)
+		JWKSHandler(iss).ServeHTTP(w, r)
+	}))
+
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func (iss *testIssuer) JWKSet() JWKSet { return JWKSet{Keys: []JWK{iss.key.JWK()}} }
+
+func (iss *testIssuer) sign(t *testing.T, claims IDTokenClaims) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(iss.key.Method(), claims)
+	tok.Header["kid"] = iss.key.ID()
+	str, err := tok.SignedString(iss.key.signKey())
+	require.NoError(t, err)
+	return str
+}
+
+func (iss *testIssuer) claims(aud string) IDTokenClaims {
+	now := time.Now()
+	return IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    iss.URL,
+			Subject:   "user-1",
+			Audience:  jwt.ClaimStrings{aud},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		GivenName:  "Jane",
+		FamilyName: "Doe",
+		Picture:    "https://example.com/jane.png",
+	}
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	iss := newTestIssuer(t)
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		v := NewOIDCVerifier(iss.URL, "chatroom", iss.Client())
+		have, err := v.VerifyIdentity(ctx, iss.sign(t, iss.claims("chatroom")))
+		require.NoError(t, err)
+		assert.Equal(t, Identity{
+			Issuer:  iss.URL,
+			Subject: "user-1",
+			Name:    "Jane Doe",
+			Picture: "https://example.com/jane.png",
+		}, have)
+	})
+	t.Run("wrong audience", func(t *testing.T) {
+		v := NewOIDCVerifier(iss.URL, "chatroom", iss.Client())
+		_, err := v.Verify(ctx, iss.sign(t, iss.claims("other")))
+		assert.True(t, errors.Is(err, ErrInvalidIDToken))
+	})
+	t.Run("expired", func(t *testing.T) {
+		claims := iss.claims("chatroom")
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+		v := NewOIDCVerifier(iss.URL, "chatroom", iss.Client())
+		_, err := v.Verify(ctx, iss.sign(t, claims))
+		assert.True(t, errors.Is(err, ErrInvalidIDToken))
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		other := newTestIssuer(t)
+		claims := other.claims("chatroom")
+		claims.Issuer = iss.URL
+
+		v := NewOIDCVerifier(iss.URL, "chatroom", iss.Client())
+		_, err := v.Verify(ctx, other.sign(t, claims))
+		assert.True(t, errors.Is(err, ErrMissingIdentityKey))
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		token := iss.sign(t, iss.claims("chatroom"))
+		before := iss.fetches.Load()
+
+		v := NewOIDCVerifier(iss.URL, "chatroom", iss.Client())
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := v.Verify(ctx, token)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), iss.fetches.Load()-before)
+	})
+	t.Run("issuer mismatch", func(t *testing.T) {
+		v := NewOIDCVerifier(iss.URL+"/other", "chatroom", iss.Client())
+		_, err := v.Verify(ctx, iss.sign(t, iss.claims("chatroom")))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/roeldev/demo-chatroom/chatevents"
+	"github.com/roeldev/demo-chatroom/chatevents/event"
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+var _ chatevents.EventHandler = (*Presence)(nil)
+
+// errStatusUnchanged aborts a status change which is not needed, or not
+// allowed because the user explicitly set its status.
+const errStatusUnchanged errors.Msg = "status is not changed"
+
+// Presence automatically changes the status of users based on their activity.
+// A user becomes away when it has not sent a message, emoji reply or typed
+// for a while, and unresponsive when its keepalive stream is lost. Activity
+// or reconnecting changes the status back to default. A status which is
+// explicitly set by the user, e.g. busy, is never changed.
+type Presence struct {
+	users        chatusers.UsersStore
+	event        chatevents.Publisher
+	away         time.Duration
+	unresponsive time.Duration
+
+	mut   sync.Mutex
+	state map[chatusers.UserID]*presence
+}
+
+type presence struct {
+	idle *time.Timer      // changes status to away
+	lost *time.Timer      // changes status to unresponsive
+	auto chatusers.Status // status set by Presence
+}
+
+// NewPresence creates a new [Presence]. Users become away after being
+// inactive for the away duration, which is disabled when zero. Users become
+// unresponsive when their last stream is closed for the unresponsive
+// duration, or immediately when zero.
+func NewPresence(users chatusers.UsersStore, pub chatevents.Publisher, away, unresponsive time.Duration) *Presence {
+	if users == nil {
+		panic("chatauth.NewPresence: users must not be nil")
+	}
+	if pub == nil {
+		panic("chatauth.NewPresence: pub must not be nil")
+	}
+
+	return &Presence{
+		users:        users,
+		event:        pub,
+		away:         away,
+		unresponsive: unresponsive,
+		state:        make(map[chatusers.UserID]*presence, 8),
+	}
+}
+
+// WithPresence sets the [Presence] which changes the status of users when
+// their streams are closed and resumed.
+func WithPresence(p *Presence) ManagerOption {
+	return func(man *Manager) { man.presence = p }
+}
+
+// HandleEvent registers activity of users from chat, emoji reply, typing and
+// join events, and stops tracking users who leave. Status events which are
+// not caused by [Presence] mark the user's status as explicitly set.
+func (p *Presence) HandleEvent(e chatevents.Event) {
+	switch et := e.Type.(type) {
+	case *event.UserJoinEvent:
+		p.Active(et.UserID)
+	case *event.ChatEvent:
+		p.Active(et.UserID)
+	case *event.EmojiReplyEvent:
+		p.Active(et.UserID)
+	case *event.UserTypingEvent:
+		if et.IsTyping {
+			p.Active(et.UserID)
+		}
+	case *event.UserLeaveEvent:
+		p.Forget(et.UserID)
+	case *event.UserStatusEvent:
+		p.mut.Lock()
+		if st := p.state[et.UserID]; st != nil && st.auto != et.After {
+			st.auto = chatusers.Status_Default
+		}
+		p.mut.Unlock()
+	}
+}
+
+// Active registers activity of the user. Its status changes back to default
+// when it is away.
+func (p *Presence) Active(uid chatusers.UserID) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	st := p.get(uid)
+	if st.idle != nil {
+		st.idle.Reset(p.away)
+	}
+	if st.auto == chatusers.Status_Away {
+		p.change(uid, st, chatusers.Status_Default)
+	}
+}
+
+// Connect registers the user's stream is (re)opened. Its status changes back
+// to default when it is unresponsive or away.
+func (p *Presence) Connect(uid chatusers.UserID) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	st := p.get(uid)
+	if st.lost != nil {
+		st.lost.Stop()
+		st.lost = nil
+	}
+	if st.idle != nil {
+		st.idle.Reset(p.away)
+	}
+	if st.auto != chatusers.Status_Default {
+		p.change(uid, st, chatusers.Status_Default)
+	}
+}
+
+// Disconnect registers the user's last stream is closed. The user becomes
+// unresponsive when it does not reconnect in time.
+func (p *Presence) Disconnect(uid chatusers.UserID) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	st := p.get(uid)
+	if p.unresponsive <= 0 {
+		p.change(uid, st, chatusers.Status_Unresponsive)
+		return
+	}
+	if st.lost == nil {
+		st.lost = time.AfterFunc(p.unresponsive, func() { p.expire(uid, chatusers.Status_Unresponsive) })
+	}
+}
+
+// Forget stops tracking the user, e.g. when it leaves the chatroom.
+func (p *Presence) Forget(uid chatusers.UserID) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if st, ok := p.state[uid]; ok {
+		if st.idle != nil {
+			st.idle.Stop()
+		}
+		if st.lost != nil {
+			st.lost.Stop()
+		}
+		delete(p.state, uid)
+	}
+}
+
+// get returns the state of the user, it must be called with mut locked.
+func (p *Presence) get(uid chatusers.UserID) *presence {
+	st := p.state[uid]
+	if st == nil {
+		st = new(presence)
+		if p.away > 0 {
+			st.idle = time.AfterFunc(p.away, func() { p.expire(uid, chatusers.Status_Away) })
+		}
+		p.state[uid] = st
+	}
+	return st
+}
+
+func (p *Presence) expire(uid chatusers.UserID, status chatusers.Status) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	st := p.state[uid]
+	if st == nil {
+		return
+	}
+	if !p.users.Has(uid) {
+		// the user left before its state was forgotten
+		delete(p.state, uid)
+		return
+	}
+	if status == chatusers.Status_Unresponsive {
+		st.lost = nil
+	} else if st.auto != chatusers.Status_Default {
+		// an unresponsive user does not become away
+		return
+	}
+	p.change(uid, st, status)
+}
+
+// change changes the status of the user to after, unless its current status
+// is explicitly set by the user. It must be called with mut locked.
+func (p *Presence) change(uid chatusers.UserID, st *presence, after chatusers.Status) {
+	prev, user, err := p.users.UpdateFunc(uid, func(user *chatusers.User) error {
+		if user.Status == after || (user.Status != chatusers.Status_Default && user.Status != st.auto) {
+			return errors.New(errStatusUnchanged)
+		}
+		user.Status = after
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	st.auto = after
+	p.event.Publish(&event.UserStatusEvent{
+		UserID:      uid,
+		UserDetails: user.UserDetails,
+		Before:      prev.Status,
+		After:       after,
+	})
+}
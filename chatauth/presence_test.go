@@ -0,0 +1,94 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roeldev/demo-chatroom/chatevents"
+	"github.com/roeldev/demo-chatroom/chatevents/event"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPresence(t *testing.T, away, unresponsive time.Duration, status chatusers.Status) (*Presence, chatusers.UsersStore, chatusers.UserID) {
+	t.Helper()
+
+	users := chatusers.NewUsersStore(1)
+	uid, err := users.Add(chatusers.User{
+		UserDetails: chatusers.UserDetails{Name: "foo"},
+		Status:      status,
+	})
+	require.NoError(t, err)
+
+	p := NewPresence(users, new(testPublisher), away, unresponsive)
+	t.Cleanup(func() { p.Forget(uid) })
+	return p, users, uid
+}
+
+func eventuallyStatus(t *testing.T, users chatusers.UsersStore, uid chatusers.UserID, want chatusers.Status) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		user, err := users.Get(uid)
+		return err == nil && user.Status == want
+	}, time.Second, time.Millisecond)
+}
+
+func TestPresence(t *testing.T) {
+	t.Run("away", func(t *testing.T) {
+		p, users, uid := newTestPresence(t, 10*time.Millisecond, time.Hour, chatusers.Status_Default)
+		p.HandleEvent(chatevents.Event{Type: &event.UserJoinEvent{UserID: uid}})
+		eventuallyStatus(t, users, uid, chatusers.Status_Away)
+
+		p.HandleEvent(chatevents.Event{Type: &event.ChatEvent{UserID: uid}})
+		eventuallyStatus(t, users, uid, chatusers.Status_Default)
+	})
+	t.Run("unresponsive", func(t *testing.T) {
+		p, users, uid := newTestPresence(t, 0, 10*time.Millisecond, chatusers.Status_Default)
+		p.Connect(uid)
+		p.Disconnect(uid)
+		eventuallyStatus(t, users, uid, chatusers.Status_Unresponsive)
+
+		p.Connect(uid)
+		eventuallyStatus(t, users, uid, chatusers.Status_Default)
+	})
+	t.Run("reconnect in time", func(t *testing.T) {
+		p, users, uid := newTestPresence(t, 0, 10*time.Millisecond, chatusers.Status_Default)
+		p.Disconnect(uid)
+		p.Connect(uid)
+
+		time.Sleep(20 * time.Millisecond)
+		eventuallyStatus(t, users, uid, chatusers.Status_Default)
+	})
+	t.Run("busy is never overridden", func(t *testing.T) {
+		p, users, uid := newTestPresence(t, time.Millisecond, 0, chatusers.Status_Busy)
+		p.Active(uid)
+		p.Disconnect(uid)
+
+		time.Sleep(10 * time.Millisecond)
+		eventuallyStatus(t, users, uid, chatusers.Status_Busy)
+	})
+	t.Run("explicit status", func(t *testing.T) {
+		p, users, uid := newTestPresence(t, 0, 0, chatusers.Status_Default)
+		p.Disconnect(uid)
+		eventuallyStatus(t, users, uid, chatusers.Status_Unresponsive)
+
+		// the user explicitly changes its status to away while disconnected
+		user, err := users.Get(uid)
+		require.NoError(t, err)
+		user.Status = chatusers.Status_Away
+		require.NoError(t, users.Update(uid, user))
+		p.HandleEvent(chatevents.Event{Type: &event.UserStatusEvent{
+			UserID: uid,
+			Before: chatusers.Status_Unresponsive,
+			After:  chatusers.Status_Away,
+		}})
+
+		p.Connect(uid)
+		eventuallyStatus(t, users, uid, chatusers.Status_Away)
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+// refreshFile is the JSON representation of the state of a refreshStore.
+// Tokens are stored by their hash, never in plain text.
+type refreshFile struct {
+	Families map[string]chatusers.UserID `json:"families"`
+	Tokens   []refreshFileEntry          `json:"tokens"`
+}
+
+type refreshFileEntry struct {
+	Hash    string           `json:"hash"`
+	Family  string           `json:"family"`
+	UserID  chatusers.UserID `json:"user_id"`
+	Expires time.Time        `json:"expires"`
+	Used    bool             `json:"used,omitempty"`
+}
+
+// readRefreshFile reads the families and tokens of a refreshStore from file.
+// It does not change rs when file does not exist.
+func readRefreshFile(file string, rs *refreshStore) error {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var rf refreshFile
+	if err = json.Unmarshal(data, &rf); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for family, uid := range rf.Families {
+		rs.families[family] = uid
+	}
+	for _, fe := range rf.Tokens {
+		raw, err := unb64(fe.Hash)
+		if err != nil || len(raw) != sha256.Size {
+			return errors.Newf("invalid refresh token hash in file %s", file)
+		}
+
+		rs.tokens[[sha256.Size]byte(raw)] = &refreshEntry{
+			RefreshToken: RefreshToken{
+				Family:  fe.Family,
+				UserID:  fe.UserID,
+				Expires: fe.Expires,
+			},
+			used: fe.Used,
+		}
+	}
+	return nil
+}
+
+// writeRefreshFile writes the families and tokens of rs to file, which is
+// replaced atomically.
+func writeRefreshFile(file string, rs *refreshStore) error {
+	rf := refreshFile{
+		Families: rs.families,
+		Tokens:   make([]refreshFileEntry, 0, len(rs.tokens)),
+	}
+	for hash, entry := range rs.tokens {
+		rf.Tokens = append(rf.Tokens, refreshFileEntry{
+			Hash:    b64(hash[:]),
+			Family:  entry.Family,
+			UserID:  entry.UserID,
+			Expires: entry.Expires,
+			Used:    entry.used,
+		})
+	}
+
+	data, err := json.MarshalIndent(rf, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	tmp := file + ".tmp"
+	if err = os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp, file))
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+const (
+	ErrInvalidRefreshToken errors.Msg = "invalid refresh token"
+	ErrRefreshTokenExpired errors.Msg = "refresh token has expired"
+	ErrRefreshTokenReused  errors.Msg = "refresh token is already used"
+)
+
+const DefaultRefreshTokenLifetime = 24 * time.Hour
+
+// RefreshToken contains the details of an issued refresh token. All refresh
+// tokens which are issued by rotating a previous refresh token belong to the
+// same family.
+type RefreshToken struct {
+	Family  string
+	UserID  chatusers.UserID
+	Expires time.Time
+}
+
+// RefreshStore issues and redeems single-use refresh tokens.
+type RefreshStore interface {
+	// Issue creates a new refresh token for the user. A new family is
+	// started when family is empty.
+	Issue(uid chatusers.UserID, family string) (string, RefreshToken, error)
+	// Redeem marks the refresh token as used and returns its details. When
+	// the token was already used, the whole family is invalidated and
+	// ErrRefreshTokenReused is returned.
+	Redeem(token string) (RefreshToken, error)
+	// RevokeUser invalidates all refresh tokens of the user.
+	RevokeUser(uid chatusers.UserID)
+}
+
+type refreshStore struct {
+	mut      sync.Mutex
+	file     string
+	lifetime time.Duration
+	tokens   map[[sha256.Size]byte]*refreshEntry
+	families map[string]chatusers.UserID
+}
+
+type refreshEntry struct {
+	RefreshToken
+	used bool
+}
+
+// NewRefreshStore returns a simple in-memory [RefreshStore] which issues
+// refresh tokens that are valid for the provided lifetime.
+func NewRefreshStore(lifetime time.Duration) RefreshStore {
+	rs, _ := NewFileRefreshStore("", lifetime)
+	return rs
+}
+
+// NewFileRefreshStore returns a [RefreshStore] which stores the hashes of its
+// refresh tokens in file, so they can still be redeemed after a restart.
+// Existing tokens are loaded from file when it exists.
+func NewFileRefreshStore(file string, lifetime time.Duration) (RefreshStore, error) {
+	if lifetime <= 0 {
+		lifetime = DefaultRefreshTokenLifetime
+	}
+
+	rs := &refreshStore{
+		file:     file,
+		lifetime: lifetime,
+		tokens:   make(map[[sha256.Size]byte]*refreshEntry, 8),
+		families: make(map[string]chatusers.UserID, 8),
+	}
+	if file == "" {
+		return rs, nil
+	}
+	if err := readRefreshFile(file, rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (rs *refreshStore) Issue(uid chatusers.UserID, family string) (string, RefreshToken, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", RefreshToken{}, errors.WithStack(err)
+	}
+
+	rs.mut.Lock()
+	defer rs.mut.Unlock()
+
+	// prune before starting a new family, which has no tokens yet
+	rs.prune(time.Now())
+
+	newFamily := family == ""
+	if newFamily {
+		family = uuid.NewString()
+		rs.families[family] = uid
+	} else if _, ok := rs.families[family]; !ok {
+		return "", RefreshToken{}, errors.New(ErrInvalidRefreshToken)
+	}
+
+	token := b64(b)
+	hash := sha256.Sum256([]byte(token))
+	entry := &refreshEntry{RefreshToken: RefreshToken{
+		Family:  family,
+		UserID:  uid,
+		Expires: time.Now().Add(rs.lifetime),
+	}}
+	rs.tokens[hash] = entry
+
+	if err := rs.save(); err != nil {
+		delete(rs.tokens, hash)
+		if newFamily {
+			delete(rs.families, family)
+		}
+		return "", RefreshToken{}, err
+	}
+	return token, entry.RefreshToken, nil
+}
+
+func (rs *refreshStore) Redeem(token string) (RefreshToken, error) {
+	rs.mut.Lock()
+	defer rs.mut.Unlock()
+
+	entry, ok := rs.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return RefreshToken{}, errors.New(ErrInvalidRefreshToken)
+	}
+	if entry.used {
+		rs.revokeFamily(entry.Family)
+		return entry.RefreshToken, errors.Append(errors.New(ErrRefreshTokenReused), rs.save())
+	}
+	if entry.Expires.Before(time.Now()) {
+		return entry.RefreshToken, errors.New(ErrRefreshTokenExpired)
+	}
+
+	entry.used = true
+	if err := rs.save(); err != nil {
+		entry.used = false
+		return RefreshToken{}, err
+	}
+	return entry.RefreshToken, nil
+}
+
+func (rs *refreshStore) RevokeUser(uid chatusers.UserID) {
+	rs.mut.Lock()
+	defer rs.mut.Unlock()
+
+	for family, owner := range rs.families {
+		if owner == uid {
+			rs.revokeFamily(family)
+		}
+	}
+	// the tokens are revoked in memory regardless; the file is fully
+	// rewritten on the next successful save
+	_ = rs.save()
+}
+
+func (rs *refreshStore) revokeFamily(family string) {
+	delete(rs.families, family)
+	for hash, entry := range rs.tokens {
+		if entry.Family == family {
+			delete(rs.tokens, hash)
+		}
+	}
+}
+
+// prune removes the expired tokens, and the families which have no tokens
+// left.
+func (rs *refreshStore) prune(now time.Time) {
+	live := make(map[string]struct{}, len(rs.families))
+	for hash, entry := range rs.tokens {
+		if entry.Expires.Before(now) {
+			delete(rs.tokens, hash)
+			continue
+		}
+		live[entry.Family] = struct{}{}
+	}
+	for family := range rs.families {
+		if _, ok := live[family]; !ok {
+			delete(rs.families, family)
+		}
+	}
+}
+
+// save writes the families and tokens to file. It must be called while
+// holding the lock.
+func (rs *refreshStore) save() error {
+	if rs.file == "" {
+		return nil
+	}
+	return writeRefreshFile(rs.file, rs)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshStore(t *testing.T) {
+	uid := uuid.New()
+
+	t.Run("rotate", func(t *testing.T) {
+		store := NewRefreshStore(time.Hour)
+		first, rt, err := store.Issue(uid, "")
+		require.NoError(t, err)
+		assert.NotEmpty(t, rt.Family)
+
+		have, err := store.Redeem(first)
+		require.NoError(t, err)
+		assert.Equal(t, rt, have)
+
+		second, next, err := store.Issue(uid, have.Family)
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, rt.Family, next.Family)
+
+		_, err = store.Redeem(second)
+		assert.NoError(t, err)
+	})
+	t.Run("reuse invalidates family", func(t *testing.T) {
+		store := NewRefreshStore(time.Hour)
+		first, rt, err := store.Issue(uid, "")
+		require.NoError(t, err)
+		_, err = store.Redeem(first)
+		require.NoError(t, err)
+		second, _, err := store.Issue(uid, rt.Family)
+		require.NoError(t, err)
+
+		_, err = store.Redeem(first)
+		assert.True(t, errors.Is(err, ErrRefreshTokenReused))
+
+		_, err = store.Redeem(second)
+		assert.True(t, errors.Is(err, ErrInvalidRefreshToken))
+		_, _, err = store.Issue(uid, rt.Family)
+		assert.True(t, errors.Is(err, ErrInvalidRefreshToken))
+	})
+	t.Run("expired", func(t *testing.T) {
+		store := NewRefreshStore(time.Nanosecond)
+		token, _, err := store.Issue(uid, "")
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+
+		_, err = store.Redeem(token)
+		assert.True(t, errors.Is(err, ErrRefreshTokenExpired))
+	})
+	t.Run("prune families", func(t *testing.T) {
+		store := NewRefreshStore(time.Millisecond)
+		_, rt, err := store.Issue(uid, "")
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+
+		_, next, err := store.Issue(uid, "")
+		require.NoError(t, err)
+
+		families := store.(*refreshStore).families
+		assert.NotContains(t, families, rt.Family)
+		assert.Contains(t, families, next.Family)
+
+		_, _, err = store.Issue(uid, rt.Family)
+		assert.True(t, errors.Is(err, ErrInvalidRefreshToken))
+	})
+	t.Run("revoke user", func(t *testing.T) {
+		store := NewRefreshStore(time.Hour)
+		token, _, err := store.Issue(uid, "")
+		require.NoError(t, err)
+
+		store.RevokeUser(uid)
+		_, err = store.Redeem(token)
+		assert.True(t, errors.Is(err, ErrInvalidRefreshToken))
+	})
+	t.Run("reload", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "refresh.json")
+		store, err := NewFileRefreshStore(file, time.Hour)
+		require.NoError(t, err)
+		used, _, err := store.Issue(uid, "")
+		require.NoError(t, err)
+		_, err = store.Redeem(used)
+		require.NoError(t, err)
+		token, rt, err := store.Issue(uid, "")
+		require.NoError(t, err)
+
+		store, err = NewFileRefreshStore(file, time.Hour)
+		require.NoError(t, err)
+		have, err := store.Redeem(token)
+		require.NoError(t, err)
+		assert.Equal(t, rt.Family, have.Family)
+		assert.Equal(t, uid, have.UserID)
+
+		_, err = store.Redeem(used)
+		assert.True(t, errors.Is(err, ErrRefreshTokenReused))
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-pogo/errors"
+)
+
+// readRevocationFile reads the revoked ids and the expiry times of their
+// tokens from file. It does not change rs when file does not exist.
+func readRevocationFile(file string, rs *revocationStore) error {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var revoked map[string]time.Time
+	if err = json.Unmarshal(data, &revoked); err != nil {
+		return errors.WithStack(err)
+	}
+	for jti, expires := range revoked {
+		rs.revoked[jti] = expires
+	}
+	return nil
+}
+
+// writeRevocationFile writes the revoked ids of rs to file, which is replaced
+// atomically.
+func writeRevocationFile(file string, rs *revocationStore) error {
+	data, err := json.MarshalIndent(rs.revoked, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	tmp := file + ".tmp"
+	if err = os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp, file))
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"sync"
+	"time"
+)
+
+// RevocationChecker checks if a token is revoked by its id (jti).
+type RevocationChecker interface {
+	IsRevoked(jti string) bool
+}
+
+// RevocationStore stores the ids (jti) of revoked tokens. A revoked id only
+// needs to be remembered until the token it belongs to has expired.
+type RevocationStore interface {
+	RevocationChecker
+	Revoke(jti string, expires time.Time)
+}
+
+type revocationStore struct {
+	mut     sync.RWMutex
+	file    string
+	revoked map[string]time.Time
+}
+
+// NewRevocationStore returns a simple in-memory [RevocationStore] which
+// forgets revoked ids once their tokens have expired.
+func NewRevocationStore() RevocationStore {
+	rs, _ := NewFileRevocationStore("")
+	return rs
+}
+
+// NewFileRevocationStore returns a [RevocationStore] which persists the
+// revoked ids in file, so tokens which are still valid after a restart remain
+// revoked. Existing revoked ids are loaded from file when it exists.
+func NewFileRevocationStore(file string) (RevocationStore, error) {
+	rs := &revocationStore{
+		file:    file,
+		revoked: make(map[string]time.Time, 8),
+	}
+	if file == "" {
+		return rs, nil
+	}
+	if err := readRevocationFile(file, rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (rs *revocationStore) Revoke(jti string, expires time.Time) {
+	if jti == "" {
+		return
+	}
+
+	rs.mut.Lock()
+	defer rs.mut.Unlock()
+
+	now := time.Now()
+	for id, exp := range rs.revoked {
+		if exp.Before(now) {
+			delete(rs.revoked, id)
+		}
+	}
+	rs.revoked[jti] = expires
+
+	// the id is revoked in memory regardless; the file is fully rewritten on
+	// the next successful save
+	_ = rs.save()
+}
+
+func (rs *revocationStore) IsRevoked(jti string) bool {
+	if jti == "" {
+		return false
+	}
+
+	rs.mut.RLock()
+	defer rs.mut.RUnlock()
+
+	_, ok := rs.revoked[jti]
+	return ok
+}
+
+// save writes the revoked ids to file. It must be called while holding the
+// lock.
+func (rs *revocationStore) save() error {
+	if rs.file == "" {
+		return nil
+	}
+	return writeRevocationFile(rs.file, rs)
+}
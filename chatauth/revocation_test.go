@@ -0,0 +1,40 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationStore(t *testing.T) {
+	store := NewRevocationStore()
+	assert.False(t, store.IsRevoked(""))
+	assert.False(t, store.IsRevoked("foo"))
+
+	store.Revoke("foo", time.Now().Add(-time.Second))
+	assert.True(t, store.IsRevoked("foo"))
+
+	// expired ids are removed on the next revocation
+	store.Revoke("bar", time.Now().Add(time.Hour))
+	assert.False(t, store.IsRevoked("foo"))
+	assert.True(t, store.IsRevoked("bar"))
+}
+
+func TestFileRevocationStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "revoked.json")
+	store, err := NewFileRevocationStore(file)
+	require.NoError(t, err)
+	store.Revoke("foo", time.Now().Add(time.Hour))
+
+	store, err = NewFileRevocationStore(file)
+	require.NoError(t, err)
+	assert.True(t, store.IsRevoked("foo"))
+	assert.False(t, store.IsRevoked("bar"))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"time"
+
+	"github.com/roeldev/demo-chatroom/chatevents/event"
+	"github.com/roeldev/demo-chatroom/chatusers"
+)
+
+// SessionTracker keeps track of the open streams of users. A user leaves the
+// chatroom once all of its streams are closed.
+type SessionTracker interface {
+	Connect(uid chatusers.UserID)
+	Disconnect(uid chatusers.UserID)
+}
+
+var _ SessionTracker = (*Manager)(nil)
+
+type session struct {
+	conns int
+	timer *time.Timer
+}
+
+type ManagerOption func(man *Manager)
+
+// WithGracePeriod sets how long a user, of whom all streams are closed, is
+// marked as unresponsive before it leaves the chatroom. The user can resume
+// its session during this period. Users leave immediately when zero.
+func WithGracePeriod(dur time.Duration) ManagerOption {
+	return func(man *Manager) { man.grace = dur }
+}
+
+// Connect registers an opened stream of the user. It resumes the user's
+// session when it is within its grace period.
+func (man *Manager) Connect(uid chatusers.UserID) {
+	man.sessMut.Lock()
+	sess := man.sessions[uid]
+	if sess == nil {
+		sess = new(session)
+		man.sessions[uid] = sess
+	}
+
+	sess.conns++
+	if sess.timer != nil {
+		sess.timer.Stop()
+		sess.timer = nil
+	}
+	first := sess.conns == 1
+	man.sessMut.Unlock()
+
+	if first {
+		man.presence.Connect(uid)
+	}
+}
+
+// Disconnect registers a closed stream of the user. When it was the user's
+// last open stream, the user's [Presence] is notified and the user leaves the
+// chatroom once the grace period expires.
+func (man *Manager) Disconnect(uid chatusers.UserID) {
+	man.sessMut.Lock()
+	sess := man.sessions[uid]
+	if sess == nil || sess.conns <= 0 {
+		man.sessMut.Unlock()
+		return
+	}
+
+	sess.conns--
+	if sess.conns > 0 {
+		man.sessMut.Unlock()
+		return
+	}
+	if man.grace <= 0 {
+		delete(man.sessions, uid)
+		man.sessMut.Unlock()
+		man.Leave(uid, event.Disconnected)
+		return
+	}
+
+	sess.timer = time.AfterFunc(man.grace, func() { man.expire(uid, sess) })
+	man.sessMut.Unlock()
+
+	man.presence.Disconnect(uid)
+}
+
+// ResumeSessions starts the grace period of all users in the store without an
+// open stream, e.g. users which were persisted before a restart. Users who do
+// not reconnect within the grace period leave the chatroom, or leave
+// immediately when there is no grace period.
+func (man *Manager) ResumeSessions() {
+	for uid := range man.users.All() {
+		man.sessMut.Lock()
+		if _, ok := man.sessions[uid]; ok {
+			man.sessMut.Unlock()
+			continue
+		}
+		if man.grace <= 0 {
+			man.sessMut.Unlock()
+			man.Leave(uid, event.Disconnected)
+			continue
+		}
+
+		sess := new(session)
+		sess.timer = time.AfterFunc(man.grace, func() { man.expire(uid, sess) })
+		man.sessions[uid] = sess
+		man.sessMut.Unlock()
+
+		man.presence.Disconnect(uid)
+	}
+}
+
+func (man *Manager) expire(uid chatusers.UserID, sess *session) {
+	man.sessMut.Lock()
+	if man.sessions[uid] != sess || sess.conns > 0 {
+		man.sessMut.Unlock()
+		return
+	}
+	man.sessMut.Unlock()
+
+	man.Leave(uid, event.Disconnected)
+}
+
+// endSession removes the session of the user and stops its grace period.
+func (man *Manager) endSession(uid chatusers.UserID) {
+	man.presence.Forget(uid)
+
+	man.sessMut.Lock()
+	defer man.sessMut.Unlock()
+
+	if sess, ok := man.sessions[uid]; ok {
+		if sess.timer != nil {
+			sess.timer.Stop()
+		}
+		delete(man.sessions, uid)
+	}
+}
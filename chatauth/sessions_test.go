@@ -0,0 +1,111 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatauth
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/roeldev/demo-chatroom/chatevents/event"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPublisher struct {
+	mut    sync.Mutex
+	events []event.Type
+}
+
+func (p *testPublisher) Publish(typ event.Type) {
+	p.mut.Lock()
+	p.events = append(p.events, typ)
+	p.mut.Unlock()
+}
+
+func (p *testPublisher) leaveEvents() int {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	var n int
+	for _, typ := range p.events {
+		if _, ok := typ.(*event.UserLeaveEvent); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func newTestManager(t *testing.T, grace time.Duration) (*Manager, *testPublisher, chatusers.UserID) {
+	t.Helper()
+
+	auth, err := NewJWTAuth(nil, 0)
+	require.NoError(t, err)
+
+	pub := new(testPublisher)
+	users := chatusers.NewUsersStore(1)
+	man := NewManager(auth, users, pub, nil, nil, WithGracePeriod(grace))
+
+	uid, _, err := man.Join(chatusers.User{UserDetails: chatusers.UserDetails{Name: "foo"}}, nil)
+	require.NoError(t, err)
+	return man, pub, uid
+}
+
+func TestManager_Disconnect(t *testing.T) {
+	t.Run("resume", func(t *testing.T) {
+		man, pub, uid := newTestManager(t, time.Hour)
+		man.Connect(uid)
+		man.Connect(uid)
+		man.Disconnect(uid)
+
+		user, err := man.users.Get(uid)
+		require.NoError(t, err)
+		assert.Equal(t, chatusers.Status_Default, user.Status)
+
+		man.Disconnect(uid)
+		user, err = man.users.Get(uid)
+		require.NoError(t, err)
+		assert.Equal(t, chatusers.Status_Unresponsive, user.Status)
+
+		man.Connect(uid)
+		user, err = man.users.Get(uid)
+		require.NoError(t, err)
+		assert.Equal(t, chatusers.Status_Default, user.Status)
+		assert.Equal(t, 0, pub.leaveEvents())
+	})
+	t.Run("expire", func(t *testing.T) {
+		man, pub, uid := newTestManager(t, time.Millisecond)
+		man.Connect(uid)
+		man.Disconnect(uid)
+
+		assert.Eventually(t, func() bool {
+			return pub.leaveEvents() == 1
+		}, time.Second, time.Millisecond)
+		assert.False(t, man.users.Has(uid))
+	})
+	t.Run("no grace period", func(t *testing.T) {
+		man, pub, uid := newTestManager(t, 0)
+		man.Connect(uid)
+		man.Disconnect(uid)
+
+		assert.False(t, man.users.Has(uid))
+		assert.Equal(t, 1, pub.leaveEvents())
+	})
+}
+
+func TestManager_ResumeSessions(t *testing.T) {
+	man, pub, uid := newTestManager(t, time.Millisecond)
+	connected, _, err := man.Join(chatusers.User{UserDetails: chatusers.UserDetails{Name: "bar"}}, nil)
+	require.NoError(t, err)
+	man.Connect(connected)
+
+	man.ResumeSessions()
+	assert.Eventually(t, func() bool {
+		return pub.leaveEvents() == 1
+	}, time.Second, time.Millisecond)
+	assert.False(t, man.users.Has(uid))
+	assert.True(t, man.users.Has(connected))
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatavatars
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrAvatarTooLarge errors.Msg = "avatar image is too large"
+	ErrInvalidImage   errors.Msg = "avatar is not a valid png, jpeg or gif image"
+	ErrInvalidKey     errors.Msg = "invalid avatar key"
+	ErrInvalidBaseURL errors.Msg = "avatars base url must be an absolute http(s) url"
+)
+
+const (
+	// Path is the path the avatars are served from.
+	Path = "/avatars/"
+
+	DefaultMaxSize      = 1 << 20
+	DefaultMaxDimension = 1024
+
+	ext = ".png"
+)
+
+type Config struct {
+	// Dir is the directory uploaded avatars are stored in. Avatar uploads are
+	// disabled when empty.
+	Dir string `env:"AVATARS_DIR"`
+	// PublicURL is the public base url of the server which serves the
+	// avatars, e.g. https://api.example.com. Avatar urls are relative to the
+	// server when empty.
+	PublicURL string `env:"AVATARS_PUBLIC_URL"`
+	// MaxSize is the maximum size in bytes of an uploaded image.
+	MaxSize int `env:"AVATARS_MAX_SIZE" default:"1048576"`
+	// MaxDimension is the maximum width and height of an uploaded image.
+	MaxDimension int `env:"AVATARS_MAX_DIMENSION" default:"1024"`
+}
+
+// NewAvatars creates new [Avatars] based on the config. It returns nil when
+// [Config.Dir] is empty, as avatar uploads are disabled.
+func (conf Config) NewAvatars() (*Avatars, error) {
+	if conf.Dir == "" {
+		return nil, nil
+	}
+	if err := validateBaseURL(conf.PublicURL); err != nil {
+		return nil, err
+	}
+
+	store, err := NewDirBlobStore(conf.Dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewAvatars(store, conf.PublicURL, conf.MaxSize, conf.MaxDimension), nil
+}
+
+// validateBaseURL checks if baseURL is either empty or an absolute http(s)
+// url.
+func validateBaseURL(baseURL string) error {
+	if baseURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return errors.Wrap(err, ErrInvalidBaseURL)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New(ErrInvalidBaseURL)
+	}
+	return nil
+}
+
+// Avatars re-encodes uploaded avatar images to PNG and stores them in a
+// [BlobStore], using the hash of their content as key. Re-encoding strips any
+// metadata from the image.
+type Avatars struct {
+	store   BlobStore
+	baseURL string
+	size    int
+	maxDim  int
+}
+
+// NewAvatars creates new [Avatars] which stores images in store. The urls of
+// uploaded avatars are prefixed with baseURL. Images larger than maxSize bytes
+// or maxDim pixels in width or height are rejected.
+func NewAvatars(store BlobStore, baseURL string, maxSize, maxDim int) *Avatars {
+	if store == nil {
+		panic("chatavatars.NewAvatars: store must not be nil")
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxDim <= 0 {
+		maxDim = DefaultMaxDimension
+	}
+
+	return &Avatars{
+		store:   store,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		size:    maxSize,
+		maxDim:  maxDim,
+	}
+}
+
+// MaxSize returns the maximum size in bytes of an uploaded image.
+func (av *Avatars) MaxSize() int { return av.size }
+
+// Upload decodes the image, re-encodes it as PNG and stores it. It returns the
+// content-hash url the avatar is served from.
+func (av *Avatars) Upload(data []byte) (string, error) {
+	if len(data) > av.size {
+		return "", errors.New(ErrAvatarTooLarge)
+	}
+
+	// check the dimensions before decoding the whole image, to prevent
+	// decompression bombs
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", errors.Wrap(err, ErrInvalidImage)
+	}
+	if conf.Width <= 0 || conf.Height <= 0 || conf.Width > av.maxDim || conf.Height > av.maxDim {
+		return "", errors.New(ErrAvatarTooLarge)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", errors.Wrap(err, ErrInvalidImage)
+	}
+
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err = enc.Encode(&buf, img); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	key := hex.EncodeToString(sum[:]) + ext
+	if err = av.store.Put(key, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return av.baseURL + Path + key, nil
+}
+
+// Handler serves the avatars. Their urls contain the hash of their content,
+// so responses are cached indefinitely.
+func (av *Avatars) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.PathValue("file")
+		data, err := av.store.Get(key)
+		if errors.Is(err, ErrBlobNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		h := w.Header()
+		h.Set("Content-Type", "image/png")
+		h.Set("Cache-Control", "public, max-age=31536000, immutable")
+		h.Set("ETag", `"`+strings.TrimSuffix(key, ext)+`"`)
+		h.Set("X-Content-Type-Options", "nosniff")
+		http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(data))
+	})
+}
+
+// isValidKey indicates if key is a hex encoded SHA-256 hash with a png
+// extension. It prevents keys from escaping the directory of a
+// [DirBlobStore].
+func isValidKey(key string) bool {
+	hash, ok := strings.CutSuffix(key, ext)
+	if !ok || len(hash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
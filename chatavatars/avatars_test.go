@@ -0,0 +1,113 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatavatars
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(t *testing.T, size int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for x := range size {
+		img.Set(x, x, color.RGBA{R: 255, A: 255})
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func TestAvatars_Upload(t *testing.T) {
+	t.Run("re-encode as png", func(t *testing.T) {
+		store := NewMemoryBlobStore()
+		url, err := NewAvatars(store, "", 0, 0).Upload(testImage(t, 16))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(url, Path))
+
+		data, err := store.Get(strings.TrimPrefix(url, Path))
+		require.NoError(t, err)
+		_, err = png.Decode(bytes.NewReader(data))
+		assert.NoError(t, err)
+	})
+	t.Run("base url", func(t *testing.T) {
+		url, err := NewAvatars(NewMemoryBlobStore(), "https://api.example.com/", 0, 0).Upload(testImage(t, 16))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(url, "https://api.example.com"+Path), url)
+	})
+	t.Run("content hash", func(t *testing.T) {
+		av := NewAvatars(NewMemoryBlobStore(), "", 0, 0)
+		url1, err := av.Upload(testImage(t, 16))
+		require.NoError(t, err)
+		url2, err := av.Upload(testImage(t, 16))
+		require.NoError(t, err)
+		url3, err := av.Upload(testImage(t, 8))
+		require.NoError(t, err)
+
+		assert.Equal(t, url1, url2)
+		assert.NotEqual(t, url1, url3)
+	})
+	t.Run("too large", func(t *testing.T) {
+		data := testImage(t, 32)
+		_, err := NewAvatars(NewMemoryBlobStore(), "", len(data)-1, 0).Upload(data)
+		assert.ErrorIs(t, err, ErrAvatarTooLarge)
+
+		_, err = NewAvatars(NewMemoryBlobStore(), "", 0, 31).Upload(data)
+		assert.ErrorIs(t, err, ErrAvatarTooLarge)
+	})
+	t.Run("invalid image", func(t *testing.T) {
+		_, err := NewAvatars(NewMemoryBlobStore(), "", 0, 0).Upload([]byte("<svg></svg>"))
+		assert.ErrorIs(t, err, ErrInvalidImage)
+	})
+}
+
+func TestAvatars_Handler(t *testing.T) {
+	store, err := NewDirBlobStore(t.TempDir())
+	require.NoError(t, err)
+
+	av := NewAvatars(store, "", 0, 0)
+	url, err := av.Upload(testImage(t, 16))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+Path+"{file}", av.Handler())
+
+	t.Run("ok", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Cache-Control"), "immutable")
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+	})
+	t.Run("not modified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("If-None-Match", `"`+strings.TrimSuffix(strings.TrimPrefix(url, Path), ".png")+`"`)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		for _, file := range []string{strings.Repeat("0", 64) + ".png", "..%2Fusers.db"} {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+file, nil))
+			assert.Equal(t, http.StatusNotFound, rec.Code, file)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatavatars
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-pogo/errors"
+)
+
+const ErrBlobNotFound errors.Msg = "blob does not exist"
+
+// BlobStore stores binary objects by key. Keys are content hashes, so an
+// object with a key never changes.
+type BlobStore interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+}
+
+var (
+	_ BlobStore = (*MemoryBlobStore)(nil)
+	_ BlobStore = (*DirBlobStore)(nil)
+)
+
+// MemoryBlobStore is a [BlobStore] which keeps its objects in memory. Its
+// size is unbounded, so it is meant for tests only.
+type MemoryBlobStore struct {
+	mut   sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{blobs: make(map[string][]byte, 8)}
+}
+
+func (ms *MemoryBlobStore) Put(key string, data []byte) error {
+	ms.mut.Lock()
+	ms.blobs[key] = data
+	ms.mut.Unlock()
+	return nil
+}
+
+func (ms *MemoryBlobStore) Get(key string) ([]byte, error) {
+	ms.mut.RLock()
+	defer ms.mut.RUnlock()
+
+	data, ok := ms.blobs[key]
+	if !ok {
+		return nil, errors.New(ErrBlobNotFound)
+	}
+	return data, nil
+}
+
+// DirBlobStore is a [BlobStore] which stores each object as a file in a
+// directory on local disk.
+type DirBlobStore struct {
+	dir string
+}
+
+// NewDirBlobStore creates a new [DirBlobStore] which stores its objects in
+// dir. The directory is created when it does not exist.
+func NewDirBlobStore(dir string) (*DirBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &DirBlobStore{dir: dir}, nil
+}
+
+// Put writes data to a temporary file which is renamed to key, so readers
+// never see a partially written object.
+func (ds *DirBlobStore) Put(key string, data []byte) error {
+	if !isValidKey(key) {
+		return errors.New(ErrInvalidKey)
+	}
+
+	tmp, err := os.CreateTemp(ds.dir, ".tmp-*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return errors.WithStack(err)
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return errors.WithStack(err)
+	}
+	if err = os.Rename(tmp.Name(), filepath.Join(ds.dir, key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (ds *DirBlobStore) Get(key string) ([]byte, error) {
+	if !isValidKey(key) {
+		return nil, errors.New(ErrBlobNotFound)
+	}
+
+	data, err := os.ReadFile(filepath.Join(ds.dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New(ErrBlobNotFound)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return data, nil
+}
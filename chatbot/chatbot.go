@@ -5,6 +5,8 @@
 package chatbot
 
 import (
+	"time"
+
 	"github.com/go-pogo/webapp/logger"
 	chatroom "github.com/roeldev/demo-chatroom"
 )
@@ -15,3 +17,12 @@ type Config struct {
 }
 
 type botClient = chatroom.Client
+
+// renewBefore is the duration before its expiry at which a token is renewed.
+const renewBefore = time.Minute
+
+// renewIn returns the duration until a token which expires at expires should
+// be renewed.
+func renewIn(expires time.Time) time.Duration {
+	return max(time.Until(expires)-renewBefore, 0)
+}
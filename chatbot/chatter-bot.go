@@ -15,7 +15,6 @@ import (
 	chatroom "github.com/roeldev/demo-chatroom"
 	apiv1 "github.com/roeldev/demo-chatroom/api/v1"
 	"github.com/rs/zerolog"
-	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -26,15 +25,20 @@ type ChatterBot struct {
 	rand random
 }
 
-func NewChatterBot(conf chatroom.ClientConfig, log zerolog.Logger) *ChatterBot {
+func NewChatterBot(conf chatroom.ClientConfig, log zerolog.Logger) (*ChatterBot, error) {
+	client, err := chatroom.NewClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ChatterBot{
-		botClient: chatroom.NewClient(conf),
+		botClient: client,
 		log:       log,
 		rand: random{
 			min: 10,
 			max: random{30, 60}.get(),
 		},
-	}
+	}, nil
 }
 
 func (bot *ChatterBot) Login(ctx context.Context, user *apiv1.UserDetails) error {
@@ -56,7 +60,7 @@ func (bot *ChatterBot) Login(ctx context.Context, user *apiv1.UserDetails) error
 
 func (bot *ChatterBot) Chatter(ctx context.Context) error {
 	keepalive := bot.Keepalive(ctx)
-	keepalive.Send(&emptypb.Empty{})
+	keepalive.Send(&apiv1.KeepaliveRequest{})
 	defer func() {
 		_, _ = keepalive.CloseAndReceive()
 	}()
@@ -64,12 +68,22 @@ func (bot *ChatterBot) Chatter(ctx context.Context) error {
 	timer := time.NewTimer(time.Second * time.Duration(rand.IntN(bot.rand.min)))
 	defer timer.Stop()
 
+	renew := time.NewTimer(renewIn(bot.TokenExpires()))
+	defer renew.Stop()
+
 	var nextMsg string
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 
+		case <-renew.C:
+			if err := bot.RenewKeepalive(ctx, keepalive); err != nil {
+				return err
+			}
+			bot.log.Debug().Time("expires", bot.TokenExpires()).Msg("renewed token")
+			renew.Reset(renewIn(bot.TokenExpires()))
+
 		case <-timer.C:
 			if nextMsg == "" {
 				nextMsg = faker.Sentence()
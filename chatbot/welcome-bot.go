@@ -24,8 +24,12 @@ type WelcomeBot struct {
 	log zerolog.Logger
 }
 
-func NewWelcomeBot(conf chatroom.ClientConfig, log zerolog.Logger) *WelcomeBot {
-	client := chatroom.NewClient(conf)
+func NewWelcomeBot(conf chatroom.ClientConfig, log zerolog.Logger) (*WelcomeBot, error) {
+	client, err := chatroom.NewClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
 	return &WelcomeBot{
 		log:       log,
 		botClient: client,
@@ -34,7 +38,7 @@ func NewWelcomeBot(conf chatroom.ClientConfig, log zerolog.Logger) *WelcomeBot {
 			client.BaseURL(),
 			connect.WithInterceptors(client.Interceptor()),
 		),
-	}
+	}, nil
 }
 
 func (bot *WelcomeBot) Login(ctx context.Context, user *apiv1.UserDetails) error {
@@ -85,7 +89,24 @@ func (bot *WelcomeBot) WelcomeUser(ctx context.Context, uid string, name string)
 	return nil
 }
 
+// ListenForEvents listens for events until ctx is canceled. When the stream
+// is closed because the access token expired, the token is renewed and the
+// stream is reopened.
 func (bot *WelcomeBot) ListenForEvents(ctx context.Context) error {
+	for {
+		err := bot.listenForEvents(ctx)
+		if connect.CodeOf(err) != connect.CodeUnauthenticated {
+			return err
+		}
+
+		bot.log.Debug().Err(err).Msg("renew token and reopen stream")
+		if err = bot.RenewToken(ctx); err != nil {
+			return errors.Wrap(err, "failed to renew token")
+		}
+	}
+}
+
+func (bot *WelcomeBot) listenForEvents(ctx context.Context) error {
 	bot.log.Debug().Msg("start listening for events")
 	stream, err := bot.events.EventStream(ctx, connect.NewRequest(&emptypb.Empty{}))
 	if err != nil {
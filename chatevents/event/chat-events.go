@@ -89,6 +89,7 @@ var _ ReceiverEvent = (*ChatEditEvent)(nil)
 type ChatEditEvent struct {
 	event
 	ChatID     ChatID
+	UserID     chatusers.UserID // user who edits the chat
 	ReceiverID chatusers.UserID
 	Text       string
 }
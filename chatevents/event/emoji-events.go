@@ -12,6 +12,7 @@ type EmojiReplyEvent struct {
 	UserDetails chatusers.UserDetails
 	ReplyChatID ChatID
 	Emoji       string
+	Add         bool
 }
 
 type EmojiRemoveEvent struct {
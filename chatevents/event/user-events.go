@@ -16,8 +16,22 @@ type LeaveReason uint8
 const (
 	UserLeave LeaveReason = iota
 	Disconnected
+	Kicked
 )
 
+func (lr LeaveReason) String() string {
+	switch lr {
+	case UserLeave:
+		return "leave"
+	case Disconnected:
+		return "disconnected"
+	case Kicked:
+		return "kicked"
+	default:
+		return "unknown"
+	}
+}
+
 var (
 	_ UserEvent = (*UserJoinEvent)(nil)
 	_ UserEvent = (*UserLeaveEvent)(nil)
@@ -52,6 +66,7 @@ type UserStatusEvent struct {
 	UserDetails chatusers.UserDetails
 	Before      chatusers.Status
 	After       chatusers.Status
+	Custom      chatusers.CustomStatus // custom status after the change
 }
 
 func (e UserJoinEvent) GetUserID() chatusers.UserID   { return e.UserID }
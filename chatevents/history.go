@@ -45,6 +45,11 @@ func (his *HistoryHandler) HandleEvent(e Event) {
 
 	case *event.EmojiReplyEvent:
 		his.UpdateChatEvent(et.ReplyChatID, func(chat *event.ChatEvent) {
+			if !et.Add {
+				chat.RemoveEmojiReply(et.UserID)
+				return
+			}
+
 			chat.AddEmojiReply(event.EmojiReply{
 				Time:        e.Time,
 				UserID:      et.UserID,
@@ -0,0 +1,41 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/roeldev/demo-chatroom/chatevents/event"
+	"github.com/roeldev/demo-chatroom/chatusers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryHandler_HandleEvent(t *testing.T) {
+	t.Run("user update keeps sender details", func(t *testing.T) {
+		his := NewHistoryHandler(nil)
+		uid := uuid.New()
+		before := chatusers.UserDetails{Name: "foo", Initials: "F"}
+		after := chatusers.UserDetails{Name: "bar", Initials: "B"}
+
+		his.HandleEvent(Event{Time: time.Now(), Type: &event.ChatEvent{
+			ChatID:      uuid.New(),
+			UserID:      uid,
+			UserDetails: before,
+			Text:        "hi",
+		}})
+		his.HandleEvent(Event{Time: time.Now(), Type: &event.UserUpdateEvent{
+			UserID: uid,
+			Before: before,
+			After:  after,
+		}})
+
+		all := his.All()
+		assert.Len(t, all, 2)
+		assert.Equal(t, before, all[0].AsUserEvent().GetUserDetails())
+		assert.Equal(t, after, all[1].AsUserEvent().GetUserDetails())
+	})
+}
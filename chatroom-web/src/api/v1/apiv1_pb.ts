@@ -13,7 +13,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file api/v1/apiv1.proto.
  */
 export const file_api_v1_apiv1: GenFile = /*@__PURE__*/
-  fileDesc("ChJhcGkvdjEvYXBpdjEucHJvdG8SBmFwaS52MSIVCgRVVUlEEg0KBXZhbHVlGAEgASgJIhYKBUNvbG9yEg0KBXZhbHVlGAEgASgJImsKC1VzZXJEZXRhaWxzEgwKBG5hbWUYASABKAkSEAoIaW5pdGlhbHMYAiABKAkSHQoGY29sb3IxGAMgASgLMg0uYXBpLnYxLkNvbG9yEh0KBmNvbG9yMhgEIAEoCzINLmFwaS52MS5Db2xvciI/CgtVc2VyTWVudGlvbhIdCgd1c2VyX2lkGAEgASgLMgwuYXBpLnYxLlVVSUQSEQoJdXNlcl9uYW1lGAIgASgJIkoKBkNoYXRJRBIhCgtyZWNlaXZlcl9pZBgBIAEoCzIMLmFwaS52MS5VVUlEEh0KB2NoYXRfaWQYAiABKAsyDC5hcGkudjEuVVVJRCJRCgtKb2luUmVxdWVzdBIhCgR1c2VyGAEgASgLMhMuYXBpLnYxLlVzZXJEZXRhaWxzEh8KBWZsYWdzGAIgASgOMhAuYXBpLnYxLlVzZXJGbGFnIh0KDEpvaW5SZXNwb25zZRINCgV0b2tlbhgBIAEoCSIeCg1SZW5ld1Jlc3BvbnNlEg0KBXRva2VuGAEgASgJIv4BChNBY3RpdmVVc2Vyc1Jlc3BvbnNlEigKBHRpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi8KBXVzZXJzGAIgAygLMiAuYXBpLnYxLkFjdGl2ZVVzZXJzUmVzcG9uc2UuVXNlchqLAQoEVXNlchIYCgJpZBgBIAEoCzIMLmFwaS52MS5VVUlEEiQKB2RldGFpbHMYAiABKAsyEy5hcGkudjEuVXNlckRldGFpbHMSHwoFZmxhZ3MYAyABKA4yEC5hcGkudjEuVXNlckZsYWcSIgoGc3RhdHVzGAQgASgOMhIuYXBpLnYxLlVzZXJTdGF0dXMiPAoUVXBkYXRlRGV0YWlsc1JlcXVlc3QSJAoHZGV0YWlscxgBIAEoCzITLmFwaS52MS5Vc2VyRGV0YWlscyI5ChNVcGRhdGVTdGF0dXNSZXF1ZXN0EiIKBnN0YXR1cxgBIAEoDjISLmFwaS52MS5Vc2VyU3RhdHVzIkoKFUluZGljYXRlVHlwaW5nUmVxdWVzdBIhCgtyZWNlaXZlcl9pZBgBIAEoCzIMLmFwaS52MS5VVUlEEg4KBnR5cGluZxgCIAEoCCK4AQoPU2VuZENoYXRSZXF1ZXN0EigKBHRpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiEKC3JlY2VpdmVyX2lkGAIgASgLMgwuYXBpLnYxLlVVSUQSIwoNcmVwbHlfY2hhdF9pZBgDIAEoCzIMLmFwaS52MS5VVUlEEgwKBHRleHQYBCABKAkSJQoIbWVudGlvbnMYBSADKAsyEy5hcGkudjEuVXNlck1lbnRpb24iZwoPRWRpdENoYXRSZXF1ZXN0EigKBHRpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhwKBGNoYXQYAiABKAsyDi5hcGkudjEuQ2hhdElEEgwKBHRleHQYAyABKAkidwoRRW1vamlSZXBseVJlcXVlc3QSKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASHAoEY2hhdBgCIAEoCzIOLmFwaS52MS5DaGF0SUQSDQoFZW1vamkYAyABKAwSCwoDYWRkGAQgASgIIksKCUV2ZW50VXNlchIYCgJpZBgBIAEoCzIMLmFwaS52MS5VVUlEEiQKB2RldGFpbHMYAiABKAsyEy5hcGkudjEuVXNlckRldGFpbHMiVgoVUHJldmlvdXNFdmVudHNSZXF1ZXN0Ei4KCnVudGlsX3RpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEg0KBWxpbWl0GAIgASgNItICChZQcmV2aW91c0V2ZW50c1Jlc3BvbnNlEj0KB2hpc3RvcnkYASADKAsyLC5hcGkudjEuUHJldmlvdXNFdmVudHNSZXNwb25zZS5QcmV2aW91c0V2ZW50GvgBCg1QcmV2aW91c0V2ZW50EigKBHRpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEioKCXVzZXJfam9pbhgKIAEoCzIVLmFwaS52MS5Vc2VySm9pbkV2ZW50SAASLAoKdXNlcl9sZWF2ZRgLIAEoCzIWLmFwaS52MS5Vc2VyTGVhdmVFdmVudEgAEi4KC3VzZXJfdXBkYXRlGAwgASgLMhcuYXBpLnYxLlVzZXJVcGRhdGVFdmVudEgAEioKCWNoYXRfc2VudBgUIAEoCzIVLmFwaS52MS5DaGF0U2VudEV2ZW50SABCBwoFZXZlbnQiPgoSRXZlbnRTdHJlYW1SZXF1ZXN0Eg8KBXN0YXJ0GAEgASgJSAASDQoDYWNrGAIgASgJSABCCAoGc3RyZWFtIroDChNFdmVudFN0cmVhbVJlc3BvbnNlEigKBHRpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEioKCXVzZXJfam9pbhgKIAEoCzIVLmFwaS52MS5Vc2VySm9pbkV2ZW50SAASLAoKdXNlcl9sZWF2ZRgLIAEoCzIWLmFwaS52MS5Vc2VyTGVhdmVFdmVudEgAEi4KC3VzZXJfdXBkYXRlGAwgASgLMhcuYXBpLnYxLlVzZXJVcGRhdGVFdmVudEgAEi4KC3VzZXJfc3RhdHVzGA0gASgLMhcuYXBpLnYxLlVzZXJTdGF0dXNFdmVudEgAEi4KC3VzZXJfdHlwaW5nGA4gASgLMhcuYXBpLnYxLlVzZXJUeXBpbmdFdmVudEgAEioKCWNoYXRfc2VudBgUIAEoCzIVLmFwaS52MS5DaGF0U2VudEV2ZW50SAASKgoJY2hhdF9lZGl0GBUgASgLMhUuYXBpLnYxLkNoYXRFZGl0RXZlbnRIABIuCgtlbW9qaV9yZXBseRgWIAEoCzIXLmFwaS52MS5FbW9qaVJlcGx5RXZlbnRIAEIHCgVldmVudCJRCg1Vc2VySm9pbkV2ZW50Eh8KBHVzZXIYASABKAsyES5hcGkudjEuRXZlbnRVc2VyEh8KBWZsYWdzGAIgASgOMhAuYXBpLnYxLlVzZXJGbGFnIlYKDlVzZXJMZWF2ZUV2ZW50Eh8KBHVzZXIYASABKAsyES5hcGkudjEuRXZlbnRVc2VyEiMKBnJlYXNvbhgCIAEoDjITLmFwaS52MS5MZWF2ZVJlYXNvbiJXCg9Vc2VyVXBkYXRlRXZlbnQSHwoEdXNlchgBIAEoCzIRLmFwaS52MS5FdmVudFVzZXISIwoGYmVmb3JlGAIgASgLMhMuYXBpLnYxLlVzZXJEZXRhaWxzInoKD1VzZXJTdGF0dXNFdmVudBIfCgR1c2VyGAEgASgLMhEuYXBpLnYxLkV2ZW50VXNlchIiCgZzdGF0dXMYAiABKA4yEi5hcGkudjEuVXNlclN0YXR1cxIiCgZiZWZvcmUYAyABKA4yEi5hcGkudjEuVXNlclN0YXR1cyJlCg9Vc2VyVHlwaW5nRXZlbnQSHwoEdXNlchgBIAEoCzIRLmFwaS52MS5FdmVudFVzZXISIQoLcmVjZWl2ZXJfaWQYAiABKAsyDC5hcGkudjEuVVVJRBIOCgZ0eXBpbmcYAyABKAgi2QMKDUNoYXRTZW50RXZlbnQSHQoHY2hhdF9pZBgBIAEoCzIMLmFwaS52MS5VVUlEEh8KBHVzZXIYAiABKAsyES5hcGkudjEuRXZlbnRVc2VyEiEKC3JlY2VpdmVyX2lkGAMgASgLMgwuYXBpLnYxLlVVSUQSIwoNcmVwbHlfY2hhdF9pZBgEIAEoCzIMLmFwaS52MS5VVUlEEgwKBHRleHQYBSABKAkSLQoJdGV4dF9lZGl0GAYgASgLMhouYXBpLnYxLkNoYXRTZW50RXZlbnQuRWRpdBIlCghtZW50aW9ucxgHIAMoCzITLmFwaS52MS5Vc2VyTWVudGlvbhIwCgZlbW9qaXMYCCADKAsyIC5hcGkudjEuQ2hhdFNlbnRFdmVudC5FbW9qaVJlcGx5GkIKBEVkaXQSKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEAoIb3JpZ2luYWwYAiABKAkaZgoKRW1vamlSZXBseRIoCgR0aW1lGAEgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIfCgR1c2VyGAIgASgLMhEuYXBpLnYxLkV2ZW50VXNlchINCgVlbW9qaRgDIAEoDCJcCg1DaGF0RWRpdEV2ZW50Eh8KBHVzZXIYASABKAsyES5hcGkudjEuRXZlbnRVc2VyEhwKBGNoYXQYAiABKAsyDi5hcGkudjEuQ2hhdElEEgwKBHRleHQYAyABKAkibAoPRW1vamlSZXBseUV2ZW50Eh8KBHVzZXIYASABKAsyES5hcGkudjEuRXZlbnRVc2VyEhwKBGNoYXQYAiABKAsyDi5hcGkudjEuQ2hhdElEEg0KBWVtb2ppGAMgASgMEgsKA2FkZBgEIAEoCCpJCghVc2VyRmxhZxISCg5VU0VSX0ZMQUdfTk9ORRAAEhQKEFVTRVJfRkxBR19JU19CT1QQARITCg9VU0VSX0ZMQUdfTk9fRE0QAipvCgpVc2VyU3RhdHVzEhcKE1VTRVJfU1RBVFVTX0RFRkFVTFQQABIcChhVU0VSX1NUQVRVU19VTlJFU1BPTlNJVkUQARIUChBVU0VSX1NUQVRVU19CVVNZEAISFAoQVVNFUl9TVEFUVVNfQVdBWRADKkoKC0xlYXZlUmVhc29uEhwKGExFQVZFX1JFQVNPTl9VU0VSX0FDVElPThAAEh0KGUxFQVZFX1JFQVNPTl9ESVNDT05ORUNURUQQATL4AQoLQXV0aFNlcnZpY2USMwoESm9pbhITLmFwaS52MS5Kb2luUmVxdWVzdBoULmFwaS52MS5Kb2luUmVzcG9uc2UiABI/CglLZWVwYWxpdmUSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiACgBEjgKBVJlbmV3EhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GhUuYXBpLnYxLlJlbmV3UmVzcG9uc2UiABI5CgVMZWF2ZRIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAMlcKD1JlZ2lzdHJ5U2VydmljZRJECgtBY3RpdmVVc2VycxIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRobLmFwaS52MS5BY3RpdmVVc2Vyc1Jlc3BvbnNlIgAyqQMKC1VzZXJTZXJ2aWNlEkcKDVVwZGF0ZURldGFpbHMSHC5hcGkudjEuVXBkYXRlRGV0YWlsc1JlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABJFCgxVcGRhdGVTdGF0dXMSGy5hcGkudjEuVXBkYXRlU3RhdHVzUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEkkKDkluZGljYXRlVHlwaW5nEh0uYXBpLnYxLkluZGljYXRlVHlwaW5nUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEj0KCFNlbmRDaGF0EhcuYXBpLnYxLlNlbmRDaGF0UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEj0KCEVkaXRDaGF0EhcuYXBpLnYxLkVkaXRDaGF0UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEkEKCkVtb2ppUmVwbHkSGS5hcGkudjEuRW1vamlSZXBseVJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiADKqAQoNRXZlbnRzU2VydmljZRJRCg5QcmV2aW91c0V2ZW50cxIdLmFwaS52MS5QcmV2aW91c0V2ZW50c1JlcXVlc3QaHi5hcGkudjEuUHJldmlvdXNFdmVudHNSZXNwb25zZSIAEkYKC0V2ZW50U3RyZWFtEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GhsuYXBpLnYxLkV2ZW50U3RyZWFtUmVzcG9uc2UiADABQjRaLWdpdGh1Yi5jb20vcm9lbGRldi9kZW1vLWNoYXRyb29tL2FwaS92MTthcGl2MZIDAggCYghlZGl0aW9uc3DoBw", [file_google_protobuf_any, file_google_protobuf_empty, file_google_protobuf_duration, file_google_protobuf_timestamp]);
+  fileDesc("ChJhcGkvdjEvYXBpdjEucHJvdG8SBmFwaS52MSIVCgRVVUlEEg0KBXZhbHVlGAEgASgJIhYKBUNvbG9yEg0KBXZhbHVlGAEgASgJIp4BCgtVc2VyRGV0YWlscxIMCgRuYW1lGAEgASgJEhAKCGluaXRpYWxzGAIgASgJEh0KBmNvbG9yMRgDIAEoCzINLmFwaS52MS5Db2xvchIdCgZjb2xvcjIYBCABKAsyDS5hcGkudjEuQ29sb3ISDwoHcGljdHVyZRgFIAEoCRIgCgdwcm9maWxlGAYgASgLMg8uYXBpLnYxLlByb2ZpbGUiOwoHUHJvZmlsZRIQCghwcm9ub3VucxgBIAEoCRILCgNiaW8YAiABKAkSEQoJdGltZV96b25lGAMgASgJIlsKDEN1c3RvbVN0YXR1cxIMCgR0ZXh0GAEgASgJEg0KBWVtb2ppGAIgASgJEi4KCmV4cGlyZXNfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIj8KC1VzZXJNZW50aW9uEh0KB3VzZXJfaWQYASABKAsyDC5hcGkudjEuVVVJRBIRCgl1c2VyX25hbWUYAiABKAkiSgoGQ2hhdElEEiEKC3JlY2VpdmVyX2lkGAEgASgLMgwuYXBpLnYxLlVVSUQSHQoHY2hhdF9pZBgCIAEoCzIMLmFwaS52MS5VVUlEIpEBCgtKb2luUmVxdWVzdBIhCgR1c2VyGAEgASgLMhMuYXBpLnYxLlVzZXJEZXRhaWxzEh8KBWZsYWdzGAIgAygOMhAuYXBpLnYxLlVzZXJGbGFnEhAKCGlkX3Rva2VuGAMgASgJEiwKCWNoYWxsZW5nZRgEIAEoCzIZLmFwaS52MS5DaGFsbGVuZ2VTb2x1dGlvbiJeCglDaGFsbGVuZ2USDQoFbm9uY2UYASABKAkSEgoKZGlmZmljdWx0eRgCIAEoDRIuCgpleHBpcmVzX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCIzChFDaGFsbGVuZ2VTb2x1dGlvbhINCgVub25jZRgBIAEoCRIPCgdjb3VudGVyGAIgASgEIjMKDkpvaW5Cb3RSZXF1ZXN0EiEKBHVzZXIYASABKAsyEy5hcGkudjEuVXNlckRldGFpbHMidAoPUmVnaXN0ZXJSZXF1ZXN0EiEKBHVzZXIYASABKAsyEy5hcGkudjEuVXNlckRldGFpbHMSEAoIcGFzc3dvcmQYAiABKAkSLAoJY2hhbGxlbmdlGAMgASgLMhkuYXBpLnYxLkNoYWxsZW5nZVNvbHV0aW9uIlEKDExvZ2luUmVxdWVzdBIMCgRuYW1lGAEgASgJEhAKCHBhc3N3b3JkGAIgASgJEiEKBHVzZXIYAyABKAsyEy5hcGkudjEuVXNlckRldGFpbHMiZAoMSm9pblJlc3BvbnNlEg0KBXRva2VuGAEgASgJEhUKDXJlZnJlc2hfdG9rZW4YAiABKAkSLgoKZXhwaXJlc19hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiIQoQS2VlcGFsaXZlUmVxdWVzdBINCgV0b2tlbhgBIAEoCSIlCgxSZW5ld1JlcXVlc3QSFQoNcmVmcmVzaF90b2tlbhgBIAEoCSJlCg1SZW5ld1Jlc3BvbnNlEg0KBXRva2VuGAEgASgJEhUKDXJlZnJlc2hfdG9rZW4YAiABKAkSLgoKZXhwaXJlc19hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiqwMKE0FjdGl2ZVVzZXJzUmVzcG9uc2USKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoFdXNlcnMYAiADKAsyIC5hcGkudjEuQWN0aXZlVXNlcnNSZXNwb25zZS5Vc2VyEjIKBnR5cGluZxgDIAMoCzIiLmFwaS52MS5BY3RpdmVVc2Vyc1Jlc3BvbnNlLlR5cGluZxq4AQoEVXNlchIYCgJpZBgBIAEoCzIMLmFwaS52MS5VVUlEEiQKB2RldGFpbHMYAiABKAsyEy5hcGkudjEuVXNlckRldGFpbHMSHwoFZmxhZ3MYAyADKA4yEC5hcGkudjEuVXNlckZsYWcSIgoGc3RhdHVzGAQgASgOMhIuYXBpLnYxLlVzZXJTdGF0dXMSKwoNY3VzdG9tX3N0YXR1cxgFIAEoCzIULmFwaS52MS5DdXN0b21TdGF0dXMaSgoGVHlwaW5nEh0KB3VzZXJfaWQYASABKAsyDC5hcGkudjEuVVVJRBIhCgtyZWNlaXZlcl9pZBgCIAEoCzIMLmFwaS52MS5VVUlEIpABChBMaXN0VXNlcnNSZXF1ZXN0Eg4KBnByZWZpeBgBIAEoCRIfCgVmbGFncxgCIAMoDjIQLmFwaS52MS5Vc2VyRmxhZxIkCghzdGF0dXNlcxgDIAMoDjISLmFwaS52MS5Vc2VyU3RhdHVzEhEKCXBhZ2Vfc2l6ZRgEIAEoDRISCgpwYWdlX3Rva2VuGAUgASgJIl0KEUxpc3RVc2Vyc1Jlc3BvbnNlEi8KBXVzZXJzGAEgAygLMiAuYXBpLnYxLkFjdGl2ZVVzZXJzUmVzcG9uc2UuVXNlchIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiLwoOR2V0VXNlclJlcXVlc3QSHQoHdXNlcl9pZBgBIAEoCzIMLmFwaS52MS5VVUlEIjwKFFVwZGF0ZURldGFpbHNSZXF1ZXN0EiQKB2RldGFpbHMYASABKAsyEy5hcGkudjEuVXNlckRldGFpbHMiZgoTVXBkYXRlU3RhdHVzUmVxdWVzdBIiCgZzdGF0dXMYASABKA4yEi5hcGkudjEuVXNlclN0YXR1cxIrCg1jdXN0b21fc3RhdHVzGAIgASgLMhQuYXBpLnYxLkN1c3RvbVN0YXR1cyJKChVJbmRpY2F0ZVR5cGluZ1JlcXVlc3QSIQoLcmVjZWl2ZXJfaWQYASABKAsyDC5hcGkudjEuVVVJRBIOCgZ0eXBpbmcYAiABKAgiuAEKD1NlbmRDaGF0UmVxdWVzdBIoCgR0aW1lGAEgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIhCgtyZWNlaXZlcl9pZBgCIAEoCzIMLmFwaS52MS5VVUlEEiMKDXJlcGx5X2NoYXRfaWQYAyABKAsyDC5hcGkudjEuVVVJRBIMCgR0ZXh0GAQgASgJEiUKCG1lbnRpb25zGAUgAygLMhMuYXBpLnYxLlVzZXJNZW50aW9uImcKD0VkaXRDaGF0UmVxdWVzdBIoCgR0aW1lGAEgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIcCgRjaGF0GAIgASgLMg4uYXBpLnYxLkNoYXRJRBIMCgR0ZXh0GAMgASgJIncKEUVtb2ppUmVwbHlSZXF1ZXN0EigKBHRpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhwKBGNoYXQYAiABKAsyDi5hcGkudjEuQ2hhdElEEg0KBWVtb2ppGAMgASgMEgsKA2FkZBgEIAEoCCIxChBCbG9ja1VzZXJSZXF1ZXN0Eh0KB3VzZXJfaWQYASABKAsyDC5hcGkudjEuVVVJRCIzChJVbmJsb2NrVXNlclJlcXVlc3QSHQoHdXNlcl9pZBgBIAEoCzIMLmFwaS52MS5VVUlEIjUKE0xpc3RCbG9ja2VkUmVzcG9uc2USHgoIdXNlcl9pZHMYASADKAsyDC5hcGkudjEuVVVJRCIkChNVcGxvYWRBdmF0YXJSZXF1ZXN0Eg0KBWltYWdlGAEgASgMIicKFFVwbG9hZEF2YXRhclJlc3BvbnNlEg8KB3BpY3R1cmUYASABKAkiSwoJRXZlbnRVc2VyEhgKAmlkGAEgASgLMgwuYXBpLnYxLlVVSUQSJAoHZGV0YWlscxgCIAEoCzITLmFwaS52MS5Vc2VyRGV0YWlscyJWChVQcmV2aW91c0V2ZW50c1JlcXVlc3QSLgoKdW50aWxfdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDQoFbGltaXQYAiABKA0i0gIKFlByZXZpb3VzRXZlbnRzUmVzcG9uc2USPQoHaGlzdG9yeRgBIAMoCzIsLmFwaS52MS5QcmV2aW91c0V2ZW50c1Jlc3BvbnNlLlByZXZpb3VzRXZlbnQa+AEKDVByZXZpb3VzRXZlbnQSKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASKgoJdXNlcl9qb2luGAogASgLMhUuYXBpLnYxLlVzZXJKb2luRXZlbnRIABIsCgp1c2VyX2xlYXZlGAsgASgLMhYuYXBpLnYxLlVzZXJMZWF2ZUV2ZW50SAASLgoLdXNlcl91cGRhdGUYDCABKAsyFy5hcGkudjEuVXNlclVwZGF0ZUV2ZW50SAASKgoJY2hhdF9zZW50GBQgASgLMhUuYXBpLnYxLkNoYXRTZW50RXZlbnRIAEIHCgVldmVudCI+ChJFdmVudFN0cmVhbVJlcXVlc3QSDwoFc3RhcnQYASABKAlIABINCgNhY2sYAiABKAlIAEIICgZzdHJlYW0iugMKE0V2ZW50U3RyZWFtUmVzcG9uc2USKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASKgoJdXNlcl9qb2luGAogASgLMhUuYXBpLnYxLlVzZXJKb2luRXZlbnRIABIsCgp1c2VyX2xlYXZlGAsgASgLMhYuYXBpLnYxLlVzZXJMZWF2ZUV2ZW50SAASLgoLdXNlcl91cGRhdGUYDCABKAsyFy5hcGkudjEuVXNlclVwZGF0ZUV2ZW50SAASLgoLdXNlcl9zdGF0dXMYDSABKAsyFy5hcGkudjEuVXNlclN0YXR1c0V2ZW50SAASLgoLdXNlcl90eXBpbmcYDiABKAsyFy5hcGkudjEuVXNlclR5cGluZ0V2ZW50SAASKgoJY2hhdF9zZW50GBQgASgLMhUuYXBpLnYxLkNoYXRTZW50RXZlbnRIABIqCgljaGF0X2VkaXQYFSABKAsyFS5hcGkudjEuQ2hhdEVkaXRFdmVudEgAEi4KC2Vtb2ppX3JlcGx5GBYgASgLMhcuYXBpLnYxLkVtb2ppUmVwbHlFdmVudEgAQgcKBWV2ZW50IlEKDVVzZXJKb2luRXZlbnQSHwoEdXNlchgBIAEoCzIRLmFwaS52MS5FdmVudFVzZXISHwoFZmxhZ3MYAiADKA4yEC5hcGkudjEuVXNlckZsYWciVgoOVXNlckxlYXZlRXZlbnQSHwoEdXNlchgBIAEoCzIRLmFwaS52MS5FdmVudFVzZXISIwoGcmVhc29uGAIgASgOMhMuYXBpLnYxLkxlYXZlUmVhc29uIlcKD1VzZXJVcGRhdGVFdmVudBIfCgR1c2VyGAEgASgLMhEuYXBpLnYxLkV2ZW50VXNlchIjCgZiZWZvcmUYAiABKAsyEy5hcGkudjEuVXNlckRldGFpbHMipwEKD1VzZXJTdGF0dXNFdmVudBIfCgR1c2VyGAEgASgLMhEuYXBpLnYxLkV2ZW50VXNlchIiCgZzdGF0dXMYAiABKA4yEi5hcGkudjEuVXNlclN0YXR1cxIiCgZiZWZvcmUYAyABKA4yEi5hcGkudjEuVXNlclN0YXR1cxIrCg1jdXN0b21fc3RhdHVzGAQgASgLMhQuYXBpLnYxLkN1c3RvbVN0YXR1cyJlCg9Vc2VyVHlwaW5nRXZlbnQSHwoEdXNlchgBIAEoCzIRLmFwaS52MS5FdmVudFVzZXISIQoLcmVjZWl2ZXJfaWQYAiABKAsyDC5hcGkudjEuVVVJRBIOCgZ0eXBpbmcYAyABKAgi2QMKDUNoYXRTZW50RXZlbnQSHQoHY2hhdF9pZBgBIAEoCzIMLmFwaS52MS5VVUlEEh8KBHVzZXIYAiABKAsyES5hcGkudjEuRXZlbnRVc2VyEiEKC3JlY2VpdmVyX2lkGAMgASgLMgwuYXBpLnYxLlVVSUQSIwoNcmVwbHlfY2hhdF9pZBgEIAEoCzIMLmFwaS52MS5VVUlEEgwKBHRleHQYBSABKAkSLQoJdGV4dF9lZGl0GAYgASgLMhouYXBpLnYxLkNoYXRTZW50RXZlbnQuRWRpdBIlCghtZW50aW9ucxgHIAMoCzITLmFwaS52MS5Vc2VyTWVudGlvbhIwCgZlbW9qaXMYCCADKAsyIC5hcGkudjEuQ2hhdFNlbnRFdmVudC5FbW9qaVJlcGx5GkIKBEVkaXQSKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEAoIb3JpZ2luYWwYAiABKAkaZgoKRW1vamlSZXBseRIoCgR0aW1lGAEgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIfCgR1c2VyGAIgASgLMhEuYXBpLnYxLkV2ZW50VXNlchINCgVlbW9qaRgDIAEoDCJcCg1DaGF0RWRpdEV2ZW50Eh8KBHVzZXIYASABKAsyES5hcGkudjEuRXZlbnRVc2VyEhwKBGNoYXQYAiABKAsyDi5hcGkudjEuQ2hhdElEEgwKBHRleHQYAyABKAkibAoPRW1vamlSZXBseUV2ZW50Eh8KBHVzZXIYASABKAsyES5hcGkudjEuRXZlbnRVc2VyEhwKBGNoYXQYAiABKAsyDi5hcGkudjEuQ2hhdElEEg0KBWVtb2ppGAMgASgMEgsKA2FkZBgEIAEoCCIiChBSZXZva2VLZXlSZXF1ZXN0Eg4KBmtleV9pZBgBIAEoCSIwCg9LaWNrVXNlclJlcXVlc3QSHQoHdXNlcl9pZBgBIAEoCzIMLmFwaS52MS5VVUlEIlMKElNldFVzZXJSb2xlUmVxdWVzdBIdCgd1c2VyX2lkGAEgASgLMgwuYXBpLnYxLlVVSUQSHgoEcm9sZRgCIAEoDjIQLmFwaS52MS5Vc2VyUm9sZSIzCh1TZXRDaGFsbGVuZ2VEaWZmaWN1bHR5UmVxdWVzdBISCgpkaWZmaWN1bHR5GAEgASgNKkkKCFVzZXJGbGFnEhIKDlVTRVJfRkxBR19OT05FEAASFAoQVVNFUl9GTEFHX0lTX0JPVBABEhMKD1VTRVJfRkxBR19OT19ETRACKmIKCFVzZXJSb2xlEhIKDlVTRVJfUk9MRV9OT05FEAASFAoQVVNFUl9ST0xFX01FTUJFUhABEhcKE1VTRVJfUk9MRV9NT0RFUkFUT1IQAhITCg9VU0VSX1JPTEVfQURNSU4QAypvCgpVc2VyU3RhdHVzEhcKE1VTRVJfU1RBVFVTX0RFRkFVTFQQABIcChhVU0VSX1NUQVRVU19VTlJFU1BPTlNJVkUQARIUChBVU0VSX1NUQVRVU19CVVNZEAISFAoQVVNFUl9TVEFUVVNfQVdBWRADKmMKC0xlYXZlUmVhc29uEhwKGExFQVZFX1JFQVNPTl9VU0VSX0FDVElPThAAEh0KGUxFQVZFX1JFQVNPTl9ESVNDT05ORUNURUQQARIXChNMRUFWRV9SRUFTT05fS0lDS0VEEAIy5AMKC0F1dGhTZXJ2aWNlEjsKDEdldENoYWxsZW5nZRIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRoRLmFwaS52MS5DaGFsbGVuZ2UiABIzCgRKb2luEhMuYXBpLnYxLkpvaW5SZXF1ZXN0GhQuYXBpLnYxLkpvaW5SZXNwb25zZSIAEjkKB0pvaW5Cb3QSFi5hcGkudjEuSm9pbkJvdFJlcXVlc3QaFC5hcGkudjEuSm9pblJlc3BvbnNlIgASOwoIUmVnaXN0ZXISFy5hcGkudjEuUmVnaXN0ZXJSZXF1ZXN0GhQuYXBpLnYxLkpvaW5SZXNwb25zZSIAEjUKBUxvZ2luEhQuYXBpLnYxLkxvZ2luUmVxdWVzdBoULmFwaS52MS5Kb2luUmVzcG9uc2UiABJBCglLZWVwYWxpdmUSGC5hcGkudjEuS2VlcGFsaXZlUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAKAESNgoFUmVuZXcSFC5hcGkudjEuUmVuZXdSZXF1ZXN0GhUuYXBpLnYxLlJlbmV3UmVzcG9uc2UiABI5CgVMZWF2ZRIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAMuIBCg9SZWdpc3RyeVNlcnZpY2USRAoLQWN0aXZlVXNlcnMSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaGy5hcGkudjEuQWN0aXZlVXNlcnNSZXNwb25zZSIAEkIKCUxpc3RVc2VycxIYLmFwaS52MS5MaXN0VXNlcnNSZXF1ZXN0GhkuYXBpLnYxLkxpc3RVc2Vyc1Jlc3BvbnNlIgASRQoHR2V0VXNlchIWLmFwaS52MS5HZXRVc2VyUmVxdWVzdBogLmFwaS52MS5BY3RpdmVVc2Vyc1Jlc3BvbnNlLlVzZXIiADLCBQoLVXNlclNlcnZpY2USRwoNVXBkYXRlRGV0YWlscxIcLmFwaS52MS5VcGRhdGVEZXRhaWxzUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEkUKDFVwZGF0ZVN0YXR1cxIbLmFwaS52MS5VcGRhdGVTdGF0dXNSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASSQoOSW5kaWNhdGVUeXBpbmcSHS5hcGkudjEuSW5kaWNhdGVUeXBpbmdSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASPQoIU2VuZENoYXQSFy5hcGkudjEuU2VuZENoYXRSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASPQoIRWRpdENoYXQSFy5hcGkudjEuRWRpdENoYXRSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASQQoKRW1vamlSZXBseRIZLmFwaS52MS5FbW9qaVJlcGx5UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEj8KCUJsb2NrVXNlchIYLmFwaS52MS5CbG9ja1VzZXJSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASQwoLVW5ibG9ja1VzZXISGi5hcGkudjEuVW5ibG9ja1VzZXJSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5IgASRAoLTGlzdEJsb2NrZWQSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaGy5hcGkudjEuTGlzdEJsb2NrZWRSZXNwb25zZSIAEksKDFVwbG9hZEF2YXRhchIbLmFwaS52MS5VcGxvYWRBdmF0YXJSZXF1ZXN0GhwuYXBpLnYxLlVwbG9hZEF2YXRhclJlc3BvbnNlIgAyqgEKDUV2ZW50c1NlcnZpY2USUQoOUHJldmlvdXNFdmVudHMSHS5hcGkudjEuUHJldmlvdXNFdmVudHNSZXF1ZXN0Gh4uYXBpLnYxLlByZXZpb3VzRXZlbnRzUmVzcG9uc2UiABJGCgtFdmVudFN0cmVhbRIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRobLmFwaS52MS5FdmVudFN0cmVhbVJlc3BvbnNlIgAwATLuAgoMQWRtaW5TZXJ2aWNlEj4KClJvdGF0ZUtleXMSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiABI/CglSZXZva2VLZXkSGC5hcGkudjEuUmV2b2tlS2V5UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEj0KCEtpY2tVc2VyEhcuYXBpLnYxLktpY2tVc2VyUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAEkMKC1NldFVzZXJSb2xlEhouYXBpLnYxLlNldFVzZXJSb2xlUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eSIAElkKFlNldENoYWxsZW5nZURpZmZpY3VsdHkSJS5hcGkudjEuU2V0Q2hhbGxlbmdlRGlmZmljdWx0eVJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkiAEI0Wi1naXRodWIuY29tL3JvZWxkZXYvZGVtby1jaGF0cm9vbS9hcGkvdjE7YXBpdjGSAwIIAmIIZWRpdGlvbnNw6Ac", [file_google_protobuf_any, file_google_protobuf_empty, file_google_protobuf_duration, file_google_protobuf_timestamp]);
 
 /**
  * //////////////////////////////////////////////////////////////////////////////
@@ -81,6 +81,18 @@ export type UserDetails = Message<"api.v1.UserDetails"> & {
    * @generated from field: api.v1.Color color2 = 4;
    */
   color2?: Color;
+
+  /**
+   * avatar picture url, read-only, see UploadAvatar
+   *
+   * @generated from field: string picture = 5;
+   */
+  picture: string;
+
+  /**
+   * @generated from field: api.v1.Profile profile = 6;
+   */
+  profile?: Profile;
 };
 
 /**
@@ -90,6 +102,77 @@ export type UserDetails = Message<"api.v1.UserDetails"> & {
 export const UserDetailsSchema: GenMessage<UserDetails> = /*@__PURE__*/
   messageDesc(file_api_v1_apiv1, 2);
 
+/**
+ * Optional details a user shares about itself.
+ *
+ * @generated from message api.v1.Profile
+ */
+export type Profile = Message<"api.v1.Profile"> & {
+  /**
+   * max 32 chars
+   *
+   * @generated from field: string pronouns = 1;
+   */
+  pronouns: string;
+
+  /**
+   * max 160 chars
+   *
+   * @generated from field: string bio = 2;
+   */
+  bio: string;
+
+  /**
+   * IANA time zone name, e.g. Europe/Amsterdam
+   *
+   * @generated from field: string time_zone = 3;
+   */
+  timeZone: string;
+};
+
+/**
+ * Describes the message api.v1.Profile.
+ * Use `create(ProfileSchema)` to create a new message.
+ */
+export const ProfileSchema: GenMessage<Profile> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 3);
+
+/**
+ * CustomStatus is a free-text status with an optional emoji, which is cleared
+ * automatically when it expires.
+ *
+ * @generated from message api.v1.CustomStatus
+ */
+export type CustomStatus = Message<"api.v1.CustomStatus"> & {
+  /**
+   * max 100 chars
+   *
+   * @generated from field: string text = 1;
+   */
+  text: string;
+
+  /**
+   * a single emoji
+   *
+   * @generated from field: string emoji = 2;
+   */
+  emoji: string;
+
+  /**
+   * empty = never expires
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 3;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message api.v1.CustomStatus.
+ * Use `create(CustomStatusSchema)` to create a new message.
+ */
+export const CustomStatusSchema: GenMessage<CustomStatus> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 4);
+
 /**
  * @generated from message api.v1.UserMention
  */
@@ -110,7 +193,7 @@ export type UserMention = Message<"api.v1.UserMention"> & {
  * Use `create(UserMentionSchema)` to create a new message.
  */
 export const UserMentionSchema: GenMessage<UserMention> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 3);
+  messageDesc(file_api_v1_apiv1, 5);
 
 /**
  * @generated from message api.v1.ChatID
@@ -134,7 +217,7 @@ export type ChatID = Message<"api.v1.ChatID"> & {
  * Use `create(ChatIDSchema)` to create a new message.
  */
 export const ChatIDSchema: GenMessage<ChatID> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 4);
+  messageDesc(file_api_v1_apiv1, 6);
 
 /**
  * @generated from message api.v1.JoinRequest
@@ -146,9 +229,24 @@ export type JoinRequest = Message<"api.v1.JoinRequest"> & {
   user?: UserDetails;
 
   /**
-   * @generated from field: api.v1.UserFlag flags = 2;
+   * @generated from field: repeated api.v1.UserFlag flags = 2;
+   */
+  flags: UserFlag[];
+
+  /**
+   * OIDC ID token of the user. The user's name and picture are taken from
+   * the token when provided.
+   *
+   * @generated from field: string id_token = 3;
+   */
+  idToken: string;
+
+  /**
+   * Solution to the challenge from GetChallenge.
+   *
+   * @generated from field: api.v1.ChallengeSolution challenge = 4;
    */
-  flags: UserFlag;
+  challenge?: ChallengeSolution;
 };
 
 /**
@@ -156,16 +254,159 @@ export type JoinRequest = Message<"api.v1.JoinRequest"> & {
  * Use `create(JoinRequestSchema)` to create a new message.
  */
 export const JoinRequestSchema: GenMessage<JoinRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 5);
+  messageDesc(file_api_v1_apiv1, 7);
+
+/**
+ * @generated from message api.v1.Challenge
+ */
+export type Challenge = Message<"api.v1.Challenge"> & {
+  /**
+   * Server signed nonce, which can be used once.
+   *
+   * @generated from field: string nonce = 1;
+   */
+  nonce: string;
+
+  /**
+   * Number of leading zero bits the SHA-256 hash of "<nonce>:<counter>"
+   * must have. No solution is required when zero.
+   *
+   * @generated from field: uint32 difficulty = 2;
+   */
+  difficulty: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 3;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message api.v1.Challenge.
+ * Use `create(ChallengeSchema)` to create a new message.
+ */
+export const ChallengeSchema: GenMessage<Challenge> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 8);
+
+/**
+ * @generated from message api.v1.ChallengeSolution
+ */
+export type ChallengeSolution = Message<"api.v1.ChallengeSolution"> & {
+  /**
+   * @generated from field: string nonce = 1;
+   */
+  nonce: string;
+
+  /**
+   * @generated from field: uint64 counter = 2;
+   */
+  counter: bigint;
+};
+
+/**
+ * Describes the message api.v1.ChallengeSolution.
+ * Use `create(ChallengeSolutionSchema)` to create a new message.
+ */
+export const ChallengeSolutionSchema: GenMessage<ChallengeSolution> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 9);
+
+/**
+ * @generated from message api.v1.JoinBotRequest
+ */
+export type JoinBotRequest = Message<"api.v1.JoinBotRequest"> & {
+  /**
+   * @generated from field: api.v1.UserDetails user = 1;
+   */
+  user?: UserDetails;
+};
+
+/**
+ * Describes the message api.v1.JoinBotRequest.
+ * Use `create(JoinBotRequestSchema)` to create a new message.
+ */
+export const JoinBotRequestSchema: GenMessage<JoinBotRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 10);
+
+/**
+ * @generated from message api.v1.RegisterRequest
+ */
+export type RegisterRequest = Message<"api.v1.RegisterRequest"> & {
+  /**
+   * @generated from field: api.v1.UserDetails user = 1;
+   */
+  user?: UserDetails;
+
+  /**
+   * @generated from field: string password = 2;
+   */
+  password: string;
+
+  /**
+   * Solution to the challenge from GetChallenge.
+   *
+   * @generated from field: api.v1.ChallengeSolution challenge = 3;
+   */
+  challenge?: ChallengeSolution;
+};
+
+/**
+ * Describes the message api.v1.RegisterRequest.
+ * Use `create(RegisterRequestSchema)` to create a new message.
+ */
+export const RegisterRequestSchema: GenMessage<RegisterRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 11);
+
+/**
+ * @generated from message api.v1.LoginRequest
+ */
+export type LoginRequest = Message<"api.v1.LoginRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string password = 2;
+   */
+  password: string;
+
+  /**
+   * Optional details to use, the name is always the account's name.
+   *
+   * @generated from field: api.v1.UserDetails user = 3;
+   */
+  user?: UserDetails;
+};
+
+/**
+ * Describes the message api.v1.LoginRequest.
+ * Use `create(LoginRequestSchema)` to create a new message.
+ */
+export const LoginRequestSchema: GenMessage<LoginRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 12);
 
 /**
  * @generated from message api.v1.JoinResponse
  */
 export type JoinResponse = Message<"api.v1.JoinResponse"> & {
   /**
+   * Short-lived access token.
+   *
    * @generated from field: string token = 1;
    */
   token: string;
+
+  /**
+   * Long-lived, single-use token which is used to renew the access token.
+   *
+   * @generated from field: string refresh_token = 2;
+   */
+  refreshToken: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 3;
+   */
+  expiresAt?: Timestamp;
 };
 
 /**
@@ -173,7 +414,44 @@ export type JoinResponse = Message<"api.v1.JoinResponse"> & {
  * Use `create(JoinResponseSchema)` to create a new message.
  */
 export const JoinResponseSchema: GenMessage<JoinResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 6);
+  messageDesc(file_api_v1_apiv1, 13);
+
+/**
+ * @generated from message api.v1.KeepaliveRequest
+ */
+export type KeepaliveRequest = Message<"api.v1.KeepaliveRequest"> & {
+  /**
+   * Renewed access token, which extends the lifetime of the user's open
+   * streams.
+   *
+   * @generated from field: string token = 1;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message api.v1.KeepaliveRequest.
+ * Use `create(KeepaliveRequestSchema)` to create a new message.
+ */
+export const KeepaliveRequestSchema: GenMessage<KeepaliveRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 14);
+
+/**
+ * @generated from message api.v1.RenewRequest
+ */
+export type RenewRequest = Message<"api.v1.RenewRequest"> & {
+  /**
+   * @generated from field: string refresh_token = 1;
+   */
+  refreshToken: string;
+};
+
+/**
+ * Describes the message api.v1.RenewRequest.
+ * Use `create(RenewRequestSchema)` to create a new message.
+ */
+export const RenewRequestSchema: GenMessage<RenewRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 15);
 
 /**
  * @generated from message api.v1.RenewResponse
@@ -183,6 +461,16 @@ export type RenewResponse = Message<"api.v1.RenewResponse"> & {
    * @generated from field: string token = 1;
    */
   token: string;
+
+  /**
+   * @generated from field: string refresh_token = 2;
+   */
+  refreshToken: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 3;
+   */
+  expiresAt?: Timestamp;
 };
 
 /**
@@ -190,7 +478,7 @@ export type RenewResponse = Message<"api.v1.RenewResponse"> & {
  * Use `create(RenewResponseSchema)` to create a new message.
  */
 export const RenewResponseSchema: GenMessage<RenewResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 7);
+  messageDesc(file_api_v1_apiv1, 16);
 
 /**
  * @generated from message api.v1.ActiveUsersResponse
@@ -205,6 +493,13 @@ export type ActiveUsersResponse = Message<"api.v1.ActiveUsersResponse"> & {
    * @generated from field: repeated api.v1.ActiveUsersResponse.User users = 2;
    */
   users: ActiveUsersResponse_User[];
+
+  /**
+   * Users who are typing in the global chatroom or to the requesting user.
+   *
+   * @generated from field: repeated api.v1.ActiveUsersResponse.Typing typing = 3;
+   */
+  typing: ActiveUsersResponse_Typing[];
 };
 
 /**
@@ -212,7 +507,7 @@ export type ActiveUsersResponse = Message<"api.v1.ActiveUsersResponse"> & {
  * Use `create(ActiveUsersResponseSchema)` to create a new message.
  */
 export const ActiveUsersResponseSchema: GenMessage<ActiveUsersResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 8);
+  messageDesc(file_api_v1_apiv1, 17);
 
 /**
  * @generated from message api.v1.ActiveUsersResponse.User
@@ -229,22 +524,139 @@ export type ActiveUsersResponse_User = Message<"api.v1.ActiveUsersResponse.User"
   details?: UserDetails;
 
   /**
-   * @generated from field: api.v1.UserFlag flags = 3;
+   * @generated from field: repeated api.v1.UserFlag flags = 3;
    */
-  flags: UserFlag;
+  flags: UserFlag[];
 
   /**
    * @generated from field: api.v1.UserStatus status = 4;
    */
-  status: UserStatus;
+  status: UserStatus;
+
+  /**
+   * @generated from field: api.v1.CustomStatus custom_status = 5;
+   */
+  customStatus?: CustomStatus;
+};
+
+/**
+ * Describes the message api.v1.ActiveUsersResponse.User.
+ * Use `create(ActiveUsersResponse_UserSchema)` to create a new message.
+ */
+export const ActiveUsersResponse_UserSchema: GenMessage<ActiveUsersResponse_User> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 17, 0);
+
+/**
+ * @generated from message api.v1.ActiveUsersResponse.Typing
+ */
+export type ActiveUsersResponse_Typing = Message<"api.v1.ActiveUsersResponse.Typing"> & {
+  /**
+   * @generated from field: api.v1.UUID user_id = 1;
+   */
+  userId?: UUID;
+
+  /**
+   * empty = global chatroom
+   *
+   * @generated from field: api.v1.UUID receiver_id = 2;
+   */
+  receiverId?: UUID;
+};
+
+/**
+ * Describes the message api.v1.ActiveUsersResponse.Typing.
+ * Use `create(ActiveUsersResponse_TypingSchema)` to create a new message.
+ */
+export const ActiveUsersResponse_TypingSchema: GenMessage<ActiveUsersResponse_Typing> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 17, 1);
+
+/**
+ * @generated from message api.v1.ListUsersRequest
+ */
+export type ListUsersRequest = Message<"api.v1.ListUsersRequest"> & {
+  /**
+   * name prefix, ignores case and look-alike characters
+   *
+   * @generated from field: string prefix = 1;
+   */
+  prefix: string;
+
+  /**
+   * users must have all flags
+   *
+   * @generated from field: repeated api.v1.UserFlag flags = 2;
+   */
+  flags: UserFlag[];
+
+  /**
+   * users must have any of the statuses, empty = any
+   *
+   * @generated from field: repeated api.v1.UserStatus statuses = 3;
+   */
+  statuses: UserStatus[];
+
+  /**
+   * default 50, max 200
+   *
+   * @generated from field: uint32 page_size = 4;
+   */
+  pageSize: number;
+
+  /**
+   * next_page_token of the previous response
+   *
+   * @generated from field: string page_token = 5;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message api.v1.ListUsersRequest.
+ * Use `create(ListUsersRequestSchema)` to create a new message.
+ */
+export const ListUsersRequestSchema: GenMessage<ListUsersRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 18);
+
+/**
+ * @generated from message api.v1.ListUsersResponse
+ */
+export type ListUsersResponse = Message<"api.v1.ListUsersResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.ActiveUsersResponse.User users = 1;
+   */
+  users: ActiveUsersResponse_User[];
+
+  /**
+   * empty = last page
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message api.v1.ListUsersResponse.
+ * Use `create(ListUsersResponseSchema)` to create a new message.
+ */
+export const ListUsersResponseSchema: GenMessage<ListUsersResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 19);
+
+/**
+ * @generated from message api.v1.GetUserRequest
+ */
+export type GetUserRequest = Message<"api.v1.GetUserRequest"> & {
+  /**
+   * @generated from field: api.v1.UUID user_id = 1;
+   */
+  userId?: UUID;
 };
 
 /**
- * Describes the message api.v1.ActiveUsersResponse.User.
- * Use `create(ActiveUsersResponse_UserSchema)` to create a new message.
+ * Describes the message api.v1.GetUserRequest.
+ * Use `create(GetUserRequestSchema)` to create a new message.
  */
-export const ActiveUsersResponse_UserSchema: GenMessage<ActiveUsersResponse_User> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 8, 0);
+export const GetUserRequestSchema: GenMessage<GetUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 20);
 
 /**
  * @generated from message api.v1.UpdateDetailsRequest
@@ -261,7 +673,7 @@ export type UpdateDetailsRequest = Message<"api.v1.UpdateDetailsRequest"> & {
  * Use `create(UpdateDetailsRequestSchema)` to create a new message.
  */
 export const UpdateDetailsRequestSchema: GenMessage<UpdateDetailsRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 9);
+  messageDesc(file_api_v1_apiv1, 21);
 
 /**
  * @generated from message api.v1.UpdateStatusRequest
@@ -271,6 +683,13 @@ export type UpdateStatusRequest = Message<"api.v1.UpdateStatusRequest"> & {
    * @generated from field: api.v1.UserStatus status = 1;
    */
   status: UserStatus;
+
+  /**
+   * empty = clear custom status
+   *
+   * @generated from field: api.v1.CustomStatus custom_status = 2;
+   */
+  customStatus?: CustomStatus;
 };
 
 /**
@@ -278,7 +697,7 @@ export type UpdateStatusRequest = Message<"api.v1.UpdateStatusRequest"> & {
  * Use `create(UpdateStatusRequestSchema)` to create a new message.
  */
 export const UpdateStatusRequestSchema: GenMessage<UpdateStatusRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 10);
+  messageDesc(file_api_v1_apiv1, 22);
 
 /**
  * @generated from message api.v1.IndicateTypingRequest
@@ -302,7 +721,7 @@ export type IndicateTypingRequest = Message<"api.v1.IndicateTypingRequest"> & {
  * Use `create(IndicateTypingRequestSchema)` to create a new message.
  */
 export const IndicateTypingRequestSchema: GenMessage<IndicateTypingRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 11);
+  messageDesc(file_api_v1_apiv1, 23);
 
 /**
  * @generated from message api.v1.SendChatRequest
@@ -343,7 +762,7 @@ export type SendChatRequest = Message<"api.v1.SendChatRequest"> & {
  * Use `create(SendChatRequestSchema)` to create a new message.
  */
 export const SendChatRequestSchema: GenMessage<SendChatRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 12);
+  messageDesc(file_api_v1_apiv1, 24);
 
 /**
  * @generated from message api.v1.EditChatRequest
@@ -370,7 +789,7 @@ export type EditChatRequest = Message<"api.v1.EditChatRequest"> & {
  * Use `create(EditChatRequestSchema)` to create a new message.
  */
 export const EditChatRequestSchema: GenMessage<EditChatRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 13);
+  messageDesc(file_api_v1_apiv1, 25);
 
 /**
  * @generated from message api.v1.EmojiReplyRequest
@@ -404,7 +823,101 @@ export type EmojiReplyRequest = Message<"api.v1.EmojiReplyRequest"> & {
  * Use `create(EmojiReplyRequestSchema)` to create a new message.
  */
 export const EmojiReplyRequestSchema: GenMessage<EmojiReplyRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 14);
+  messageDesc(file_api_v1_apiv1, 26);
+
+/**
+ * Chat, typing and emoji events of blocked users are no longer received, and
+ * blocked users cannot send direct messages to the user who blocked them.
+ *
+ * @generated from message api.v1.BlockUserRequest
+ */
+export type BlockUserRequest = Message<"api.v1.BlockUserRequest"> & {
+  /**
+   * @generated from field: api.v1.UUID user_id = 1;
+   */
+  userId?: UUID;
+};
+
+/**
+ * Describes the message api.v1.BlockUserRequest.
+ * Use `create(BlockUserRequestSchema)` to create a new message.
+ */
+export const BlockUserRequestSchema: GenMessage<BlockUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 27);
+
+/**
+ * @generated from message api.v1.UnblockUserRequest
+ */
+export type UnblockUserRequest = Message<"api.v1.UnblockUserRequest"> & {
+  /**
+   * @generated from field: api.v1.UUID user_id = 1;
+   */
+  userId?: UUID;
+};
+
+/**
+ * Describes the message api.v1.UnblockUserRequest.
+ * Use `create(UnblockUserRequestSchema)` to create a new message.
+ */
+export const UnblockUserRequestSchema: GenMessage<UnblockUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 28);
+
+/**
+ * @generated from message api.v1.ListBlockedResponse
+ */
+export type ListBlockedResponse = Message<"api.v1.ListBlockedResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.UUID user_ids = 1;
+   */
+  userIds: UUID[];
+};
+
+/**
+ * Describes the message api.v1.ListBlockedResponse.
+ * Use `create(ListBlockedResponseSchema)` to create a new message.
+ */
+export const ListBlockedResponseSchema: GenMessage<ListBlockedResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 29);
+
+/**
+ * The image is re-encoded as png and becomes the user's picture. It should be
+ * a png, jpeg or gif image of at most 1 MiB and 1024x1024 pixels, unless
+ * configured otherwise.
+ *
+ * @generated from message api.v1.UploadAvatarRequest
+ */
+export type UploadAvatarRequest = Message<"api.v1.UploadAvatarRequest"> & {
+  /**
+   * @generated from field: bytes image = 1;
+   */
+  image: Uint8Array;
+};
+
+/**
+ * Describes the message api.v1.UploadAvatarRequest.
+ * Use `create(UploadAvatarRequestSchema)` to create a new message.
+ */
+export const UploadAvatarRequestSchema: GenMessage<UploadAvatarRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 30);
+
+/**
+ * @generated from message api.v1.UploadAvatarResponse
+ */
+export type UploadAvatarResponse = Message<"api.v1.UploadAvatarResponse"> & {
+  /**
+   * url of the avatar picture
+   *
+   * @generated from field: string picture = 1;
+   */
+  picture: string;
+};
+
+/**
+ * Describes the message api.v1.UploadAvatarResponse.
+ * Use `create(UploadAvatarResponseSchema)` to create a new message.
+ */
+export const UploadAvatarResponseSchema: GenMessage<UploadAvatarResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 31);
 
 /**
  * @generated from message api.v1.EventUser
@@ -426,7 +939,7 @@ export type EventUser = Message<"api.v1.EventUser"> & {
  * Use `create(EventUserSchema)` to create a new message.
  */
 export const EventUserSchema: GenMessage<EventUser> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 15);
+  messageDesc(file_api_v1_apiv1, 32);
 
 /**
  * @generated from message api.v1.PreviousEventsRequest
@@ -448,7 +961,7 @@ export type PreviousEventsRequest = Message<"api.v1.PreviousEventsRequest"> & {
  * Use `create(PreviousEventsRequestSchema)` to create a new message.
  */
 export const PreviousEventsRequestSchema: GenMessage<PreviousEventsRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 16);
+  messageDesc(file_api_v1_apiv1, 33);
 
 /**
  * @generated from message api.v1.PreviousEventsResponse
@@ -465,7 +978,7 @@ export type PreviousEventsResponse = Message<"api.v1.PreviousEventsResponse"> &
  * Use `create(PreviousEventsResponseSchema)` to create a new message.
  */
 export const PreviousEventsResponseSchema: GenMessage<PreviousEventsResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 17);
+  messageDesc(file_api_v1_apiv1, 34);
 
 /**
  * @generated from message api.v1.PreviousEventsResponse.PreviousEvent
@@ -511,7 +1024,7 @@ export type PreviousEventsResponse_PreviousEvent = Message<"api.v1.PreviousEvent
  * Use `create(PreviousEventsResponse_PreviousEventSchema)` to create a new message.
  */
 export const PreviousEventsResponse_PreviousEventSchema: GenMessage<PreviousEventsResponse_PreviousEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 17, 0);
+  messageDesc(file_api_v1_apiv1, 34, 0);
 
 /**
  * @generated from message api.v1.EventStreamRequest
@@ -540,7 +1053,7 @@ export type EventStreamRequest = Message<"api.v1.EventStreamRequest"> & {
  * Use `create(EventStreamRequestSchema)` to create a new message.
  */
 export const EventStreamRequestSchema: GenMessage<EventStreamRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 18);
+  messageDesc(file_api_v1_apiv1, 35);
 
 /**
  * @generated from message api.v1.EventStreamResponse
@@ -610,7 +1123,7 @@ export type EventStreamResponse = Message<"api.v1.EventStreamResponse"> & {
  * Use `create(EventStreamResponseSchema)` to create a new message.
  */
 export const EventStreamResponseSchema: GenMessage<EventStreamResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 19);
+  messageDesc(file_api_v1_apiv1, 36);
 
 /**
  * User joins
@@ -624,9 +1137,9 @@ export type UserJoinEvent = Message<"api.v1.UserJoinEvent"> & {
   user?: EventUser;
 
   /**
-   * @generated from field: api.v1.UserFlag flags = 2;
+   * @generated from field: repeated api.v1.UserFlag flags = 2;
    */
-  flags: UserFlag;
+  flags: UserFlag[];
 };
 
 /**
@@ -634,7 +1147,7 @@ export type UserJoinEvent = Message<"api.v1.UserJoinEvent"> & {
  * Use `create(UserJoinEventSchema)` to create a new message.
  */
 export const UserJoinEventSchema: GenMessage<UserJoinEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 20);
+  messageDesc(file_api_v1_apiv1, 37);
 
 /**
  * User leaves
@@ -658,7 +1171,7 @@ export type UserLeaveEvent = Message<"api.v1.UserLeaveEvent"> & {
  * Use `create(UserLeaveEventSchema)` to create a new message.
  */
 export const UserLeaveEventSchema: GenMessage<UserLeaveEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 21);
+  messageDesc(file_api_v1_apiv1, 38);
 
 /**
  * User update's its details
@@ -682,7 +1195,7 @@ export type UserUpdateEvent = Message<"api.v1.UserUpdateEvent"> & {
  * Use `create(UserUpdateEventSchema)` to create a new message.
  */
 export const UserUpdateEventSchema: GenMessage<UserUpdateEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 22);
+  messageDesc(file_api_v1_apiv1, 39);
 
 /**
  * User status is changed
@@ -704,6 +1217,11 @@ export type UserStatusEvent = Message<"api.v1.UserStatusEvent"> & {
    * @generated from field: api.v1.UserStatus before = 3;
    */
   before: UserStatus;
+
+  /**
+   * @generated from field: api.v1.CustomStatus custom_status = 4;
+   */
+  customStatus?: CustomStatus;
 };
 
 /**
@@ -711,7 +1229,7 @@ export type UserStatusEvent = Message<"api.v1.UserStatusEvent"> & {
  * Use `create(UserStatusEventSchema)` to create a new message.
  */
 export const UserStatusEventSchema: GenMessage<UserStatusEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 23);
+  messageDesc(file_api_v1_apiv1, 40);
 
 /**
  * User is typing a message
@@ -742,7 +1260,7 @@ export type UserTypingEvent = Message<"api.v1.UserTypingEvent"> & {
  * Use `create(UserTypingEventSchema)` to create a new message.
  */
 export const UserTypingEventSchema: GenMessage<UserTypingEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 24);
+  messageDesc(file_api_v1_apiv1, 41);
 
 /**
  * User sends chat message
@@ -798,7 +1316,7 @@ export type ChatSentEvent = Message<"api.v1.ChatSentEvent"> & {
  * Use `create(ChatSentEventSchema)` to create a new message.
  */
 export const ChatSentEventSchema: GenMessage<ChatSentEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 25);
+  messageDesc(file_api_v1_apiv1, 42);
 
 /**
  * @generated from message api.v1.ChatSentEvent.Edit
@@ -820,7 +1338,7 @@ export type ChatSentEvent_Edit = Message<"api.v1.ChatSentEvent.Edit"> & {
  * Use `create(ChatSentEvent_EditSchema)` to create a new message.
  */
 export const ChatSentEvent_EditSchema: GenMessage<ChatSentEvent_Edit> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 25, 0);
+  messageDesc(file_api_v1_apiv1, 42, 0);
 
 /**
  * @generated from message api.v1.ChatSentEvent.EmojiReply
@@ -847,7 +1365,7 @@ export type ChatSentEvent_EmojiReply = Message<"api.v1.ChatSentEvent.EmojiReply"
  * Use `create(ChatSentEvent_EmojiReplySchema)` to create a new message.
  */
 export const ChatSentEvent_EmojiReplySchema: GenMessage<ChatSentEvent_EmojiReply> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 25, 1);
+  messageDesc(file_api_v1_apiv1, 42, 1);
 
 /**
  * @generated from message api.v1.ChatEditEvent
@@ -874,7 +1392,7 @@ export type ChatEditEvent = Message<"api.v1.ChatEditEvent"> & {
  * Use `create(ChatEditEventSchema)` to create a new message.
  */
 export const ChatEditEventSchema: GenMessage<ChatEditEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 26);
+  messageDesc(file_api_v1_apiv1, 43);
 
 /**
  * @generated from message api.v1.EmojiReplyEvent
@@ -908,7 +1426,84 @@ export type EmojiReplyEvent = Message<"api.v1.EmojiReplyEvent"> & {
  * Use `create(EmojiReplyEventSchema)` to create a new message.
  */
 export const EmojiReplyEventSchema: GenMessage<EmojiReplyEvent> = /*@__PURE__*/
-  messageDesc(file_api_v1_apiv1, 27);
+  messageDesc(file_api_v1_apiv1, 44);
+
+/**
+ * @generated from message api.v1.RevokeKeyRequest
+ */
+export type RevokeKeyRequest = Message<"api.v1.RevokeKeyRequest"> & {
+  /**
+   * kid header of the tokens signed with the key
+   *
+   * @generated from field: string key_id = 1;
+   */
+  keyId: string;
+};
+
+/**
+ * Describes the message api.v1.RevokeKeyRequest.
+ * Use `create(RevokeKeyRequestSchema)` to create a new message.
+ */
+export const RevokeKeyRequestSchema: GenMessage<RevokeKeyRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 45);
+
+/**
+ * @generated from message api.v1.KickUserRequest
+ */
+export type KickUserRequest = Message<"api.v1.KickUserRequest"> & {
+  /**
+   * @generated from field: api.v1.UUID user_id = 1;
+   */
+  userId?: UUID;
+};
+
+/**
+ * Describes the message api.v1.KickUserRequest.
+ * Use `create(KickUserRequestSchema)` to create a new message.
+ */
+export const KickUserRequestSchema: GenMessage<KickUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 46);
+
+/**
+ * @generated from message api.v1.SetUserRoleRequest
+ */
+export type SetUserRoleRequest = Message<"api.v1.SetUserRoleRequest"> & {
+  /**
+   * @generated from field: api.v1.UUID user_id = 1;
+   */
+  userId?: UUID;
+
+  /**
+   * @generated from field: api.v1.UserRole role = 2;
+   */
+  role: UserRole;
+};
+
+/**
+ * Describes the message api.v1.SetUserRoleRequest.
+ * Use `create(SetUserRoleRequestSchema)` to create a new message.
+ */
+export const SetUserRoleRequestSchema: GenMessage<SetUserRoleRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 47);
+
+/**
+ * @generated from message api.v1.SetChallengeDifficultyRequest
+ */
+export type SetChallengeDifficultyRequest = Message<"api.v1.SetChallengeDifficultyRequest"> & {
+  /**
+   * number of leading zero bits, at most 32
+   *
+   * @generated from field: uint32 difficulty = 1;
+   */
+  difficulty: number;
+};
+
+/**
+ * Describes the message api.v1.SetChallengeDifficultyRequest.
+ * Use `create(SetChallengeDifficultyRequestSchema)` to create a new message.
+ */
+export const SetChallengeDifficultyRequestSchema: GenMessage<SetChallengeDifficultyRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_apiv1, 48);
 
 /**
  * @generated from enum api.v1.UserFlag
@@ -940,6 +1535,39 @@ export enum UserFlag {
 export const UserFlagSchema: GenEnum<UserFlag> = /*@__PURE__*/
   enumDesc(file_api_v1_apiv1, 0);
 
+/**
+ * Roles which can be assigned to users, see AdminService.SetUserRole.
+ *
+ * @generated from enum api.v1.UserRole
+ */
+export enum UserRole {
+  /**
+   * @generated from enum value: USER_ROLE_NONE = 0;
+   */
+  NONE = 0,
+
+  /**
+   * @generated from enum value: USER_ROLE_MEMBER = 1;
+   */
+  MEMBER = 1,
+
+  /**
+   * @generated from enum value: USER_ROLE_MODERATOR = 2;
+   */
+  MODERATOR = 2,
+
+  /**
+   * @generated from enum value: USER_ROLE_ADMIN = 3;
+   */
+  ADMIN = 3,
+}
+
+/**
+ * Describes the enum api.v1.UserRole.
+ */
+export const UserRoleSchema: GenEnum<UserRole> = /*@__PURE__*/
+  enumDesc(file_api_v1_apiv1, 1);
+
 /**
  * @generated from enum api.v1.UserStatus
  */
@@ -969,7 +1597,7 @@ export enum UserStatus {
  * Describes the enum api.v1.UserStatus.
  */
 export const UserStatusSchema: GenEnum<UserStatus> = /*@__PURE__*/
-  enumDesc(file_api_v1_apiv1, 1);
+  enumDesc(file_api_v1_apiv1, 2);
 
 /**
  * @generated from enum api.v1.LeaveReason
@@ -984,18 +1612,34 @@ export enum LeaveReason {
    * @generated from enum value: LEAVE_REASON_DISCONNECTED = 1;
    */
   DISCONNECTED = 1,
+
+  /**
+   * @generated from enum value: LEAVE_REASON_KICKED = 2;
+   */
+  KICKED = 2,
 }
 
 /**
  * Describes the enum api.v1.LeaveReason.
  */
 export const LeaveReasonSchema: GenEnum<LeaveReason> = /*@__PURE__*/
-  enumDesc(file_api_v1_apiv1, 2);
+  enumDesc(file_api_v1_apiv1, 3);
 
 /**
  * @generated from service api.v1.AuthService
  */
 export const AuthService: GenService<{
+  /**
+   * GetChallenge returns a proof-of-work challenge which must be solved to
+   * join, when its difficulty is greater than zero.
+   *
+   * @generated from rpc api.v1.AuthService.GetChallenge
+   */
+  getChallenge: {
+    methodKind: "unary";
+    input: typeof EmptySchema;
+    output: typeof ChallengeSchema;
+  },
   /**
    * @generated from rpc api.v1.AuthService.Join
    */
@@ -1005,11 +1649,47 @@ export const AuthService: GenService<{
     output: typeof JoinResponseSchema;
   },
   /**
+   * JoinBot joins a pre-registered bot. The bot authenticates with its API
+   * key in the x-api-key header, or with a client certificate.
+   *
+   * @generated from rpc api.v1.AuthService.JoinBot
+   */
+  joinBot: {
+    methodKind: "unary";
+    input: typeof JoinBotRequestSchema;
+    output: typeof JoinResponseSchema;
+  },
+  /**
+   * Register creates an account with a reserved name and joins the
+   * chatroom with it.
+   *
+   * @generated from rpc api.v1.AuthService.Register
+   */
+  register: {
+    methodKind: "unary";
+    input: typeof RegisterRequestSchema;
+    output: typeof JoinResponseSchema;
+  },
+  /**
+   * Login joins the chatroom using a registered account.
+   *
+   * @generated from rpc api.v1.AuthService.Login
+   */
+  login: {
+    methodKind: "unary";
+    input: typeof LoginRequestSchema;
+    output: typeof JoinResponseSchema;
+  },
+  /**
+   * Keepalive keeps the user's session alive for as long as the stream is
+   * open. The stream is closed with an unauthenticated error when the access
+   * token expires, unless the client sends a renewed access token.
+   *
    * @generated from rpc api.v1.AuthService.Keepalive
    */
   keepalive: {
     methodKind: "client_streaming";
-    input: typeof EmptySchema;
+    input: typeof KeepaliveRequestSchema;
     output: typeof EmptySchema;
   },
   /**
@@ -1017,7 +1697,7 @@ export const AuthService: GenService<{
    */
   renew: {
     methodKind: "unary";
-    input: typeof EmptySchema;
+    input: typeof RenewRequestSchema;
     output: typeof RenewResponseSchema;
   },
   /**
@@ -1043,6 +1723,24 @@ export const RegistryService: GenService<{
     input: typeof EmptySchema;
     output: typeof ActiveUsersResponseSchema;
   },
+  /**
+   * ListUsers returns a page of users, ordered by name.
+   *
+   * @generated from rpc api.v1.RegistryService.ListUsers
+   */
+  listUsers: {
+    methodKind: "unary";
+    input: typeof ListUsersRequestSchema;
+    output: typeof ListUsersResponseSchema;
+  },
+  /**
+   * @generated from rpc api.v1.RegistryService.GetUser
+   */
+  getUser: {
+    methodKind: "unary";
+    input: typeof GetUserRequestSchema;
+    output: typeof ActiveUsersResponse_UserSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_api_v1_apiv1, 1);
 
@@ -1098,6 +1796,38 @@ export const UserService: GenService<{
     input: typeof EmojiReplyRequestSchema;
     output: typeof EmptySchema;
   },
+  /**
+   * @generated from rpc api.v1.UserService.BlockUser
+   */
+  blockUser: {
+    methodKind: "unary";
+    input: typeof BlockUserRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc api.v1.UserService.UnblockUser
+   */
+  unblockUser: {
+    methodKind: "unary";
+    input: typeof UnblockUserRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc api.v1.UserService.ListBlocked
+   */
+  listBlocked: {
+    methodKind: "unary";
+    input: typeof EmptySchema;
+    output: typeof ListBlockedResponseSchema;
+  },
+  /**
+   * @generated from rpc api.v1.UserService.UploadAvatar
+   */
+  uploadAvatar: {
+    methodKind: "unary";
+    input: typeof UploadAvatarRequestSchema;
+    output: typeof UploadAvatarResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_api_v1_apiv1, 2);
 
@@ -1124,3 +1854,68 @@ export const EventsService: GenService<{
 }> = /*@__PURE__*/
   serviceDesc(file_api_v1_apiv1, 3);
 
+/**
+ * AdminService can only be used by users with the admin role.
+ *
+ * @generated from service api.v1.AdminService
+ */
+export const AdminService: GenService<{
+  /**
+   * RotateKeys generates a new signing key. Tokens signed with the previous
+   * key remain valid until they expire.
+   *
+   * @generated from rpc api.v1.AdminService.RotateKeys
+   */
+  rotateKeys: {
+    methodKind: "unary";
+    input: typeof EmptySchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * RevokeKey removes a (compromised) signing key. All tokens signed with
+   * it are rejected immediately.
+   *
+   * @generated from rpc api.v1.AdminService.RevokeKey
+   */
+  revokeKey: {
+    methodKind: "unary";
+    input: typeof RevokeKeyRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * KickUser removes the user from the chatroom and revokes all of its
+   * tokens.
+   *
+   * @generated from rpc api.v1.AdminService.KickUser
+   */
+  kickUser: {
+    methodKind: "unary";
+    input: typeof KickUserRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * SetUserRole changes the role of the user. Its access tokens are revoked,
+   * so the new role applies once its client renews its access token.
+   *
+   * @generated from rpc api.v1.AdminService.SetUserRole
+   */
+  setUserRole: {
+    methodKind: "unary";
+    input: typeof SetUserRoleRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * SetChallengeDifficulty changes the difficulty of the proof-of-work
+   * challenge which must be solved to join or register. Challenges are no
+   * longer required when zero.
+   *
+   * @generated from rpc api.v1.AdminService.SetChallengeDifficulty
+   */
+  setChallengeDifficulty: {
+    methodKind: "unary";
+    input: typeof SetChallengeDifficultyRequestSchema;
+    output: typeof EmptySchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_api_v1_apiv1, 4);
+
@@ -15,6 +15,16 @@ const authClaims: Writable<AuthClaims> = writable({});
 // authToken store which is accessible throughout the application's life.
 const authToken = writable("");
 
+// refreshToken is used once to renew the auth token before it expires.
+const refreshToken = writable("");
+
+// AuthTokens are received when joining the chatroom or renewing the auth
+// token.
+export type AuthTokens = {
+    token: string;
+    refreshToken: string;
+}
+
 export function isAuthenticated(): boolean {
     return get(authToken) != "";
 }
@@ -24,10 +34,26 @@ export function setAuthToken(token: string) {
     authClaims.set(jwtDecode(token))
 }
 
+export function setAuthTokens(tokens: AuthTokens) {
+    setAuthToken(tokens.token);
+    refreshToken.set(tokens.refreshToken);
+}
+
 export function getAuthToken(): string {
     return get(authToken);
 }
 
+export function getRefreshToken(): string {
+    return get(refreshToken);
+}
+
+// isAuthTokenExpired indicates if the auth token has expired and must be
+// renewed before it can be used again.
+export function isAuthTokenExpired(): boolean {
+    const exp = getAuthClaims().exp;
+    return !!exp && exp * 1000 <= Date.now();
+}
+
 export function getAuthClaims(): AuthClaims {
     return get(authClaims);
 }
@@ -2,14 +2,29 @@
 // Use of this source code is governed by a GPL-style
 // license that can be found in the LICENSE file.
 
-import { type AuthClaims, isAuthenticated, getAuthToken, getAuthClaims, activeUserID } from "./auth.ts";
+import {
+    type AuthClaims,
+    type AuthTokens,
+    isAuthenticated,
+    isAuthTokenExpired,
+    setAuthTokens,
+    getAuthToken,
+    getAuthClaims,
+    activeUserID
+} from "./auth.ts";
 import { tokenAuthorizer } from "./interceptor.ts";
+import { renewAuthToken, scheduleRenewal } from "./renew.ts";
 
 export {
     type AuthClaims,
+    type AuthTokens,
     isAuthenticated,
+    isAuthTokenExpired,
+    setAuthTokens,
     getAuthToken,
     getAuthClaims,
     activeUserID,
-    tokenAuthorizer
+    tokenAuthorizer,
+    renewAuthToken,
+    scheduleRenewal
 }
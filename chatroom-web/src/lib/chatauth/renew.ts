@@ -0,0 +1,49 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+import { type Transport, createClient } from "@connectrpc/connect";
+import { API } from "$lib/chatapi";
+import { getAuthClaims, getRefreshToken, setAuthTokens } from "./auth.ts";
+
+// renewBefore is the number of milliseconds before its expiry at which the
+// auth token is renewed.
+const renewBefore = 60_000;
+
+let renewTimer: ReturnType<typeof setTimeout> | undefined;
+let renewing: Promise<void> | undefined;
+
+// renewAuthToken renews the auth token using the refresh token and schedules
+// the next renewal. Concurrent calls share the same renewal, as a refresh
+// token can only be used once.
+export function renewAuthToken(transport: Transport): Promise<void> {
+    if (!renewing) {
+        renewing = createClient(API.AuthService, transport)
+            .renew({ refreshToken: getRefreshToken() })
+            .then((response) => {
+                setAuthTokens(response);
+                scheduleRenewal(transport);
+            })
+            .finally(() => {
+                renewing = undefined;
+            });
+    }
+    return renewing;
+}
+
+// scheduleRenewal renews the auth token shortly before it expires.
+export function scheduleRenewal(transport: Transport) {
+    clearTimeout(renewTimer);
+
+    const exp = getAuthClaims().exp;
+    if (!exp) {
+        return;
+    }
+
+    const delay = Math.max(exp * 1000 - Date.now() - renewBefore, 0);
+    renewTimer = setTimeout(() => {
+        renewAuthToken(transport).catch((err) => {
+            console.log("err renewing auth token", err)
+        });
+    }, delay);
+}
@@ -3,8 +3,9 @@
 // license that can be found in the LICENSE file.
 
 import { getContext } from "svelte";
-import { type Client, type Transport, createClient } from "@connectrpc/connect";
+import { type Client, type Transport, Code, ConnectError, createClient } from "@connectrpc/connect";
 import { API } from "$lib/chatapi";
+import { getAuthToken, isAuthTokenExpired, renewAuthToken } from "$lib/chatauth";
 
 export interface EventStreamHandler {
     handleEventStream(res: API.EventStreamResponse): boolean
@@ -16,14 +17,13 @@ export interface PreviousEventHandler {
 
 // EventStreamer
 export class EventStreamer {
+    private readonly transport: Transport;
     private readonly client: Client<typeof API.EventsService>;
     private active: boolean = true;
 
     constructor(transport?: Transport) {
-        this.client = createClient(
-            API.EventsService,
-            transport ?? getContext('transport'),
-        );
+        this.transport = transport ?? getContext('transport');
+        this.client = createClient(API.EventsService, this.transport);
     }
 
     loadPrevious(handlers: PreviousEventHandler[]) {
@@ -38,10 +38,25 @@ export class EventStreamer {
 
     async stream(handlers: EventStreamHandler[], callbackFn: () => void) {
         while (this.active) {
-            for await (const res of this.client.eventStream({})) {
-                handlers.every(h => h.handleEventStream(res));
-                if (!!callbackFn) {
-                    callbackFn();
+            const token = getAuthToken();
+            try {
+                for await (const res of this.client.eventStream({})) {
+                    handlers.every(h => h.handleEventStream(res));
+                    if (!!callbackFn) {
+                        callbackFn();
+                    }
+                }
+            } catch (err) {
+                // the stream is closed once the token it was opened with
+                // expires. browsers cannot push the renewed token over the
+                // client streaming keepalive, so reopen the stream with the
+                // renewed token instead; the session is resumed
+                const renewed = token != getAuthToken();
+                if (ConnectError.from(err).code != Code.Unauthenticated || (!renewed && !isAuthTokenExpired())) {
+                    throw err;
+                }
+                if (isAuthTokenExpired()) {
+                    await renewAuthToken(this.transport);
                 }
             }
         }
@@ -42,7 +42,7 @@ export class UsersList implements EventStreamHandler {
                         return {
                             id: user.id!.value,
                             details: mapUserDetails(user.details!),
-                            flags: user.flags!,
+                            flags: user.flags,
                             status: user.status!,
                         };
                     })
@@ -9,7 +9,7 @@ export type UUID = string;
 export type User = {
     id: UUID;
     details: UserDetails;
-    flags: API.UserFlag;
+    flags: API.UserFlag[];
     status: API.UserStatus;
     typing?: boolean;
 };
@@ -25,7 +25,7 @@ export function mapEventUser(user: API.EventUser): User {
     return {
         id: user.id!.value,
         details: mapUserDetails(user.details!),
-        flags: API.UserFlag.NONE,
+        flags: [],
         status: API.UserStatus.DEFAULT,
     }
 }
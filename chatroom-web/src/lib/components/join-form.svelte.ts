@@ -7,20 +7,19 @@ import { type Client, type Transport, createClient } from "@connectrpc/connect";
 
 import { goto } from "$app/navigation";
 import { API } from "$lib/chatapi";
-import { setAuthToken } from "$lib/chatauth/auth.ts";
+import { scheduleRenewal, setAuthTokens } from "$lib/chatauth";
 
 export type JoinSubmitEvent = SubmitEvent & { currentTarget: EventTarget & HTMLFormElement }
 
 export class JoinForm {
+    private readonly transport: Transport;
     private readonly client: Client<typeof API.AuthService>;
     private readonly fieldName: string
 
     constructor(fieldName: string, transport?: Transport) {
         this.fieldName = fieldName;
-        this.client = createClient(
-            API.AuthService,
-            transport ?? getContext("transport"),
-        );
+        this.transport = transport ?? getContext("transport");
+        this.client = createClient(API.AuthService, this.transport);
     }
 
     async submitForm(event: JoinSubmitEvent) {
@@ -35,7 +34,8 @@ export class JoinForm {
                 }
             })
             .then((response) => {
-                setAuthToken(response.token)
+                setAuthTokens(response)
+                scheduleRenewal(this.transport)
                 goto("/")
             });
     }
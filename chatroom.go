@@ -7,6 +7,9 @@
 package chatroom
 
 import (
+	"context"
+	"io"
+	"net/http"
 	"time"
 
 	"connectrpc.com/connect"
@@ -16,18 +19,45 @@ import (
 	"github.com/go-pogo/webapp"
 	"github.com/go-pogo/webapp/logger"
 	"github.com/roeldev/demo-chatroom/api/v1/apiv1connect"
+	"github.com/roeldev/demo-chatroom/chataccounts"
 	"github.com/roeldev/demo-chatroom/chatauth"
+	"github.com/roeldev/demo-chatroom/chatavatars"
 	"github.com/roeldev/demo-chatroom/chatevents"
+	"github.com/roeldev/demo-chatroom/chatevents/event"
 	"github.com/roeldev/demo-chatroom/chatusers"
 	"github.com/rs/cors"
 	"github.com/rs/zerolog"
 )
 
+const ErrUsersDBRequiresPersistentTokens errors.Msg = "persisting users requires persistent signing keys, refresh tokens and revocations"
+
 type Config struct {
 	Logger                 logger.Config       `env:",include"`
 	Server                 webapp.ServerConfig `env:",include"`
+	Auth                   chatauth.Config     `env:",include"`
+	Accounts               chataccounts.Config `env:",include"`
+	Avatars                chatavatars.Config  `env:",include"`
 	AllowedOrigins         []string            `env:"CORS_ALLOW_ORIGINS"`
 	TypingIndicatorTimeout time.Duration       `default:"5s"`
+
+	// AwayTimeout is how long a user can be inactive before its status
+	// changes to away. Disabled when zero.
+	AwayTimeout time.Duration `default:"5m"`
+	// UnresponsiveTimeout is how long a user can be without an open stream
+	// before its status changes to unresponsive.
+	UnresponsiveTimeout time.Duration `default:"5s"`
+
+	// UsersDB is the SQLite database file users are persisted in. Users are
+	// kept in memory when empty. Persisted users can only resume their
+	// session after a restart with their existing tokens, therefore it
+	// requires AUTH_REFRESH_FILE, AUTH_REVOCATION_FILE and either
+	// AUTH_KEYRING_FILE or AUTH_KEY_FILES. Users who do not reconnect within the session grace
+	// period after a restart leave the chatroom.
+	UsersDB string `env:"USERS_DB"`
+
+	// RateLimits contains the rate limit of each procedure, per user or per
+	// ip address for procedures which do not require a token.
+	RateLimits apiv1connect.RateLimits `env:"RATE_LIMITS" default:"GetChallenge=30/m:10,Join=10/m:5,JoinBot=10/m:5,Register=5/m:3,Login=10/m:5,Renew=30/m:10,SendChat=5/s:10,EditChat=5/s:10,EmojiReply=5/s:10,IndicateTyping=2/s:4,UpdateDetails=1/s:5,UpdateStatus=1/s:5,UploadAvatar=1/m:3,ListUsers=5/s:20"`
 }
 
 var _ serv.RoutesRegisterer = (*Service)(nil)
@@ -35,11 +65,22 @@ var _ serv.RoutesRegisterer = (*Service)(nil)
 type Service struct {
 	log     zerolog.Logger
 	conf    Config
+	keys    *chatauth.Keyring
 	auth    chatauth.SignerParser
 	manager *chatauth.Manager
 	history *chatevents.HistoryHandler
 	broker  *chatevents.EventsBroker
 	users   chatusers.UsersStore
+	revoked chatauth.RevocationStore
+	refresh chatauth.RefreshStore
+	bots    *chatauth.Bots
+	oidc    chatauth.IdentityVerifier
+	account chataccounts.AccountsStore
+	audit   chatauth.AuditSink
+	chal    *chatauth.Challenger
+	typing  chatusers.TypingIndicator
+	blocks  chatusers.BlockList
+	avatars *chatavatars.Avatars
 
 	interceptor connect.Interceptor
 	cors        *cors.Cors
@@ -54,7 +95,7 @@ func NewService(conf Config, log *logger.Logger, opts ...Option) (*Service, erro
 			AllowedOrigins: conf.AllowedOrigins,
 			AllowedMethods: connectcors.AllowedMethods(),
 			AllowedHeaders: append([]string{"authorization"}, connectcors.AllowedHeaders()...),
-			ExposedHeaders: connectcors.ExposedHeaders(),
+			ExposedHeaders: append([]string{apiv1connect.RetryAfterHeader}, connectcors.ExposedHeaders()...),
 		}),
 	}
 
@@ -62,12 +103,71 @@ func NewService(conf Config, log *logger.Logger, opts ...Option) (*Service, erro
 		return nil, err
 	}
 
-	// todo: auth via priv/pub certs
-	if svc.auth, err = chatauth.NewJWTAuth(); err != nil {
+	if svc.keys, err = conf.Auth.NewKeyring(); err != nil {
+		return nil, err
+	}
+	if svc.auth, err = chatauth.NewJWTAuth(svc.keys, conf.Auth.TokenLifetime); err != nil {
 		return nil, err
 	}
+	binder, err := conf.Auth.NewBinder()
+	if err != nil {
+		return nil, err
+	}
+	if svc.bots == nil {
+		if svc.bots, err = conf.Auth.LoadBots(); err != nil {
+			return nil, err
+		}
+	}
+	if svc.account == nil {
+		if svc.account, err = conf.Accounts.NewAccountsStore(); err != nil {
+			return nil, err
+		}
+	}
+	if svc.audit == nil {
+		if svc.audit, err = conf.Auth.NewAuditSink(); err != nil {
+			return nil, err
+		}
+	}
+	var auditor *chatauth.Auditor
+	if svc.audit != nil {
+		auditor = chatauth.NewAuditor(svc.log, svc.audit)
+	}
+	if svc.chal, err = conf.Auth.NewChallenger(); err != nil {
+		return nil, err
+	}
+	if svc.oidc == nil {
+		svc.oidc = conf.Auth.NewIdentityVerifier()
+	}
+	if svc.revoked == nil {
+		if svc.revoked, err = conf.Auth.NewRevocationStore(); err != nil {
+			return nil, err
+		}
+	}
+	if svc.refresh == nil {
+		if svc.refresh, err = conf.Auth.NewRefreshStore(); err != nil {
+			return nil, err
+		}
+	}
 	if svc.users == nil {
-		svc.users = chatusers.NewUsersStore(8)
+		if conf.UsersDB == "" {
+			svc.users = chatusers.NewUsersStore(8)
+		} else if !conf.Auth.HasPersistentTokens() {
+			return nil, errors.New(ErrUsersDBRequiresPersistentTokens)
+		} else if svc.users, err = chatusers.NewSQLiteUsersStore(conf.UsersDB); err != nil {
+			return nil, err
+		}
+	}
+	if svc.typing == nil {
+		svc.typing = chatusers.NewTypingIndicator(conf.TypingIndicatorTimeout)
+	}
+	if svc.avatars == nil {
+		// stays nil when avatar uploads are disabled
+		if svc.avatars, err = conf.Avatars.NewAvatars(); err != nil {
+			return nil, err
+		}
+	}
+	if svc.blocks == nil {
+		svc.blocks = chatusers.NewBlockList()
 	}
 	if svc.broker == nil {
 		svc.broker = chatevents.NewEventsBroker()
@@ -76,9 +176,25 @@ func NewService(conf Config, log *logger.Logger, opts ...Option) (*Service, erro
 		svc.history = chatevents.NewHistoryHandler(chatevents.NewLimitedEventsStore(32))
 	}
 
+	presence := chatauth.NewPresence(svc.users, svc.broker, conf.AwayTimeout, conf.UnresponsiveTimeout)
 	svc.broker.Handle(svc.history)
-	svc.manager = chatauth.NewManager(svc.auth, svc.users, svc.broker)
-	svc.interceptor = apiv1connect.NewHandlerInterceptor(svc.log, svc.auth, svc.users)
+	svc.broker.Handle(presence)
+	svc.broker.Handle(chatevents.EventHandlerFunc(func(e chatevents.Event) {
+		if leave, ok := e.Type.(*event.UserLeaveEvent); ok {
+			svc.blocks.Forget(leave.UserID)
+		}
+	}))
+	svc.manager = chatauth.NewManager(svc.auth, svc.users, svc.broker, svc.revoked, svc.refresh,
+		chatauth.WithGracePeriod(conf.Auth.SessionGracePeriod),
+		chatauth.WithPresence(presence),
+		chatauth.WithAuditor(auditor),
+	)
+	// users which are persisted before a restart have no open streams
+	svc.manager.ResumeSessions()
+	svc.interceptor = apiv1connect.NewHandlerInterceptor(svc.log, svc.auth, svc.manager, svc.users, binder,
+		apiv1connect.NewRateLimiter(conf.RateLimits),
+		auditor,
+	)
 	return svc, nil
 }
 
@@ -94,12 +210,110 @@ func (svc *Service) applyOpts(opts []Option) error {
 	return err
 }
 
+// Run runs the service's background tasks until ctx is canceled. The audit
+// sink and users store are closed afterwards, when they are an [io.Closer].
+func (svc *Service) Run(ctx context.Context) error {
+	if svc.conf.Auth.KeyRotateInterval > 0 {
+		svc.keys.RotateEvery(ctx, svc.conf.Auth.KeyRotateInterval, svc.logKeyRotation)
+	} else {
+		<-ctx.Done()
+	}
+
+	var err error
+	if closer, ok := svc.audit.(io.Closer); ok {
+		err = errors.Append(err, closer.Close())
+	}
+	if closer, ok := svc.users.(io.Closer); ok {
+		err = errors.Append(err, closer.Close())
+	}
+	return err
+}
+
+// RotateKeys generates a new signing key. Tokens signed with the previous key
+// remain valid until they expire.
+func (svc *Service) RotateKeys() error {
+	old, cur, err := svc.keys.Rotate()
+	svc.logKeyRotation(old, cur, err)
+	return err
+}
+
+// RevokeKey removes the signing key with the provided key id, for example when
+// it is compromised. All tokens signed with it are rejected immediately.
+func (svc *Service) RevokeKey(kid string) error {
+	if err := svc.keys.Revoke(kid); err != nil {
+		return err
+	}
+
+	svc.log.Warn().
+		Str("kid", kid).
+		Str("new_kid", svc.keys.Current().ID()).
+		Msg("revoked signing key")
+	return nil
+}
+
+// KickUser removes the user from the chatroom and revokes all of its tokens.
+func (svc *Service) KickUser(uid chatusers.UserID) error {
+	if err := svc.manager.Kick(uid); err != nil {
+		return err
+	}
+
+	svc.log.Info().
+		Stringer("user_id", uid).
+		Msg("kicked user")
+	return nil
+}
+
+// SetUserRole changes the role of the user. The new role applies once the
+// user's client renews its access token.
+func (svc *Service) SetUserRole(uid chatusers.UserID, role chatusers.Role) error {
+	if err := svc.manager.SetRole(uid, role); err != nil {
+		return err
+	}
+
+	svc.log.Info().
+		Stringer("user_id", uid).
+		Stringer("role", role).
+		Msg("changed user role")
+	return nil
+}
+
+// SetChallengeDifficulty changes the difficulty of the proof-of-work challenge
+// users must solve to join, e.g. when the join rate spikes. Challenges are no
+// longer required when zero.
+func (svc *Service) SetChallengeDifficulty(difficulty uint8) {
+	svc.chal.SetDifficulty(difficulty)
+
+	svc.log.Info().
+		Uint8("difficulty", svc.chal.Difficulty()).
+		Msg("changed challenge difficulty")
+}
+
+func (svc *Service) logKeyRotation(old, cur *chatauth.Key, err error) {
+	if err != nil {
+		svc.log.Err(err).Msg("failed to rotate signing key")
+		return
+	}
+
+	svc.log.Info().
+		Str("old_kid", old.ID()).
+		Str("new_kid", cur.ID()).
+		Str("alg", cur.Method().Alg()).
+		Msg("rotated signing key")
+}
+
 func (svc *Service) RegisterRoutes(rh serv.RouteHandler) {
 	routes := []serv.Route{
 		svc.authService(),
 		svc.registryService(),
 		svc.userService(),
 		svc.eventsService(),
+		svc.adminService(),
+	}
+	if svc.avatars != nil {
+		routes = append(routes, svc.avatarsRoute())
+	}
+	if len(svc.keys.JWKSet().Keys) != 0 {
+		routes = append(routes, svc.jwksRoute())
 	}
 	for _, route := range routes {
 		route.Handler = svc.cors.Handler(route.Handler)
@@ -107,21 +321,44 @@ func (svc *Service) RegisterRoutes(rh serv.RouteHandler) {
 	}
 }
 
+func (svc *Service) jwksRoute() serv.Route {
+	return serv.Route{
+		Name:    "jwks",
+		Method:  http.MethodGet,
+		Pattern: chatauth.JWKSPath,
+		Handler: chatauth.JWKSHandler(svc.keys),
+	}
+}
+
+func (svc *Service) avatarsRoute() serv.Route {
+	return serv.Route{
+		Name:    "avatars",
+		Method:  http.MethodGet,
+		Pattern: chatavatars.Path + "{file}",
+		Handler: svc.avatars.Handler(),
+	}
+}
+
 func (svc *Service) authService() serv.Route {
 	path, handler := apiv1connect.NewAuthServiceHandler(
-		apiv1connect.NewAuthService(svc.log, svc.manager),
+		apiv1connect.NewAuthService(svc.log, svc.manager, svc.bots, apiv1connect.JoinPolicy{
+			Identity:       svc.oidc,
+			AllowAnonymous: svc.conf.Auth.AllowAnonymous,
+			Accounts:       svc.account,
+			Challenge:      svc.chal,
+		}),
 		connect.WithInterceptors(svc.interceptor),
 	)
 	return serv.Route{
 		Name:    "auth-service",
 		Pattern: path,
-		Handler: handler,
+		Handler: apiv1connect.PeerCertificateHandler(handler),
 	}
 }
 
 func (svc *Service) registryService() serv.Route {
 	path, handler := apiv1connect.NewRegistryServiceHandler(
-		apiv1connect.NewRegistryService(svc.log, svc.users),
+		apiv1connect.NewRegistryService(svc.log, svc.users, svc.typing, svc.blocks),
 		connect.WithInterceptors(svc.interceptor),
 	)
 	return serv.Route{
@@ -132,14 +369,23 @@ func (svc *Service) registryService() serv.Route {
 }
 
 func (svc *Service) userService() serv.Route {
+	opts := []connect.HandlerOption{connect.WithInterceptors(svc.interceptor)}
+	if svc.avatars != nil {
+		// leave room for the other fields of an avatar upload request
+		opts = append(opts, connect.WithReadMaxBytes(svc.avatars.MaxSize()+1024))
+	}
+
 	path, handler := apiv1connect.NewUserServiceHandler(
 		apiv1connect.NewUserService(
 			svc.log,
 			svc.users,
-			chatusers.NewTypingIndicator(svc.conf.TypingIndicatorTimeout),
+			svc.account,
+			svc.typing,
+			svc.blocks,
+			svc.avatars,
 			svc.broker,
 		),
-		connect.WithInterceptors(svc.interceptor),
+		opts...,
 	)
 	return serv.Route{
 		Name:    "user-service",
@@ -155,6 +401,7 @@ func (svc *Service) eventsService() serv.Route {
 			svc.history,
 			svc.broker,
 			svc.manager,
+			svc.blocks,
 		),
 		connect.WithInterceptors(svc.interceptor),
 	)
@@ -164,3 +411,15 @@ func (svc *Service) eventsService() serv.Route {
 		Handler: handler,
 	}
 }
+
+func (svc *Service) adminService() serv.Route {
+	path, handler := apiv1connect.NewAdminServiceHandler(
+		apiv1connect.NewAdminService(svc.log, svc),
+		connect.WithInterceptors(svc.interceptor),
+	)
+	return serv.Route{
+		Name:    "admin-service",
+		Pattern: path,
+		Handler: handler,
+	}
+}
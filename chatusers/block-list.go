@@ -0,0 +1,90 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"sync"
+
+	"github.com/go-pogo/errors"
+)
+
+const ErrBlockSelf errors.Msg = "users cannot block themselves"
+
+// BlockList keeps track of the users each user has blocked.
+type BlockList interface {
+	// Block adds blocked to the block list of the user.
+	Block(uid, blocked UserID) error
+	// Unblock removes blocked from the block list of the user.
+	Unblock(uid, blocked UserID)
+	// IsBlocked indicates if the user has blocked other.
+	IsBlocked(uid, other UserID) bool
+	// Blocked returns the block list of the user.
+	Blocked(uid UserID) []UserID
+	// Forget removes the block list of the user, e.g. when the user leaves the
+	// chatroom. The user remains on the block lists of others.
+	Forget(uid UserID)
+}
+
+type blockList struct {
+	mut    sync.RWMutex
+	blocks map[UserID]map[UserID]struct{}
+}
+
+func NewBlockList() BlockList {
+	return &blockList{
+		blocks: make(map[UserID]map[UserID]struct{}, 8),
+	}
+}
+
+func (bl *blockList) Block(uid, blocked UserID) error {
+	if uid == blocked {
+		return errors.New(ErrBlockSelf)
+	}
+
+	bl.mut.Lock()
+	defer bl.mut.Unlock()
+
+	if bl.blocks[uid] == nil {
+		bl.blocks[uid] = make(map[UserID]struct{}, 2)
+	}
+	bl.blocks[uid][blocked] = struct{}{}
+	return nil
+}
+
+func (bl *blockList) Unblock(uid, blocked UserID) {
+	bl.mut.Lock()
+	defer bl.mut.Unlock()
+
+	delete(bl.blocks[uid], blocked)
+	if len(bl.blocks[uid]) == 0 {
+		delete(bl.blocks, uid)
+	}
+}
+
+func (bl *blockList) IsBlocked(uid, other UserID) bool {
+	bl.mut.RLock()
+	defer bl.mut.RUnlock()
+
+	_, ok := bl.blocks[uid][other]
+	return ok
+}
+
+func (bl *blockList) Blocked(uid UserID) []UserID {
+	bl.mut.RLock()
+	defer bl.mut.RUnlock()
+
+	res := make([]UserID, 0, len(bl.blocks[uid]))
+	for blocked := range bl.blocks[uid] {
+		res = append(res, blocked)
+	}
+	return res
+}
+
+func (bl *blockList) Forget(uid UserID) {
+	bl.mut.Lock()
+	defer bl.mut.Unlock()
+
+	delete(bl.blocks, uid)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockList(t *testing.T) {
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+
+	bl := NewBlockList()
+	assert.ErrorIs(t, bl.Block(alice, alice), ErrBlockSelf)
+	assert.NoError(t, bl.Block(alice, bob))
+	assert.NoError(t, bl.Block(alice, carol))
+	assert.NoError(t, bl.Block(carol, bob))
+
+	assert.True(t, bl.IsBlocked(alice, bob))
+	assert.False(t, bl.IsBlocked(bob, alice), "blocking is one way")
+	assert.ElementsMatch(t, []UserID{bob, carol}, bl.Blocked(alice))
+
+	bl.Unblock(alice, carol)
+	assert.False(t, bl.IsBlocked(alice, carol))
+	assert.Equal(t, []UserID{bob}, bl.Blocked(alice))
+
+	bl.Forget(bob)
+	assert.True(t, bl.IsBlocked(alice, bob), "block list of alice is kept")
+	assert.True(t, bl.IsBlocked(carol, bob), "block list of carol is kept")
+
+	bl.Forget(alice)
+	assert.Empty(t, bl.Blocked(alice))
+	assert.Equal(t, []UserID{bob}, bl.Blocked(carol))
+}
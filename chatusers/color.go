@@ -6,7 +6,20 @@ package chatusers
 
 import (
 	"image/color"
+	"math"
 	"math/rand/v2"
+	"strconv"
+)
+
+// MinContrastRatio is the minimum contrast ratio between the primary and
+// secondary color of a user. It equals the WCAG AA level for normal text, as
+// the initials are displayed in the secondary color on top of the primary
+// color.
+const MinContrastRatio = 4.5
+
+var (
+	white = color.RGBA{R: 255, G: 255, B: 255}
+	black = color.RGBA{}
 )
 
 func RandomColors() (color.RGBA, color.RGBA) {
@@ -18,7 +31,60 @@ func RandomColors() (color.RGBA, color.RGBA) {
 	return c1, SecondaryColor(c1)
 }
 
+// SecondaryColor returns white or black, whichever has the highest contrast
+// with c1. The contrast ratio with either one is always at least
+// [MinContrastRatio].
 func SecondaryColor(c1 color.Color) color.RGBA {
-	//r, g, b, _ := c1.RGBA()
-	return color.RGBA{R: 255, G: 255, B: 255}
+	if ContrastRatio(c1, white) >= ContrastRatio(c1, black) {
+		return white
+	}
+	return black
+}
+
+// RelativeLuminance returns the relative luminance of c, as defined by WCAG
+// 2. It ranges from 0 for black to 1 for white. The alpha channel is ignored.
+func RelativeLuminance(c color.Color) float64 {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return 0.2126*linearize(rgba.R) + 0.7152*linearize(rgba.G) + 0.0722*linearize(rgba.B)
+}
+
+func linearize(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio between a and b. It ranges
+// from 1 for equal colors to 21 for black and white.
+func ContrastRatio(a, b color.Color) float64 {
+	l1, l2 := RelativeLuminance(a), RelativeLuminance(b)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// CheckContrast returns a [*ContrastError] when the contrast ratio between c1
+// and c2 is below [MinContrastRatio].
+func CheckContrast(c1, c2 color.Color) error {
+	if ratio := ContrastRatio(c1, c2); ratio < MinContrastRatio {
+		return &ContrastError{Ratio: ratio}
+	}
+	return nil
+}
+
+// ContrastError is returned when the primary and secondary color of a user
+// do not have sufficient contrast. It unwraps to [ErrUnsufficientContrast].
+type ContrastError struct {
+	Ratio float64
+}
+
+func (e *ContrastError) Unwrap() error { return ErrUnsufficientContrast }
+
+func (e *ContrastError) Error() string {
+	return ErrUnsufficientContrast.String() + ": ratio between primary and secondary color is " +
+		strconv.FormatFloat(e.Ratio, 'f', 2, 64) + ":1, must be at least " +
+		strconv.FormatFloat(MinContrastRatio, 'f', 1, 64) + ":1"
 }
@@ -0,0 +1,41 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContrastRatio(t *testing.T) {
+	assert.InDelta(t, 21, ContrastRatio(black, white), 0.001)
+	assert.InDelta(t, 21, ContrastRatio(white, black), 0.001)
+	assert.InDelta(t, 1, ContrastRatio(white, white), 0.001)
+	// #777777 on white is a well known near miss of the AA level
+	assert.InDelta(t, 4.48, ContrastRatio(color.RGBA{R: 0x77, G: 0x77, B: 0x77}, white), 0.01)
+}
+
+func TestSecondaryColor(t *testing.T) {
+	tests := map[string]struct {
+		c1   color.Color
+		want color.RGBA
+	}{
+		"black":  {c1: black, want: white},
+		"white":  {c1: white, want: black},
+		"navy":   {c1: color.RGBA{B: 128}, want: white},
+		"yellow": {c1: color.RGBA{R: 255, G: 255}, want: black},
+		"gray":   {c1: color.RGBA{R: 0x77, G: 0x77, B: 0x77}, want: black},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := SecondaryColor(tc.c1)
+			assert.Equal(t, tc.want, have)
+			assert.NoError(t, CheckContrast(tc.c1, have))
+		})
+	}
+}
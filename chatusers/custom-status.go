@@ -0,0 +1,93 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrStatusTextTooLong errors.Msg = "status text should not be longer than 100 characters"
+	ErrInvalidEmoji      errors.Msg = "status emoji should be a single emoji"
+	ErrStatusExpired     errors.Msg = "status expiry time should be in the future"
+)
+
+const (
+	MaxStatusTextLength = 100
+	// maxEmojiLength allows for emoji sequences, e.g. flags, skin tones and
+	// zero width joined emoji
+	maxEmojiLength = 8
+)
+
+// CustomStatus is a free-text status with an optional emoji, e.g. "In a
+// meeting until 15:00". It is cleared once it expires, unless Expires is
+// empty.
+type CustomStatus struct {
+	Text    string
+	Emoji   string
+	Expires time.Time
+}
+
+func (cs CustomStatus) IsZero() bool { return cs == CustomStatus{} }
+
+// IsExpired indicates if the custom status has an expiry time which is not
+// after now.
+func (cs CustomStatus) IsExpired(now time.Time) bool {
+	return !cs.Expires.IsZero() && !cs.Expires.After(now)
+}
+
+// Validate checks the length of the text and emoji, and that the custom status
+// is not already expired at now.
+func (cs CustomStatus) Validate(now time.Time) error {
+	if utf8.RuneCountInString(cs.Text) > MaxStatusTextLength {
+		return errors.New(ErrStatusTextTooLong)
+	}
+	if cs.Emoji != "" && !isEmoji(cs.Emoji) {
+		return errors.New(ErrInvalidEmoji)
+	}
+	if cs.IsExpired(now) {
+		return errors.New(ErrStatusExpired)
+	}
+	return nil
+}
+
+// isEmoji indicates if s is a single emoji or emoji sequence. It checks s
+// contains at least one symbol and otherwise only runes which are part of
+// emoji sequences, e.g. joiners, variation selectors, skin tone modifiers and
+// keycaps.
+func isEmoji(s string) bool {
+	if utf8.RuneCountInString(s) > maxEmojiLength {
+		return false
+	}
+
+	var symbol, keycap bool
+	for _, r := range s {
+		switch {
+		case r == utf8.RuneError:
+			return false
+		case unicode.Is(unicode.So, r):
+			symbol = true
+		case r == '\u20e3': // combining enclosing keycap
+			symbol, keycap = true, true
+		case r == '\u200d', // zero width joiner
+			r >= '\ufe00' && r <= '\ufe0f', // variation selectors
+			r >= 0x1f3fb && r <= 0x1f3ff,   // skin tone modifiers
+			r >= 0xe0020 && r <= 0xe007f,   // tags, e.g. subdivision flags
+			r == '#', r == '*', r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	if !keycap && strings.ContainsAny(s, "#*0123456789") {
+		// digits, # and * are only emoji as part of a keycap
+		return false
+	}
+	return symbol
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomStatus_Validate(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		status  CustomStatus
+		wantErr error
+	}{
+		"empty": {},
+		"text and emoji": {
+			status: CustomStatus{Text: "In a meeting", Emoji: "📅", Expires: now.Add(time.Hour)},
+		},
+		"emoji sequence": {
+			status: CustomStatus{Emoji: "👩🏽‍💻"},
+		},
+		"text too long": {
+			status:  CustomStatus{Text: strings.Repeat("a", MaxStatusTextLength+1)},
+			wantErr: ErrStatusTextTooLong,
+		},
+		"flag": {
+			status: CustomStatus{Emoji: "🇳🇱"},
+		},
+		"keycap": {
+			status: CustomStatus{Emoji: "1️⃣"},
+		},
+		"emoji too long": {
+			status:  CustomStatus{Emoji: "not an emoji"},
+			wantErr: ErrInvalidEmoji,
+		},
+		"letters": {
+			status:  CustomStatus{Emoji: "abcdefgh"},
+			wantErr: ErrInvalidEmoji,
+		},
+		"digit": {
+			status:  CustomStatus{Emoji: "7"},
+			wantErr: ErrInvalidEmoji,
+		},
+		"expired": {
+			status:  CustomStatus{Text: "Lunch", Expires: now.Add(-time.Minute)},
+			wantErr: ErrStatusExpired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.status.Validate(now)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCustomStatus_IsExpired(t *testing.T) {
+	now := time.Now()
+	assert.False(t, CustomStatus{Text: "forever"}.IsExpired(now))
+	assert.False(t, CustomStatus{Expires: now.Add(time.Second)}.IsExpired(now))
+	assert.True(t, CustomStatus{Expires: now}.IsExpired(now))
+}
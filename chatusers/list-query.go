@@ -0,0 +1,113 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+)
+
+const ErrInvalidCursor errors.Msg = "invalid cursor"
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// UserEntry is a [User] together with its [UserID].
+type UserEntry struct {
+	ID UserID
+	User
+}
+
+// ListQuery selects the users returned by [UsersStore.List]. Users are ordered
+// by the [NameKey] of their name, and by id when keys are equal, so the order
+// is stable between pages.
+type ListQuery struct {
+	// Prefix matches users whose name starts with it, ignoring case and
+	// look-alike characters. All users match when empty.
+	Prefix string
+	// Flags matches users which have all of the flags.
+	Flags Flag
+	// Statuses matches users which have any of the statuses. All users match
+	// when empty.
+	Statuses []Status
+	// After continues listing after the user the cursor points to.
+	After Cursor
+	// Limit is the maximum number of returned users. It defaults to
+	// [DefaultListLimit] and is capped to [MaxListLimit].
+	Limit int
+}
+
+func (q ListQuery) limit() int {
+	if q.Limit <= 0 {
+		return DefaultListLimit
+	}
+	return min(q.Limit, MaxListLimit)
+}
+
+// matches indicates if the user, with its name key, is selected by the query,
+// not taking its cursor into account.
+func (q ListQuery) matches(key, prefix string, user User) bool {
+	if !strings.HasPrefix(key, prefix) || user.Flags&q.Flags != q.Flags {
+		return false
+	}
+	return len(q.Statuses) == 0 || slices.Contains(q.Statuses, user.Status)
+}
+
+// Cursor points to a user in the ordered list of users. The zero value points
+// to the start of the list.
+type Cursor struct {
+	Key string
+	ID  UserID
+}
+
+func cursorOf(entry UserEntry) Cursor {
+	return Cursor{Key: NameKey(entry.Name), ID: entry.ID}
+}
+
+func (c Cursor) IsZero() bool { return c == Cursor{} }
+
+// before indicates if the user with key and id comes after the cursor. Every
+// user comes after the zero value.
+func (c Cursor) before(key string, id UserID) bool {
+	if key != c.Key {
+		return key > c.Key
+	}
+	return bytes.Compare(id[:], c.ID[:]) > 0
+}
+
+// String encodes the cursor as an opaque url safe token. It returns an empty
+// string for the zero value.
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(append(c.ID[:], c.Key...))
+}
+
+// ParseCursor decodes a token created with [Cursor.String]. An empty token
+// results in the zero value.
+func ParseCursor(s string) (Cursor, error) {
+	if s == "" {
+		return Cursor{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) < len(uuid.Nil) || !utf8.Valid(b[len(uuid.Nil):]) {
+		return Cursor{}, errors.New(ErrInvalidCursor)
+	}
+
+	var c Cursor
+	copy(c.ID[:], b)
+	c.Key = string(b[len(uuid.Nil):])
+	return c, nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCursor(t *testing.T) {
+	want := Cursor{Key: "bob", ID: uuid.New()}
+	have, err := ParseCursor(want.String())
+	assert.NoError(t, err)
+	assert.Equal(t, want, have)
+
+	have, err = ParseCursor("")
+	assert.NoError(t, err)
+	assert.True(t, have.IsZero())
+
+	for _, token := range []string{"not a cursor", "Ym9i", want.String() + "_w"} {
+		_, err = ParseCursor(token)
+		assert.ErrorIs(t, err, ErrInvalidCursor, token)
+	}
+}
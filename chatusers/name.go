@@ -0,0 +1,120 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+var nameFolder = cases.Fold()
+
+// NameKey returns the key which is used to test the uniqueness of name. Names
+// which look alike, e.g. "Alice", "alice", "Аlice" (with a Cyrillic A) and
+// "a1ice", have the same key. The name is normalized with Unicode NFKC and
+// mapped to its confusable skeleton before it is case folded, so uppercase
+// letters of other scripts are compared by how they look instead of by their
+// lowercase form. Latin letters are never mapped to other latin letters.
+// Invisible characters are removed and whitespace is collapsed.
+func NameKey(name string) string {
+	var sb strings.Builder
+	sb.Grow(len(name))
+
+	space := false
+	for _, r := range norm.NFD.String(norm.NFKC.String(name)) {
+		switch {
+		case unicode.IsSpace(r):
+			space = sb.Len() != 0
+			continue
+		case unicode.Is(unicode.Cf, r) || unicode.Is(unicode.Variation_Selector, r):
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		if s, ok := upperConfusables[r]; ok {
+			sb.WriteString(s)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+
+	// folding may result in characters which are confusable with another
+	// lowercase character
+	folded := nameFolder.String(sb.String())
+	sb.Reset()
+	for _, r := range folded {
+		if s, ok := confusables[r]; ok {
+			sb.WriteString(s)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(sb.String())
+}
+
+// upperConfusables maps uppercase characters, which look different from their
+// lowercase form, to the latin prototype they are easily confused with. It is
+// applied before case folding.
+var upperConfusables = map[rune]string{
+	// greek
+	'Η': "h",
+	'Ν': "n",
+	'Υ': "y",
+}
+
+// confusables maps case folded characters to the latin prototype they are
+// easily confused with. It is a subset of the Unicode confusables data
+// (UTS #39), which covers the scripts impersonators commonly use.
+var confusables = map[rune]string{
+	// digits and symbols
+	'0': "o",
+	'1': "l",
+	'|': "l",
+	'ı': "i",
+	'ȷ': "j",
+	// cyrillic
+	'а': "a",
+	'в': "b",
+	'ԁ': "d",
+	'е': "e",
+	'һ': "h",
+	'і': "i",
+	'ј': "j",
+	'к': "k",
+	'ӏ': "l",
+	'м': "m",
+	'н': "h",
+	'о': "o",
+	'р': "p",
+	'ԛ': "q",
+	'г': "r",
+	'с': "c",
+	'ѕ': "s",
+	'т': "t",
+	'ѵ': "v",
+	'ԝ': "w",
+	'х': "x",
+	'у': "y",
+	'ү': "y",
+	// greek
+	'α': "a",
+	'β': "b",
+	'ε': "e",
+	'ι': "i",
+	'κ': "k",
+	'μ': "m",
+	'ο': "o",
+	'ρ': "p",
+	'τ': "t",
+	'χ': "x",
+	'γ': "y",
+	'ω': "w",
+	'ζ': "z",
+}
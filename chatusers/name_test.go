@@ -0,0 +1,56 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameKey(t *testing.T) {
+	tests := map[string][]string{
+		"alice": {
+			"Alice",
+			"ALICE",
+			"\u0410lice",                     // cyrillic A
+			"\uff21\uff4c\uff49\uff43\uff45", // fullwidth
+			"a\u200blice",                    // zero width space
+			"al\u0456ce",                     // cyrillic i
+			"a1ice",
+		},
+		"bob smith": {
+			"Bob Smith",
+			"  bob   smith ",
+			"B\u043eb Smith", // cyrillic o
+		},
+		"jose": {"JOSE"},
+		"bill": {
+			"BІLL", // cyrillic capital i
+			"BΙLL", // greek capital iota
+			"bıll", // dotless i
+		},
+		"hanna": {
+			"\u0397anna", // greek capital eta
+			"HA\u039dNA", // greek capital nu
+		},
+	}
+
+	for want, names := range tests {
+		for _, name := range names {
+			t.Run(name, func(t *testing.T) {
+				assert.Equal(t, NameKey(want), NameKey(name))
+			})
+		}
+	}
+
+	t.Run("distinct", func(t *testing.T) {
+		assert.NotEqual(t, NameKey("alice"), NameKey("alicia"))
+		assert.NotEqual(t, NameKey("jose"), NameKey("josé"))
+		assert.NotEqual(t, NameKey("Ali"), NameKey("All"))
+		assert.NotEqual(t, NameKey("Bill"), NameKey("Biii"))
+		assert.NotEqual(t, NameKey("Alice"), NameKey("AIice"), "latin letters are not confused")
+	})
+}
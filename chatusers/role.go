@@ -0,0 +1,93 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"strings"
+
+	"github.com/go-pogo/errors"
+)
+
+const ErrInvalidRole errors.Msg = "invalid role"
+
+// Role determines what a user is permitted to do. Roles can be combined to
+// describe a set of roles, e.g. in a permission table.
+type Role uint8
+
+//goland:noinspection GoSnakeCaseUsage
+const (
+	Role_Member Role = 1 << iota
+	Role_Moderator
+	Role_Admin
+	Role_Bot
+	Role_None Role = 0
+
+	Role_Staff = Role_Moderator | Role_Admin
+	Role_Any   = Role_Member | Role_Moderator | Role_Admin | Role_Bot
+)
+
+var roleNames = []struct {
+	role Role
+	name string
+}{
+	{Role_Member, "member"},
+	{Role_Moderator, "moderator"},
+	{Role_Admin, "admin"},
+	{Role_Bot, "bot"},
+}
+
+// Has indicates if any of the roles in role are also in r.
+func (r Role) Has(role Role) bool { return r&role != 0 }
+
+func (r Role) String() string {
+	if r == Role_None {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, rn := range roleNames {
+		if !r.Has(rn.role) {
+			continue
+		}
+		if sb.Len() != 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(rn.name)
+	}
+	return sb.String()
+}
+
+// ParseRole parses a comma separated list of role names.
+func ParseRole(str string) (Role, error) {
+	var role Role
+	if str == "" {
+		return role, nil
+	}
+
+next:
+	for _, name := range strings.Split(str, ",") {
+		name = strings.TrimSpace(name)
+		for _, rn := range roleNames {
+			if strings.EqualFold(name, rn.name) {
+				role |= rn.role
+				continue next
+			}
+		}
+		return Role_None, errors.Wrapf(errors.New(ErrInvalidRole), "%q", name)
+	}
+	return role, nil
+}
+
+func (r Role) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+func (r *Role) UnmarshalText(text []byte) error {
+	role, err := ParseRole(string(text))
+	if err != nil {
+		return err
+	}
+
+	*r = role
+	return nil
+}
@@ -0,0 +1,383 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+var _ UsersStore = (*SQLiteUsersStore)(nil)
+
+func init() {
+	// name_key is used by the unique index on names, see [NameKey]
+	err := sqlite.RegisterDeterministicScalarFunction("name_key", 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			name, _ := args[0].(string)
+			return NameKey(name), nil
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// SQLiteUsersStore is a [UsersStore] which persists users in an embedded
+// SQLite database.
+type SQLiteUsersStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteUsersStore opens, or creates, the SQLite database at file and
+// migrates its schema to the latest version. Use ":memory:" for a database
+// which is not persisted.
+func NewSQLiteUsersStore(file string) (*SQLiteUsersStore, error) {
+	db, err := sql.Open("sqlite", "file:"+file+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// a single connection serializes writes and keeps an in-memory database
+	// from being opened more than once
+	db.SetMaxOpenConns(1)
+
+	if err = migrate(db, usersMigrations); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &SQLiteUsersStore{db: db}, nil
+}
+
+// usersMigrations contains the schema migrations of the users database. The
+// schema version is the number of applied migrations. Never change an
+// existing migration, always append a new one.
+var usersMigrations = []string{
+	// 1: create users table
+	`CREATE TABLE users (
+		id       TEXT PRIMARY KEY,
+		name     TEXT NOT NULL UNIQUE,
+		initials TEXT NOT NULL DEFAULT '',
+		color1   TEXT NOT NULL DEFAULT '',
+		color2   TEXT NOT NULL DEFAULT '',
+		picture  TEXT NOT NULL DEFAULT '',
+		flags    INTEGER NOT NULL DEFAULT 0,
+		status   INTEGER NOT NULL DEFAULT 0,
+		role     INTEGER NOT NULL DEFAULT 0
+	)`,
+	// 2: names which look alike must be unique
+	`CREATE UNIQUE INDEX users_name_key ON users (name_key(name))`,
+	// 3: add profile
+	`ALTER TABLE users ADD COLUMN pronouns TEXT NOT NULL DEFAULT '';
+	ALTER TABLE users ADD COLUMN bio TEXT NOT NULL DEFAULT '';
+	ALTER TABLE users ADD COLUMN time_zone TEXT NOT NULL DEFAULT ''`,
+	// 4: add custom status, expires is in unix milliseconds or 0 when it
+	// never expires
+	`ALTER TABLE users ADD COLUMN status_text TEXT NOT NULL DEFAULT '';
+	ALTER TABLE users ADD COLUMN status_emoji TEXT NOT NULL DEFAULT '';
+	ALTER TABLE users ADD COLUMN status_expires INTEGER NOT NULL DEFAULT 0`,
+	// 5: name keys map confusables before case folding; rebuild the index.
+	// users whose names now have the same key as the name of an earlier user
+	// are renamed with a " (n)" suffix. the migration fails when a renamed
+	// name is still not unique
+	`DROP INDEX users_name_key;
+	UPDATE users SET name = users.name || ' (' || dup.n || ')'
+	FROM (
+		SELECT rowid AS id, row_number() OVER (PARTITION BY name_key(name) ORDER BY rowid) AS n
+		FROM users
+	) AS dup
+	WHERE users.rowid = dup.id AND dup.n > 1;
+	CREATE UNIQUE INDEX users_name_key ON users (name_key(name))`,
+}
+
+// migrate applies all migrations which are not yet applied, each in its own
+// transaction.
+func migrate(db *sql.DB, migrations []string) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for ; version < len(migrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err = tx.Exec(migrations[version]); err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "failed to apply migration %d", version+1)
+		}
+		// pragma statements do not support placeholders
+		if _, err = tx.Exec("PRAGMA user_version = " + strconv.Itoa(version+1)); err != nil {
+			_ = tx.Rollback()
+			return errors.WithStack(err)
+		}
+		if err = tx.Commit(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+const userColumns = "id, name, initials, color1, color2, picture, flags, status, role, pronouns, bio, time_zone, status_text, status_emoji, status_expires"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner) (UserID, User, error) {
+	var id, c1, c2 string
+	var expires int64
+	var user User
+	err := row.Scan(&id,
+		&user.Name, &user.Initials, &c1, &c2, &user.Picture,
+		&user.Flags, &user.Status, &user.Role,
+		&user.Profile.Pronouns, &user.Profile.Bio, &user.Profile.TimeZone,
+		&user.Custom.Text, &user.Custom.Emoji, &expires,
+	)
+	if err != nil {
+		return uuid.Nil, user, err
+	}
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, user, errors.WithStack(err)
+	}
+
+	user.Color1 = decodeColor(c1)
+	user.Color2 = decodeColor(c2)
+	user.Custom.Expires = decodeTime(expires)
+	return uid, user, nil
+}
+
+// encodeColor encodes c as a hex string in the form rrggbbaa.
+func encodeColor(c color.Color) string {
+	if c == nil {
+		return ""
+	}
+
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return hex.EncodeToString([]byte{rgba.R, rgba.G, rgba.B, rgba.A})
+}
+
+func decodeColor(s string) color.Color {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 4 {
+		return nil
+	}
+	return color.RGBA{R: b[0], G: b[1], B: b[2], A: b[3]}
+}
+
+// encodeTime encodes t as unix milliseconds, or 0 when t is zero.
+func encodeTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func decodeTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+func (us *SQLiteUsersStore) All() map[UserID]User {
+	rows, err := us.db.Query("SELECT " + userColumns + " FROM users")
+	if err != nil {
+		return map[UserID]User{}
+	}
+	defer rows.Close()
+
+	res := make(map[UserID]User, 8)
+	for rows.Next() {
+		uid, user, err := scanUser(rows)
+		if err != nil {
+			continue
+		}
+		res[uid] = user
+	}
+	return res
+}
+
+func (us *SQLiteUsersStore) Has(id UserID) bool {
+	var n int
+	err := us.db.QueryRow("SELECT 1 FROM users WHERE id = ?", id.String()).Scan(&n)
+	return err == nil
+}
+
+func (us *SQLiteUsersStore) Get(id UserID) (User, error) {
+	_, user, err := scanUser(us.db.QueryRow(
+		"SELECT "+userColumns+" FROM users WHERE id = ?", id.String(),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, errors.New(ErrUserNotFound)
+	}
+	if err != nil {
+		return User{}, errors.WithStack(err)
+	}
+	return user, nil
+}
+
+func (us *SQLiteUsersStore) Add(user User) (UserID, error) {
+	id := uuid.New()
+	_, err := us.db.Exec("INSERT INTO users ("+userColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id.String(),
+		user.Name, user.Initials, encodeColor(user.Color1), encodeColor(user.Color2), user.Picture,
+		user.Flags, user.Status, user.Role,
+		user.Profile.Pronouns, user.Profile.Bio, user.Profile.TimeZone,
+		user.Custom.Text, user.Custom.Emoji, encodeTime(user.Custom.Expires),
+	)
+	if isUniqueViolation(err) {
+		return uuid.Nil, errors.New(ErrNameAlreadyExists)
+	}
+	if err != nil {
+		return uuid.Nil, errors.WithStack(err)
+	}
+	return id, nil
+}
+
+func (us *SQLiteUsersStore) Update(id UserID, user User) error {
+	return update(us.db, id, user)
+}
+
+// UpdateFunc reads and writes the user within a single transaction. As the
+// store uses a single connection, no other changes can be made in between.
+func (us *SQLiteUsersStore) UpdateFunc(id UserID, fn func(user *User) error) (User, User, error) {
+	tx, err := us.db.Begin()
+	if err != nil {
+		return User{}, User{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, before, err := scanUser(tx.QueryRow(
+		"SELECT "+userColumns+" FROM users WHERE id = ?", id.String(),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, User{}, errors.New(ErrUserNotFound)
+	}
+	if err != nil {
+		return User{}, User{}, errors.WithStack(err)
+	}
+
+	after := before
+	if err = fn(&after); err != nil {
+		return before, before, err
+	}
+	if err = update(tx, id, after); err != nil {
+		return before, before, err
+	}
+	if err = tx.Commit(); err != nil {
+		return before, before, errors.WithStack(err)
+	}
+	return before, after, nil
+}
+
+// execer is implemented by both [sql.DB] and [sql.Tx].
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func update(db execer, id UserID, user User) error {
+	res, err := db.Exec(
+		"UPDATE users SET name = ?, initials = ?, color1 = ?, color2 = ?, picture = ?, flags = ?, status = ?, role = ?, pronouns = ?, bio = ?, time_zone = ?, status_text = ?, status_emoji = ?, status_expires = ? WHERE id = ?",
+		user.Name, user.Initials, encodeColor(user.Color1), encodeColor(user.Color2), user.Picture,
+		user.Flags, user.Status, user.Role,
+		user.Profile.Pronouns, user.Profile.Bio, user.Profile.TimeZone,
+		user.Custom.Text, user.Custom.Emoji, encodeTime(user.Custom.Expires),
+		id.String(),
+	)
+	if isUniqueViolation(err) {
+		return errors.New(ErrNameAlreadyExists)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New(ErrUserNotFound)
+	}
+	return nil
+}
+
+func (us *SQLiteUsersStore) Delete(id UserID) (User, bool) {
+	_, user, err := scanUser(us.db.QueryRow(
+		"DELETE FROM users WHERE id = ? RETURNING "+userColumns, id.String(),
+	))
+	if err != nil {
+		return User{}, false
+	}
+	return user, true
+}
+
+// List uses the unique index on the name keys to order the users, see
+// [NameKey].
+func (us *SQLiteUsersStore) List(q ListQuery) ([]UserEntry, Cursor, error) {
+	var where strings.Builder
+	args := make([]any, 0, 8)
+
+	where.WriteString("flags & ? = ?")
+	args = append(args, q.Flags, q.Flags)
+
+	if prefix := NameKey(q.Prefix); prefix != "" {
+		where.WriteString(" AND name_key(name) >= ? AND substr(name_key(name), 1, length(?)) = ?")
+		args = append(args, prefix, prefix, prefix)
+	}
+	if len(q.Statuses) != 0 {
+		where.WriteString(" AND status IN (?" + strings.Repeat(", ?", len(q.Statuses)-1) + ")")
+		for _, st := range q.Statuses {
+			args = append(args, st)
+		}
+	}
+	if !q.After.IsZero() {
+		where.WriteString(" AND (name_key(name) > ? OR (name_key(name) = ? AND id > ?))")
+		args = append(args, q.After.Key, q.After.Key, q.After.ID.String())
+	}
+
+	// select one more user than the limit, to know if there is a next page
+	limit := q.limit()
+	args = append(args, limit+1)
+
+	rows, err := us.db.Query("SELECT "+userColumns+" FROM users WHERE "+where.String()+" ORDER BY name_key(name), id LIMIT ?", args...)
+	if err != nil {
+		return nil, Cursor{}, errors.WithStack(err)
+	}
+	defer rows.Close()
+
+	res := make([]UserEntry, 0, limit)
+	for rows.Next() {
+		if len(res) == limit {
+			return res, cursorOf(res[len(res)-1]), nil
+		}
+
+		uid, user, err := scanUser(rows)
+		if err != nil {
+			return nil, Cursor{}, errors.WithStack(err)
+		}
+		res = append(res, UserEntry{ID: uid, User: user})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, Cursor{}, errors.WithStack(err)
+	}
+	return res, Cursor{}, nil
+}
+
+// Close closes the database.
+func (us *SQLiteUsersStore) Close() error {
+	return errors.WithStack(us.db.Close())
+}
+
+func isUniqueViolation(err error) bool {
+	var sqlErr *sqlite.Error
+	return errors.As(err, &sqlErr) && sqlErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
@@ -7,18 +7,47 @@ package chatusers
 import (
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+// Typing indicates the user is typing in a conversation. The receiver is
+// empty for the global chatroom.
+type Typing struct {
+	UserID     UserID
+	ReceiverID UserID
+}
+
+// IsGlobal indicates the user is typing in the global chatroom.
+func (t Typing) IsGlobal() bool { return t.ReceiverID == uuid.Nil }
+
 type TypingIndicator interface {
-	IndicateTyping(uid UserID, typing bool, callback func(typing bool))
+	// IndicateTyping changes the typing state of the user in the conversation
+	// with receiver. The callback is called when the state changes, either
+	// directly or when the user did not indicate typing within the timeout.
+	IndicateTyping(uid, receiver UserID, typing bool, callback func(typing bool))
+	// Typing returns who is typing where.
+	Typing() []Typing
+}
+
+type typingState struct {
+	expires  time.Time
+	callback func(typing bool)
 }
 
 type typingIndicator struct {
-	mut     sync.RWMutex
+	mut     sync.Mutex
 	timeout time.Duration
-	timers  map[UserID]*time.Timer
+	now     func() time.Time
+	state   map[Typing]*typingState
+	timer   *time.Timer // expires the earliest state
+	next    time.Time
 }
 
+// NewTypingIndicator creates a new [TypingIndicator]. A user is no longer
+// typing when it did not indicate typing within timeout. Repeatedly indicating
+// typing only extends the timeout, the callback is called on changes only.
+// A single timer expires the states of all conversations.
 func NewTypingIndicator(timeout time.Duration) TypingIndicator {
 	if timeout == 0 {
 		timeout = 5 * time.Second
@@ -26,41 +55,96 @@ func NewTypingIndicator(timeout time.Duration) TypingIndicator {
 
 	return &typingIndicator{
 		timeout: timeout,
-		timers:  make(map[UserID]*time.Timer, 8),
+		now:     time.Now,
+		state:   make(map[Typing]*typingState, 8),
 	}
 }
 
-func (t *typingIndicator) timer(uid UserID) *time.Timer {
+const panicNilCallback = "chatusers: callback must not be nil!"
+
+func (t *typingIndicator) IndicateTyping(uid, receiver UserID, typing bool, callback func(typing bool)) {
+	if callback == nil {
+		panic(panicNilCallback)
+	}
+
+	key := Typing{UserID: uid, ReceiverID: receiver}
+
+	t.mut.Lock()
+	state, was := t.state[key]
+	if !typing {
+		delete(t.state, key)
+		t.mut.Unlock()
+
+		if was {
+			callback(false)
+		}
+		return
+	}
+
+	if !was {
+		state = new(typingState)
+		t.state[key] = state
+	}
+	state.expires = t.now().Add(t.timeout)
+	state.callback = callback
+	t.schedule(state.expires)
+	t.mut.Unlock()
+
+	if !was {
+		callback(true)
+	}
+}
+
+func (t *typingIndicator) Typing() []Typing {
 	t.mut.Lock()
 	defer t.mut.Unlock()
 
-	if _, has := t.timers[uid]; !has {
-		t.timers[uid] = time.NewTimer(0)
+	res := make([]Typing, 0, len(t.state))
+	for key := range t.state {
+		res = append(res, key)
 	}
-	return t.timers[uid]
+	return res
 }
 
-const panicNilCallback = "chatusers: callback must not be nil!"
+// schedule makes sure the timer fires at or before at. It must be called with
+// mut locked.
+func (t *typingIndicator) schedule(at time.Time) {
+	if t.timer != nil && !t.next.IsZero() && !t.next.After(at) {
+		return
+	}
 
-func (t *typingIndicator) IndicateTyping(uid UserID, typing bool, callback func(typing bool)) {
-	if callback == nil {
-		panic(panicNilCallback)
+	t.next = at
+	if t.timer == nil {
+		t.timer = time.AfterFunc(at.Sub(t.now()), t.expire)
+	} else {
+		t.timer.Reset(at.Sub(t.now()))
 	}
+}
 
-	timer := t.timer(uid)
-	timer.Stop()
+// expire removes all expired states, calls their callbacks and schedules the
+// timer for the next state to expire.
+func (t *typingIndicator) expire() {
+	t.mut.Lock()
+	now := t.now()
+	expired := make([]func(bool), 0, 2)
 
-	if !typing {
-		go callback(false)
-		return
+	var next time.Time
+	for key, state := range t.state {
+		if !now.Before(state.expires) {
+			expired = append(expired, state.callback)
+			delete(t.state, key)
+		} else if next.IsZero() || state.expires.Before(next) {
+			next = state.expires
+		}
 	}
 
-	go callback(true)
-	timer.Reset(t.timeout)
+	t.next = time.Time{}
+	if !next.IsZero() {
+		t.schedule(next)
+	}
+	t.mut.Unlock()
 
-	go func() {
-		<-timer.C
-		timer.Stop()
+	for _, callback := range expired {
 		callback(false)
-	}()
+	}
 }
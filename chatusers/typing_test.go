@@ -0,0 +1,79 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type typingCalls struct {
+	mut   sync.Mutex
+	calls []bool
+}
+
+func (tc *typingCalls) callback(typing bool) {
+	tc.mut.Lock()
+	tc.calls = append(tc.calls, typing)
+	tc.mut.Unlock()
+}
+
+func (tc *typingCalls) get() []bool {
+	tc.mut.Lock()
+	defer tc.mut.Unlock()
+	return append([]bool(nil), tc.calls...)
+}
+
+func TestTypingIndicator_IndicateTyping(t *testing.T) {
+	t.Run("throttle", func(t *testing.T) {
+		ti := NewTypingIndicator(time.Hour)
+		uid := uuid.New()
+
+		var tc typingCalls
+		ti.IndicateTyping(uid, uuid.Nil, true, tc.callback)
+		ti.IndicateTyping(uid, uuid.Nil, true, tc.callback)
+		ti.IndicateTyping(uid, uuid.Nil, true, tc.callback)
+		ti.IndicateTyping(uid, uuid.Nil, false, tc.callback)
+		ti.IndicateTyping(uid, uuid.Nil, false, tc.callback)
+		assert.Equal(t, []bool{true, false}, tc.get())
+	})
+	t.Run("per conversation", func(t *testing.T) {
+		ti := NewTypingIndicator(time.Hour)
+		uid, receiver := uuid.New(), uuid.New()
+
+		var global, direct typingCalls
+		ti.IndicateTyping(uid, uuid.Nil, true, global.callback)
+		ti.IndicateTyping(uid, receiver, true, direct.callback)
+		assert.ElementsMatch(t, []Typing{
+			{UserID: uid},
+			{UserID: uid, ReceiverID: receiver},
+		}, ti.Typing())
+
+		ti.IndicateTyping(uid, receiver, false, direct.callback)
+		assert.Equal(t, []bool{true}, global.get())
+		assert.Equal(t, []bool{true, false}, direct.get())
+		assert.Equal(t, []Typing{{UserID: uid}}, ti.Typing())
+	})
+	t.Run("timeout", func(t *testing.T) {
+		ti := NewTypingIndicator(10 * time.Millisecond)
+		uid := uuid.New()
+
+		var first, second typingCalls
+		ti.IndicateTyping(uid, uuid.Nil, true, first.callback)
+		time.Sleep(5 * time.Millisecond)
+		ti.IndicateTyping(uuid.New(), uuid.Nil, true, second.callback)
+
+		assert.Eventually(t, func() bool {
+			return len(first.get()) == 2 && len(second.get()) == 2
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, []bool{true, false}, first.get())
+		assert.Equal(t, []bool{true, false}, second.get())
+		assert.Empty(t, ti.Typing())
+	})
+}
@@ -7,6 +7,8 @@ package chatusers
 import (
 	"image/color"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-pogo/errors"
 	"github.com/google/uuid"
@@ -43,6 +45,8 @@ type User struct {
 	UserDetails
 	Flags  Flag
 	Status Status
+	Role   Role
+	Custom CustomStatus
 }
 
 type UserDetails struct {
@@ -50,12 +54,29 @@ type UserDetails struct {
 	Initials string
 	Color1   color.Color // primary color
 	Color2   color.Color // secondary color
+	Picture  string      // url of avatar picture
+	Profile  Profile
+}
+
+// Profile contains optional details the user shares about itself.
+type Profile struct {
+	Pronouns string
+	Bio      string
+	TimeZone string // IANA time zone name, e.g. Europe/Amsterdam
 }
 
+const (
+	MaxPronounsLength = 32
+	MaxBioLength      = 160
+)
+
 const (
 	ErrEmptyName            errors.Msg = "user name should not be empty"
 	ErrInvalidNameBot       errors.Msg = "only bots may use a 'bot' suffix"
 	ErrUnsufficientContrast errors.Msg = "unsufficient color contrast"
+	ErrPronounsTooLong      errors.Msg = "pronouns should not be longer than 32 characters"
+	ErrBioTooLong           errors.Msg = "bio should not be longer than 160 characters"
+	ErrInvalidTimeZone      errors.Msg = "time zone should be a valid IANA time zone name"
 )
 
 type UserOption func(u *User) error
@@ -72,29 +93,82 @@ func NewUser(name string, opts ...UserOption) (*User, error) {
 	for _, opt := range opts {
 		err = errors.Append(err, opt(&user))
 	}
-
-	if user.Name == "" {
-		return nil, errors.New(ErrEmptyName)
-	} else if strings.HasSuffix(user.Name, "bot") && !user.Flags.Has(Flag_IsBot) {
-		return nil, errors.New(ErrInvalidNameBot)
+	if err != nil {
+		return nil, err
 	}
 
-	if user.Initials == "" {
-		user.Initials = InitialsFromName(user.Name)
+	if user.Role == Role_None {
+		if user.Flags.Has(Flag_IsBot) {
+			user.Role = Role_Bot
+		} else {
+			user.Role = Role_Member
+		}
 	}
 
 	// make sure colors are set
 	if user.Color1 == nil {
 		user.Color1, user.Color2 = RandomColors()
-	} else if user.Color2 == nil {
-		user.Color2 = SecondaryColor(user.Color1)
-		//} else {
-		// check color contrast?
 	}
-
+	if err = user.validateDetails(); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
+// WithDetails returns a copy of the user with its details replaced by
+// details. They are validated with the same rules as [NewUser]. The current
+// colors are kept when details has no primary color. The picture is
+// read-only and is never replaced.
+func (u User) WithDetails(details UserDetails) (User, error) {
+	details.Picture = u.Picture
+	if details.Color1 == nil {
+		details.Color1, details.Color2 = u.Color1, u.Color2
+	}
+
+	u.UserDetails = details
+	if err := u.validateDetails(); err != nil {
+		return User{}, err
+	}
+	return u, nil
+}
+
+// validateDetails validates the user's details and sets the initials and
+// secondary color when they are empty.
+func (u *User) validateDetails() error {
+	if u.Name == "" {
+		return errors.New(ErrEmptyName)
+	} else if strings.HasSuffix(u.Name, "bot") && !u.Flags.Has(Flag_IsBot) {
+		return errors.New(ErrInvalidNameBot)
+	}
+
+	if u.Initials == "" {
+		u.Initials = InitialsFromName(u.Name)
+	}
+
+	if u.Color2 == nil {
+		u.Color2 = SecondaryColor(u.Color1)
+	} else if err := CheckContrast(u.Color1, u.Color2); err != nil {
+		return err
+	}
+	return u.Profile.validate()
+}
+
+func (p Profile) validate() error {
+	if utf8.RuneCountInString(p.Pronouns) > MaxPronounsLength {
+		return errors.New(ErrPronounsTooLong)
+	}
+	if utf8.RuneCountInString(p.Bio) > MaxBioLength {
+		return errors.New(ErrBioTooLong)
+	}
+	if p.TimeZone != "" {
+		// Local depends on the server and is not a valid choice for users
+		if _, err := time.LoadLocation(p.TimeZone); err != nil || p.TimeZone == "Local" {
+			return errors.New(ErrInvalidTimeZone)
+		}
+	}
+	return nil
+}
+
 func InitialsFromName(name string) string {
 	if name == "" {
 		return ""
@@ -0,0 +1,120 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withColors(c1, c2 color.Color) UserOption {
+	return func(u *User) error {
+		u.Color1, u.Color2 = c1, c2
+		return nil
+	}
+}
+
+func TestNewUser(t *testing.T) {
+	t.Run("secondary color", func(t *testing.T) {
+		user, err := NewUser("foo", withColors(color.RGBA{R: 255, G: 255}, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, black, user.Color2)
+	})
+	t.Run("insufficient contrast", func(t *testing.T) {
+		user, err := NewUser("foo", withColors(color.RGBA{R: 255, G: 255}, white))
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ErrUnsufficientContrast)
+
+		var contrastErr *ContrastError
+		assert.ErrorAs(t, err, &contrastErr)
+		assert.Less(t, contrastErr.Ratio, MinContrastRatio)
+	})
+	t.Run("random colors", func(t *testing.T) {
+		for range 100 {
+			user, err := NewUser("foo")
+			assert.NoError(t, err)
+			assert.NoError(t, CheckContrast(user.Color1, user.Color2))
+		}
+	})
+}
+
+func TestUser_WithDetails(t *testing.T) {
+	user, err := NewUser("foo", withColors(color.RGBA{B: 128}, white))
+	require.NoError(t, err)
+	user.Picture = "https://example.com/foo.png"
+
+	t.Run("keep colors", func(t *testing.T) {
+		have, err := user.WithDetails(UserDetails{Name: "bar baz"})
+		assert.NoError(t, err)
+		assert.Equal(t, UserDetails{
+			Name:     "bar baz",
+			Initials: "BB",
+			Color1:   user.Color1,
+			Color2:   user.Color2,
+			Picture:  user.Picture,
+		}, have.UserDetails)
+		assert.Equal(t, "foo", user.Name, "original should not change")
+	})
+	t.Run("secondary color", func(t *testing.T) {
+		have, err := user.WithDetails(UserDetails{
+			Name:   "foo",
+			Color1: color.RGBA{R: 255, G: 255},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, black, have.Color2)
+	})
+	t.Run("profile", func(t *testing.T) {
+		profile := Profile{Pronouns: "she/her", Bio: strings.Repeat("ä", MaxBioLength), TimeZone: "America/New_York"}
+		have, err := user.WithDetails(UserDetails{Name: "foo", Profile: profile})
+		assert.NoError(t, err)
+		assert.Equal(t, profile, have.Profile)
+	})
+	t.Run("read-only picture", func(t *testing.T) {
+		have, err := user.WithDetails(UserDetails{Name: "foo", Picture: "https://example.com/bar.png"})
+		assert.NoError(t, err)
+		assert.Equal(t, user.Picture, have.Picture)
+	})
+
+	invalid := map[string]struct {
+		details UserDetails
+		wantErr error
+	}{
+		"empty name": {
+			details: UserDetails{},
+			wantErr: ErrEmptyName,
+		},
+		"bot suffix": {
+			details: UserDetails{Name: "foobot"},
+			wantErr: ErrInvalidNameBot,
+		},
+		"pronouns too long": {
+			details: UserDetails{Name: "foo", Profile: Profile{Pronouns: strings.Repeat("x", MaxPronounsLength+1)}},
+			wantErr: ErrPronounsTooLong,
+		},
+		"bio too long": {
+			details: UserDetails{Name: "foo", Profile: Profile{Bio: strings.Repeat("ä", MaxBioLength+1)}},
+			wantErr: ErrBioTooLong,
+		},
+		"invalid time zone": {
+			details: UserDetails{Name: "foo", Profile: Profile{TimeZone: "Mars/Olympus_Mons"}},
+			wantErr: ErrInvalidTimeZone,
+		},
+		"insufficient contrast": {
+			details: UserDetails{Name: "foo", Color1: white, Color2: color.RGBA{R: 255, G: 255}},
+			wantErr: ErrUnsufficientContrast,
+		},
+	}
+	for name, tc := range invalid {
+		t.Run(name, func(t *testing.T) {
+			have, err := user.WithDetails(tc.details)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.True(t, have.IsZero())
+		})
+	}
+}
@@ -5,6 +5,9 @@
 package chatusers
 
 import (
+	"bytes"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/go-pogo/errors"
@@ -16,13 +19,22 @@ const (
 	ErrNameAlreadyExists errors.Msg = "name is already in use"
 )
 
+// UsersStore stores the users of the chatroom. Names are unique, based on
+// their [NameKey].
 type UsersStore interface {
 	All() map[UserID]User
 	Has(id UserID) bool
 	Get(id UserID) (User, error)
 	Add(user User) (UserID, error)
 	Update(id UserID, user User) error
+	// UpdateFunc atomically changes the stored user using fn and returns the
+	// user before and after the change. The user is not changed when fn
+	// returns an error. fn must not call any methods of the store.
+	UpdateFunc(id UserID, fn func(user *User) error) (before, after User, err error)
 	Delete(id UserID) (User, bool)
+	// List returns the users selected by the query, and the cursor to the
+	// next page. The cursor is zero when there are no more users.
+	List(q ListQuery) ([]UserEntry, Cursor, error)
 }
 
 type usersStore struct {
@@ -68,8 +80,9 @@ func (us *usersStore) Add(user User) (UserID, error) {
 	us.mut.Lock()
 	defer us.mut.Unlock()
 
+	key := NameKey(user.Name)
 	for _, u := range us.users {
-		if u.Name == user.Name {
+		if NameKey(u.Name) == key {
 			return uuid.Nil, errors.New(ErrNameAlreadyExists)
 		}
 	}
@@ -87,11 +100,47 @@ func (us *usersStore) Update(id UserID, user User) error {
 	if !ok {
 		return errors.New(ErrUserNotFound)
 	}
+	if us.nameInUse(id, user.Name) {
+		return errors.New(ErrNameAlreadyExists)
+	}
 
 	us.users[id] = user
 	return nil
 }
 
+func (us *usersStore) UpdateFunc(id UserID, fn func(user *User) error) (User, User, error) {
+	us.mut.Lock()
+	defer us.mut.Unlock()
+
+	before, ok := us.users[id]
+	if !ok {
+		return before, before, errors.New(ErrUserNotFound)
+	}
+
+	after := before
+	if err := fn(&after); err != nil {
+		return before, before, err
+	}
+	if after.Name != before.Name && us.nameInUse(id, after.Name) {
+		return before, before, errors.New(ErrNameAlreadyExists)
+	}
+
+	us.users[id] = after
+	return before, after, nil
+}
+
+// nameInUse indicates if a user, other than the user with id, has a name
+// with the same [NameKey] as name. It must be called while holding the lock.
+func (us *usersStore) nameInUse(id UserID, name string) bool {
+	key := NameKey(name)
+	for uid, u := range us.users {
+		if uid != id && NameKey(u.Name) == key {
+			return true
+		}
+	}
+	return false
+}
+
 func (us *usersStore) Delete(id UserID) (User, bool) {
 	us.mut.Lock()
 	defer us.mut.Unlock()
@@ -102,3 +151,42 @@ func (us *usersStore) Delete(id UserID) (User, bool) {
 	}
 	return user, ok
 }
+
+func (us *usersStore) List(q ListQuery) ([]UserEntry, Cursor, error) {
+	type keyed struct {
+		key string
+		UserEntry
+	}
+
+	prefix := NameKey(q.Prefix)
+	list := make([]keyed, 0, 8)
+
+	us.mut.RLock()
+	for id, u := range us.users {
+		key := NameKey(u.Name)
+		if !q.matches(key, prefix, u) {
+			continue
+		}
+		if !q.After.IsZero() && !q.After.before(key, id) {
+			continue
+		}
+		list = append(list, keyed{key: key, UserEntry: UserEntry{ID: id, User: u}})
+	}
+	us.mut.RUnlock()
+
+	slices.SortFunc(list, func(a, b keyed) int {
+		if c := strings.Compare(a.key, b.key); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+
+	res := make([]UserEntry, 0, min(len(list), q.limit()))
+	for _, k := range list {
+		if len(res) == q.limit() {
+			return res, cursorOf(res[len(res)-1]), nil
+		}
+		res = append(res, k.UserEntry)
+	}
+	return res, Cursor{}, nil
+}
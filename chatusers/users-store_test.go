@@ -0,0 +1,283 @@
+// Copyright (c) 2025, Roel Schut. All rights reserved.
+// Use of this source code is governed by a GPL-style
+// license that can be found in the LICENSE file.
+
+package chatusers
+
+import (
+	"database/sql"
+	"image/color"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersStore(t *testing.T) {
+	testUsersStore(t, func(t *testing.T) UsersStore {
+		return NewUsersStore(1)
+	})
+}
+
+func TestSQLiteUsersStore(t *testing.T) {
+	testUsersStore(t, func(t *testing.T) UsersStore {
+		store, err := NewSQLiteUsersStore(":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	})
+
+	t.Run("persist", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "users.db")
+		store, err := NewSQLiteUsersStore(file)
+		require.NoError(t, err)
+
+		uid, err := store.Add(testUser("foo"))
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		// reopening applies no migrations
+		store, err = NewSQLiteUsersStore(file)
+		require.NoError(t, err)
+		defer store.Close()
+
+		have, err := store.Get(uid)
+		assert.NoError(t, err)
+		assert.Equal(t, testUser("foo"), have)
+	})
+}
+
+func TestSQLiteUsersStore_migrateNameKeys(t *testing.T) {
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "users.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// names which did not have the same key before migration 5
+	require.NoError(t, migrate(db, usersMigrations[:4]))
+	_, err = db.Exec("DROP INDEX users_name_key")
+	require.NoError(t, err)
+	for _, name := range []string{"Hanna", "Ηanna", "Bob", "ΗANNA"} {
+		_, err = db.Exec("INSERT INTO users (id, name) VALUES (?, ?)", uuid.NewString(), name)
+		require.NoError(t, err)
+	}
+	_, err = db.Exec("CREATE INDEX users_name_key ON users (name)")
+	require.NoError(t, err)
+
+	require.NoError(t, migrate(db, usersMigrations))
+
+	rows, err := db.Query("SELECT name FROM users ORDER BY rowid")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"Hanna", "Ηanna (2)", "Bob", "ΗANNA (3)"}, names, "users are renamed, not deleted")
+}
+
+func testUser(name string) User {
+	return User{
+		UserDetails: UserDetails{
+			Name:     name,
+			Initials: InitialsFromName(name),
+			Color1:   color.RGBA{R: 10, G: 20, B: 30, A: 255},
+			Color2:   color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			Picture:  "https://example.com/" + name + ".png",
+			Profile: Profile{
+				Pronouns: "they/them",
+				Bio:      "Hi, I'm " + name,
+				TimeZone: "Europe/Amsterdam",
+			},
+		},
+		Flags:  Flag_NoDirectMessages,
+		Status: Status_Busy,
+		Role:   Role_Moderator,
+		Custom: CustomStatus{
+			Text:    "Out for lunch",
+			Emoji:   "🍔",
+			Expires: time.Date(2030, 1, 2, 13, 30, 0, 0, time.UTC),
+		},
+	}
+}
+
+// testUsersStore is the conformance test suite every [UsersStore]
+// implementation should pass.
+func testUsersStore(t *testing.T, newStore func(t *testing.T) UsersStore) {
+	t.Run("add and get", func(t *testing.T) {
+		store := newStore(t)
+		uid, err := store.Add(testUser("foo"))
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, uid)
+
+		assert.True(t, store.Has(uid))
+		have, err := store.Get(uid)
+		assert.NoError(t, err)
+		assert.Equal(t, testUser("foo"), have)
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		store := newStore(t)
+		uid := uuid.New()
+
+		assert.False(t, store.Has(uid))
+		_, err := store.Get(uid)
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.ErrorIs(t, store.Update(uid, testUser("foo")), ErrUserNotFound)
+		_, ok := store.Delete(uid)
+		assert.False(t, ok)
+	})
+	t.Run("name already exists", func(t *testing.T) {
+		store := newStore(t)
+		_, err := store.Add(testUser("foo"))
+		require.NoError(t, err)
+		_, err = store.Add(testUser("foo"))
+		assert.ErrorIs(t, err, ErrNameAlreadyExists)
+
+		uid, err := store.Add(testUser("bar"))
+		require.NoError(t, err)
+		assert.ErrorIs(t, store.Update(uid, testUser("foo")), ErrNameAlreadyExists)
+	})
+	t.Run("name looks alike", func(t *testing.T) {
+		store := newStore(t)
+		uid, err := store.Add(testUser("Alice"))
+		require.NoError(t, err)
+
+		for _, name := range []string{"alice", "ALICE", "\u0410lice"} {
+			_, err = store.Add(testUser(name))
+			assert.ErrorIs(t, err, ErrNameAlreadyExists, name)
+		}
+
+		// users may change the case of their own name
+		assert.NoError(t, store.Update(uid, testUser("alice")))
+	})
+	t.Run("update", func(t *testing.T) {
+		store := newStore(t)
+		uid, err := store.Add(testUser("foo"))
+		require.NoError(t, err)
+
+		user := testUser("bar")
+		user.Status = Status_Away
+		require.NoError(t, store.Update(uid, user))
+
+		have, err := store.Get(uid)
+		assert.NoError(t, err)
+		assert.Equal(t, user, have)
+	})
+	t.Run("update func", func(t *testing.T) {
+		store := newStore(t)
+		uid, err := store.Add(testUser("foo"))
+		require.NoError(t, err)
+		_, err = store.Add(testUser("bar"))
+		require.NoError(t, err)
+
+		before, after, err := store.UpdateFunc(uid, func(user *User) error {
+			user.Status = Status_Away
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testUser("foo"), before)
+		assert.Equal(t, Status_Away, after.Status)
+
+		have, err := store.Get(uid)
+		assert.NoError(t, err)
+		assert.Equal(t, after, have)
+
+		_, _, err = store.UpdateFunc(uid, func(user *User) error {
+			user.Name = "bar"
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrNameAlreadyExists)
+
+		_, _, err = store.UpdateFunc(uid, func(user *User) error {
+			user.Status = Status_Default
+			return errors.New("abort")
+		})
+		assert.Error(t, err)
+		have, err = store.Get(uid)
+		assert.NoError(t, err)
+		assert.Equal(t, after, have)
+
+		_, _, err = store.UpdateFunc(uuid.New(), func(*User) error { return nil })
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+	t.Run("all and delete", func(t *testing.T) {
+		store := newStore(t)
+		foo, err := store.Add(testUser("foo"))
+		require.NoError(t, err)
+		bar, err := store.Add(testUser("bar"))
+		require.NoError(t, err)
+
+		assert.Equal(t, map[UserID]User{
+			foo: testUser("foo"),
+			bar: testUser("bar"),
+		}, store.All())
+
+		have, ok := store.Delete(foo)
+		assert.True(t, ok)
+		assert.Equal(t, testUser("foo"), have)
+		assert.False(t, store.Has(foo))
+		assert.Len(t, store.All(), 1)
+	})
+	t.Run("list", func(t *testing.T) {
+		store := newStore(t)
+		names := []string{"Carol", "bob", "Alice", "Bobby", "\u0412ert", "dave"}
+		for i, name := range names {
+			user := testUser(name)
+			if i%2 == 0 {
+				user.Flags = Flag_None
+				user.Status = Status_Default
+			}
+			_, err := store.Add(user)
+			require.NoError(t, err)
+		}
+
+		listNames := func(q ListQuery) ([]string, Cursor) {
+			list, next, err := store.List(q)
+			require.NoError(t, err)
+
+			res := make([]string, 0, len(list))
+			for _, entry := range list {
+				res = append(res, entry.Name)
+			}
+			return res, next
+		}
+
+		have, next := listNames(ListQuery{})
+		assert.Equal(t, []string{"Alice", "\u0412ert", "bob", "Bobby", "Carol", "dave"}, have)
+		assert.True(t, next.IsZero())
+
+		have, _ = listNames(ListQuery{Prefix: "BO"})
+		assert.Equal(t, []string{"bob", "Bobby"}, have, "prefix ignores case")
+		have, _ = listNames(ListQuery{Prefix: "\u0412"})
+		assert.Equal(t, []string{"\u0412ert", "bob", "Bobby"}, have, "prefix ignores look-alikes")
+
+		have, _ = listNames(ListQuery{Flags: Flag_NoDirectMessages})
+		assert.Equal(t, []string{"bob", "Bobby", "dave"}, have)
+		have, _ = listNames(ListQuery{Statuses: []Status{Status_Busy, Status_Away}})
+		assert.Equal(t, []string{"bob", "Bobby", "dave"}, have)
+
+		var pages [][]string
+		for q := (ListQuery{Limit: 4}); ; {
+			have, next = listNames(q)
+			pages = append(pages, have)
+			if next.IsZero() {
+				break
+			}
+
+			token := next.String()
+			q.After, _ = ParseCursor(token)
+			require.Equal(t, next, q.After)
+		}
+		assert.Equal(t, [][]string{
+			{"Alice", "\u0412ert", "bob", "Bobby"},
+			{"Carol", "dave"},
+		}, pages)
+	})
+}
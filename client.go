@@ -7,8 +7,10 @@ package chatroom
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/go-pogo/easytls"
 	"github.com/go-pogo/serv"
 	apiv1 "github.com/roeldev/demo-chatroom/api/v1"
 	"github.com/roeldev/demo-chatroom/api/v1/apiv1connect"
@@ -19,21 +21,44 @@ type authServiceClient = apiv1connect.AuthServiceClient
 type userServiceClient = apiv1connect.UserServiceClient
 
 type ClientConfig struct {
-	ServerHost string             `env:"API_SERVER_HOST" default:"localhost"`
-	ServerPort serv.Port          `env:"API_SERVER_PORT" default:"8080"`
+	ServerHost string    `env:"API_SERVER_HOST" default:"localhost"`
+	ServerPort serv.Port `env:"API_SERVER_PORT" default:"8080"`
+	// APIKey is the API key of a pre-registered bot.
+	APIKey string `env:"API_KEY"`
+	// TLS is used to connect over https. A certificate and key file can be
+	// provided to join as a bot using a client certificate.
+	TLS        easytls.Config     `env:"API_TLS,include"`
 	HTTPClient connect.HTTPClient `env:"-"`
 }
 
-func (c ClientConfig) httpClient() connect.HTTPClient {
-	if c.HTTPClient == nil {
-		return http.DefaultClient
+func (c ClientConfig) useTLS() bool {
+	return c.TLS.CACertFile != "" || c.TLS.CertFile != "" || c.TLS.InsecureSkipVerify
+}
+
+func (c ClientConfig) httpClient() (connect.HTTPClient, error) {
+	if c.HTTPClient != nil {
+		return c.HTTPClient, nil
+	}
+	if !c.useTLS() {
+		return http.DefaultClient, nil
+	}
+
+	tlsConf, err := c.TLS.Client()
+	if err != nil {
+		return nil, err
 	}
-	return c.HTTPClient
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConf
+	return &http.Client{Transport: transport}, nil
 }
 
 func (c ClientConfig) BaseURL() string {
-	// todo: tls support
-	return "http://" + serv.JoinHostPort(c.ServerHost, c.ServerPort)
+	scheme := "http://"
+	if c.useTLS() {
+		scheme = "https://"
+	}
+	return scheme + serv.JoinHostPort(c.ServerHost, c.ServerPort)
 }
 
 type Client struct {
@@ -41,17 +66,23 @@ type Client struct {
 	userServiceClient
 
 	baseURL     string
+	apiKey      string
 	httpClient  connect.HTTPClient
-	interceptor connect.Interceptor
+	interceptor *apiv1connect.ClientInterceptor
 }
 
-func NewClient(conf ClientConfig) *Client {
-	client := conf.httpClient()
+func NewClient(conf ClientConfig) (*Client, error) {
+	client, err := conf.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
 	baseURL := conf.BaseURL()
 	interceptor := apiv1connect.NewClientInterceptor("")
 
 	return &Client{
 		baseURL:     baseURL,
+		apiKey:      conf.APIKey,
 		httpClient:  client,
 		interceptor: interceptor,
 
@@ -67,7 +98,7 @@ func NewClient(conf ClientConfig) *Client {
 			//connect.WithGRPC(),
 			connect.WithInterceptors(interceptor),
 		),
-	}
+	}, nil
 }
 
 func (c *Client) BaseURL() string { return c.baseURL }
@@ -76,14 +107,40 @@ func (c *Client) HTTPClient() connect.HTTPClient { return c.httpClient }
 
 func (c *Client) Interceptor() connect.Interceptor { return c.interceptor }
 
+// Login joins the chatroom as a pre-registered bot, using either the
+// configured API key or client certificate.
 func (c *Client) Login(ctx context.Context, user *apiv1.UserDetails) error {
-	_, err := c.Join(ctx, connect.NewRequest(&apiv1.JoinRequest{
-		User:  user,
-		Flags: apiv1.UserFlag_USER_FLAG_IS_BOT,
+	req := connect.NewRequest(&apiv1.JoinBotRequest{User: user})
+	if c.apiKey != "" {
+		req.Header().Set(apiv1connect.APIKeyHeader, c.apiKey)
+	}
+
+	_, err := c.JoinBot(ctx, req)
+	return err
+}
+
+// RenewToken renews the access token using the refresh token received from
+// the last Join or Renew call.
+func (c *Client) RenewToken(ctx context.Context) error {
+	_, err := c.Renew(ctx, connect.NewRequest(&apiv1.RenewRequest{
+		RefreshToken: c.interceptor.RefreshToken(),
 	}))
 	return err
 }
 
+// TokenExpires returns the time at which the current access token expires.
+// Call RenewToken before then to keep the session's streams open.
+func (c *Client) TokenExpires() time.Time { return c.interceptor.TokenExpires() }
+
+// RenewKeepalive renews the access token and sends it over the keepalive stream,
+// which extends the lifetime of all open streams.
+func (c *Client) RenewKeepalive(ctx context.Context, stream *connect.ClientStreamForClient[apiv1.KeepaliveRequest, emptypb.Empty]) error {
+	if err := c.RenewToken(ctx); err != nil {
+		return err
+	}
+	return stream.Send(&apiv1.KeepaliveRequest{Token: c.interceptor.Token()})
+}
+
 func (c *Client) Logout(ctx context.Context) error {
 	_, err := c.Leave(ctx, connect.NewRequest(&emptypb.Empty{}))
 	return err
@@ -40,7 +40,7 @@ func main() {
 
 	// run services
 	ctx := context.Background()
-	if err = webapp.Run(ctx, base.Run); err != nil && !errors.Is(err, context.Canceled) {
+	if err = webapp.Run(ctx, base.Run, service.Run); err != nil && !errors.Is(err, context.Canceled) {
 		log.Err(err).Msg("error during execution")
 	}
 
@@ -66,6 +66,34 @@ func unmarshal(conf *chatroom.Config) error {
 		_ = conf.Server.TLS.CACertFile.Set(loader.PrefixDir(conf.Server.TLS.CACertFile.String()))
 		conf.Server.TLS.CertFile = loader.PrefixDir(conf.Server.TLS.CertFile)
 		conf.Server.TLS.KeyFile = loader.PrefixDir(conf.Server.TLS.KeyFile)
+
+		if conf.Accounts.File != "" {
+			conf.Accounts.File = loader.PrefixDir(conf.Accounts.File)
+		}
+		if conf.Avatars.Dir != "" {
+			conf.Avatars.Dir = loader.PrefixDir(conf.Avatars.Dir)
+		}
+		if conf.UsersDB != "" {
+			conf.UsersDB = loader.PrefixDir(conf.UsersDB)
+		}
+		if conf.Auth.AuditLog != "" && conf.Auth.AuditLog != "stdout" {
+			conf.Auth.AuditLog = loader.PrefixDir(conf.Auth.AuditLog)
+		}
+		if conf.Auth.BotsFile != "" {
+			conf.Auth.BotsFile = loader.PrefixDir(conf.Auth.BotsFile)
+		}
+		if conf.Auth.KeyringFile != "" {
+			conf.Auth.KeyringFile = loader.PrefixDir(conf.Auth.KeyringFile)
+		}
+		if conf.Auth.RefreshFile != "" {
+			conf.Auth.RefreshFile = loader.PrefixDir(conf.Auth.RefreshFile)
+		}
+		if conf.Auth.RevocationFile != "" {
+			conf.Auth.RevocationFile = loader.PrefixDir(conf.Auth.RevocationFile)
+		}
+		for i, file := range conf.Auth.KeyFiles {
+			conf.Auth.KeyFiles[i] = loader.PrefixDir(file)
+		}
 	}
 	return nil
 }
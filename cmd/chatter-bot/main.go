@@ -22,7 +22,10 @@ func main() {
 	}
 
 	log := logger.New(conf.Logger)
-	bot := chatbot.NewChatterBot(conf.Client, log.Logger)
+	bot, err := chatbot.NewChatterBot(conf.Client, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create bot")
+	}
 
 	ctx := context.Background()
 	if err := bot.Login(ctx, nil); err != nil {
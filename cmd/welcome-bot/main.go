@@ -23,7 +23,10 @@ func main() {
 	}
 
 	log := logger.New(conf.Logger)
-	bot := chatbot.NewWelcomeBot(conf.Client, log.Logger)
+	bot, err := chatbot.NewWelcomeBot(conf.Client, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create bot")
+	}
 
 	ctx := context.Background()
 	if err := bot.Login(ctx, &apiv1.UserDetails{
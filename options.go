@@ -5,6 +5,8 @@
 package chatroom
 
 import (
+	"github.com/roeldev/demo-chatroom/chataccounts"
+	"github.com/roeldev/demo-chatroom/chatauth"
 	"github.com/roeldev/demo-chatroom/chatusers"
 )
 
@@ -16,3 +18,45 @@ func WithUsers(users chatusers.UsersStore) Option {
 		return nil
 	}
 }
+
+func WithRevocationStore(store chatauth.RevocationStore) Option {
+	return func(svc *Service) error {
+		svc.revoked = store
+		return nil
+	}
+}
+
+func WithRefreshStore(store chatauth.RefreshStore) Option {
+	return func(svc *Service) error {
+		svc.refresh = store
+		return nil
+	}
+}
+
+func WithBots(bots *chatauth.Bots) Option {
+	return func(svc *Service) error {
+		svc.bots = bots
+		return nil
+	}
+}
+
+func WithIdentityVerifier(v chatauth.IdentityVerifier) Option {
+	return func(svc *Service) error {
+		svc.oidc = v
+		return nil
+	}
+}
+
+func WithAccountsStore(store chataccounts.AccountsStore) Option {
+	return func(svc *Service) error {
+		svc.account = store
+		return nil
+	}
+}
+
+func WithAuditSink(sink chatauth.AuditSink) Option {
+	return func(svc *Service) error {
+		svc.audit = sink
+		return nil
+	}
+}